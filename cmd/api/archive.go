@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+
+	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/service"
+)
+
+// runExport реализует подкоманду:
+//
+//	api export [-file backup.jsonl]
+//
+// Архив пишется в файл или в stdout.
+func runExport(ctx context.Context, cfg Config, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	file := fs.String("file", "-", "output file ('-' for stdout)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	dbPool, err := openPool(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+	svc := service.NewService(repo.NewPostgresRepository(dbPool))
+
+	if *file == "-" {
+		return svc.Export(ctx, stdout)
+	}
+	f, err := os.Create(*file)
+	if err != nil {
+		return err
+	}
+	if err := svc.Export(ctx, f); err != nil {
+		f.Close()
+		// Не оставляем обрезанный архив.
+		_ = os.Remove(*file)
+		return err
+	}
+	return f.Close()
+}
+
+// runRestore реализует подкоманду:
+//
+//	api restore [-file backup.jsonl]
+//
+// Восстановление возможно только в пустую БД. Отчёт печатается в stdout в формате JSON.
+func runRestore(ctx context.Context, cfg Config, args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	file := fs.String("file", "-", "archive file ('-' for stdin)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	input := stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	dbPool, err := openPool(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+
+	svc := service.NewService(repo.NewPostgresRepository(dbPool))
+	report, err := svc.Restore(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/handler"
+	repo "github.com/trainee/review-service/internal/repository"
+)
+
+// JWTConfig — проверка JWT корпоративного SSO (JWT_*). Включается, если задан JWKS.
+type JWTConfig struct {
+	JWKSFile      string
+	JWKSURL       string
+	Issuer        string
+	Audience      string
+	UserClaim     string
+	OrgClaim      string
+	DefaultScopes []string
+}
+
+// authConfig собирает аутентификацию: API токены и, если настроен JWKS, JWT.
+// Authenticator общий для HTTP и gRPC; nil — аутентификация выключена.
+func authConfig(cfg Config, tokenStore repo.TokenRepository) (*handler.AuthConfig, error) {
+	if !cfg.AuthEnabled {
+		return nil, nil
+	}
+
+	tokens := auth.NewTokens(tokenStore)
+	chain := auth.Chain{tokens}
+
+	jwtCfg := cfg.JWT
+	if jwtCfg.JWKSFile != "" && jwtCfg.JWKSURL != "" {
+		return nil, errors.New("set only one of JWT_JWKS_FILE and JWT_JWKS_URL")
+	}
+	var keys auth.KeySource
+	switch {
+	case jwtCfg.JWKSFile != "":
+		static, err := auth.LoadJWKSFile(jwtCfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = static
+	case jwtCfg.JWKSURL != "":
+		keys = auth.NewRemoteJWKS(jwtCfg.JWKSURL)
+	}
+	if keys != nil {
+		if err := auth.ValidateScopes(jwtCfg.DefaultScopes); err != nil {
+			return nil, err
+		}
+		jwtAuth, err := auth.NewJWTAuthenticator(auth.JWTConfig{
+			Keys:          keys,
+			Issuer:        jwtCfg.Issuer,
+			Audience:      jwtCfg.Audience,
+			UserClaim:     jwtCfg.UserClaim,
+			OrgClaim:      jwtCfg.OrgClaim,
+			DefaultScopes: jwtCfg.DefaultScopes,
+		})
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, jwtAuth)
+	}
+
+	return &handler.AuthConfig{
+		Authenticator: chain,
+		Tokens:        tokens,
+		PublicPaths:   cfg.AuthPublicPaths,
+	}, nil
+}
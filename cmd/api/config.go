@@ -0,0 +1,615 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gopkg.in/yaml.v3"
+
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/handler"
+	"github.com/trainee/review-service/internal/health"
+	"github.com/trainee/review-service/internal/logging"
+	"github.com/trainee/review-service/internal/tenant"
+	"github.com/trainee/review-service/internal/tracing"
+)
+
+type Config struct {
+	// Storage — хранилище данных (STORAGE): postgres или memory.
+	Storage  string
+	DSN      string
+	Port     string
+	Listener net.Listener
+
+	// GRPCPort — порт gRPC API (GRPC_PORT); пустое значение или "0" без GRPCListener
+	// отключают gRPC.
+	GRPCPort     string
+	GRPCListener net.Listener
+
+	// AutoMigrate применяет встроенные миграции при старте (DB_AUTO_MIGRATE);
+	// без него сервер не запускается на устаревшей схеме.
+	AutoMigrate bool
+	// RowLevelSecurity передаёт организацию в БД для политик RLS (DB_ROW_LEVEL_SECURITY).
+	RowLevelSecurity bool
+	// Org — организация, с которой работают подкоманды CLI (ORG_ID).
+	Org string
+	// IdempotencyTTL — время хранения ответов для Idempotency-Key (IDEMPOTENCY_TTL).
+	IdempotencyTTL time.Duration
+
+	// AuthEnabled включает проверку API токенов (AUTH_ENABLED).
+	AuthEnabled bool
+	// AuthPublicPaths — пути без аутентификации (AUTH_PUBLIC_PATHS, через запятую).
+	AuthPublicPaths []string
+	JWT             JWTConfig
+
+	Pool PoolConfig
+	HTTP HTTPConfig
+	// ShutdownGrace — сколько ждать завершения запросов при остановке (SHUTDOWN_GRACE).
+	ShutdownGrace time.Duration
+	// ShutdownDelay — сколько отвечать 503 на /readyz до остановки приёма
+	// запросов (SHUTDOWN_DELAY).
+	ShutdownDelay time.Duration
+	Health        HealthConfig
+	Log           LogConfig
+	Tracing       tracing.Config
+	Features      Features
+
+	// sources — откуда взято значение каждого ключа; нужно для config print.
+	sources map[string]string
+}
+
+// PoolConfig — лимиты pgxpool; нулевые значения оставляют значения драйвера.
+type PoolConfig struct {
+	MaxConns          int
+	MinConns          int
+	MaxConnLifetime   time.Duration
+	MaxConnIdleTime   time.Duration
+	HealthCheckPeriod time.Duration
+	ConnectTimeout    time.Duration
+}
+
+// apply переносит заданные лимиты в конфигурацию пула.
+func (p PoolConfig) apply(cfg *pgxpool.Config) {
+	if p.MaxConns > 0 {
+		cfg.MaxConns = int32(p.MaxConns)
+	}
+	if p.MinConns > 0 {
+		cfg.MinConns = int32(p.MinConns)
+	}
+	if p.MaxConnLifetime > 0 {
+		cfg.MaxConnLifetime = p.MaxConnLifetime
+	}
+	if p.MaxConnIdleTime > 0 {
+		cfg.MaxConnIdleTime = p.MaxConnIdleTime
+	}
+	if p.HealthCheckPeriod > 0 {
+		cfg.HealthCheckPeriod = p.HealthCheckPeriod
+	}
+	if p.ConnectTimeout > 0 {
+		cfg.ConnConfig.ConnectTimeout = p.ConnectTimeout
+	}
+}
+
+// HTTPConfig — таймауты http.Server; 0 отключает таймаут.
+type HTTPConfig struct {
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	// WriteTimeout не действует на поток событий /events.
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+}
+
+// HealthConfig — параметры проверок /readyz.
+type HealthConfig struct {
+	// CheckTimeout ограничивает каждую проверку.
+	CheckTimeout time.Duration
+	// PoolMaxUsage — доля занятых соединений пула, при которой сервис не готов.
+	PoolMaxUsage float64
+}
+
+type LogConfig struct {
+	// Level — debug, info, warn или error.
+	Level string
+	// Format — text или json.
+	Format string
+}
+
+// Features включает и выключает подсистемы сервиса.
+type Features struct {
+	GRPC        bool
+	Events      bool
+	Idempotency bool
+	Metrics     bool
+}
+
+// defaultConfig — значения по умолчанию, поверх которых применяются файл,
+// переменные окружения и флаги.
+func defaultConfig() Config {
+	return Config{
+		Storage:         storagePostgres,
+		Port:            "8080",
+		GRPCPort:        "50051",
+		IdempotencyTTL:  handler.DefaultIdempotencyTTL,
+		AuthPublicPaths: append([]string(nil), handler.DefaultPublicPaths...),
+		JWT: JWTConfig{
+			UserClaim:     "sub",
+			DefaultScopes: []string{auth.ScopeRead, auth.ScopeTeamsWrite, auth.ScopePRsWrite},
+		},
+		Pool: PoolConfig{ConnectTimeout: 5 * time.Second},
+		HTTP: HTTPConfig{
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      60 * time.Second,
+			IdleTimeout:       120 * time.Second,
+		},
+		ShutdownGrace: 10 * time.Second,
+		Health:        HealthConfig{CheckTimeout: health.DefaultTimeout, PoolMaxUsage: 1},
+		Log:           LogConfig{Level: "info", Format: "json"},
+		Tracing:       tracing.Config{Exporter: tracing.ExporterNone, SampleRatio: 1},
+		Features:      Features{GRPC: true, Events: true, Idempotency: true, Metrics: true},
+	}
+}
+
+// setting — один параметр: ключ YAML (он же имя флага), переменная окружения
+// и поле Config, в которое он разбирается.
+type setting struct {
+	key    string
+	env    string
+	usage  string
+	value  flag.Value
+	secret bool
+}
+
+func settings(cfg *Config) []setting {
+	return []setting{
+		{key: "http.port", env: "APP_PORT", value: stringVar{&cfg.Port}, usage: "HTTP port"},
+		{key: "http.read_header_timeout", env: "HTTP_READ_HEADER_TIMEOUT", value: durationVar{&cfg.HTTP.ReadHeaderTimeout}, usage: "time to read request headers"},
+		{key: "http.read_timeout", env: "HTTP_READ_TIMEOUT", value: durationVar{&cfg.HTTP.ReadTimeout}, usage: "time to read the whole request"},
+		{key: "http.write_timeout", env: "HTTP_WRITE_TIMEOUT", value: durationVar{&cfg.HTTP.WriteTimeout}, usage: "time to write the response (not applied to /events)"},
+		{key: "http.idle_timeout", env: "HTTP_IDLE_TIMEOUT", value: durationVar{&cfg.HTTP.IdleTimeout}, usage: "keep-alive idle timeout"},
+		{key: "grpc.port", env: "GRPC_PORT", value: stringVar{&cfg.GRPCPort}, usage: "gRPC port, empty disables gRPC"},
+		{key: "shutdown_grace", env: "SHUTDOWN_GRACE", value: durationVar{&cfg.ShutdownGrace}, usage: "time to finish in-flight requests on shutdown"},
+		{key: "shutdown_delay", env: "SHUTDOWN_DELAY", value: durationVar{&cfg.ShutdownDelay}, usage: "time /readyz reports not ready before the server stops accepting requests"},
+		{key: "health.check_timeout", env: "HEALTH_CHECK_TIMEOUT", value: durationVar{&cfg.Health.CheckTimeout}, usage: "timeout of each /readyz check"},
+		{key: "health.pool_max_usage", env: "HEALTH_POOL_MAX_USAGE", value: floatVar{&cfg.Health.PoolMaxUsage}, usage: "fraction of busy pool connections that makes the service not ready, 0..1"},
+
+		{key: "storage", env: "STORAGE", value: stringVar{&cfg.Storage}, usage: "postgres, or memory for demos and tests (data is lost on exit)"},
+		{key: "db.dsn", env: "DB_DSN", value: stringVar{&cfg.DSN}, usage: "PostgreSQL connection string", secret: true},
+		{key: "db.max_conns", env: "DB_MAX_CONNS", value: intVar{&cfg.Pool.MaxConns}, usage: "pool size, 0 for the driver default"},
+		{key: "db.min_conns", env: "DB_MIN_CONNS", value: intVar{&cfg.Pool.MinConns}, usage: "connections kept open"},
+		{key: "db.max_conn_lifetime", env: "DB_MAX_CONN_LIFETIME", value: durationVar{&cfg.Pool.MaxConnLifetime}, usage: "connection lifetime, 0 for the driver default"},
+		{key: "db.max_conn_idle_time", env: "DB_MAX_CONN_IDLE_TIME", value: durationVar{&cfg.Pool.MaxConnIdleTime}, usage: "idle connection lifetime, 0 for the driver default"},
+		{key: "db.health_check_period", env: "DB_HEALTH_CHECK_PERIOD", value: durationVar{&cfg.Pool.HealthCheckPeriod}, usage: "idle connection check period, 0 for the driver default"},
+		{key: "db.connect_timeout", env: "DB_CONNECT_TIMEOUT", value: durationVar{&cfg.Pool.ConnectTimeout}, usage: "connection establishment timeout"},
+		{key: "db.auto_migrate", env: "DB_AUTO_MIGRATE", value: boolVar{&cfg.AutoMigrate}, usage: "apply embedded migrations on startup"},
+		{key: "db.row_level_security", env: "DB_ROW_LEVEL_SECURITY", value: boolVar{&cfg.RowLevelSecurity}, usage: "set app.org_id for row-level security policies"},
+
+		{key: "org", env: "ORG_ID", value: stringVar{&cfg.Org}, usage: "organization for CLI subcommands"},
+		{key: "idempotency.ttl", env: "IDEMPOTENCY_TTL", value: durationVar{&cfg.IdempotencyTTL}, usage: "how long Idempotency-Key responses are kept"},
+
+		{key: "auth.enabled", env: "AUTH_ENABLED", value: boolVar{&cfg.AuthEnabled}, usage: "require API tokens or JWT"},
+		{key: "auth.public_paths", env: "AUTH_PUBLIC_PATHS", value: listVar{&cfg.AuthPublicPaths}, usage: "comma-separated paths without authentication"},
+		{key: "jwt.jwks_file", env: "JWT_JWKS_FILE", value: stringVar{&cfg.JWT.JWKSFile}, usage: "JWKS file for SSO JWT"},
+		{key: "jwt.jwks_url", env: "JWT_JWKS_URL", value: stringVar{&cfg.JWT.JWKSURL}, usage: "JWKS URL for SSO JWT"},
+		{key: "jwt.issuer", env: "JWT_ISSUER", value: stringVar{&cfg.JWT.Issuer}, usage: "expected JWT issuer"},
+		{key: "jwt.audience", env: "JWT_AUDIENCE", value: stringVar{&cfg.JWT.Audience}, usage: "expected JWT audience"},
+		{key: "jwt.user_claim", env: "JWT_USER_CLAIM", value: stringVar{&cfg.JWT.UserClaim}, usage: "claim with the user id"},
+		{key: "jwt.org_claim", env: "JWT_ORG_CLAIM", value: stringVar{&cfg.JWT.OrgClaim}, usage: "claim with the organization"},
+		{key: "jwt.default_scopes", env: "JWT_DEFAULT_SCOPES", value: listVar{&cfg.JWT.DefaultScopes}, usage: "scopes of JWT without a scope claim"},
+
+		{key: "log.level", env: "LOG_LEVEL", value: stringVar{&cfg.Log.Level}, usage: "debug, info, warn or error"},
+		{key: "log.format", env: "LOG_FORMAT", value: stringVar{&cfg.Log.Format}, usage: "text or json"},
+
+		{key: "tracing.exporter", env: "TRACING_EXPORTER", value: stringVar{&cfg.Tracing.Exporter}, usage: "none, otlp or stdout"},
+		{key: "tracing.endpoint", env: "TRACING_OTLP_ENDPOINT", value: stringVar{&cfg.Tracing.Endpoint}, usage: "OTLP/HTTP collector URL, empty for OTEL_EXPORTER_OTLP_* defaults"},
+		{key: "tracing.file", env: "TRACING_FILE", value: stringVar{&cfg.Tracing.File}, usage: "file for the stdout exporter, empty for stdout"},
+		{key: "tracing.sample_ratio", env: "TRACING_SAMPLE_RATIO", value: floatVar{&cfg.Tracing.SampleRatio}, usage: "fraction of root requests to trace, 0..1"},
+
+		{key: "features.grpc", env: "FEATURE_GRPC", value: boolVar{&cfg.Features.GRPC}, usage: "serve the gRPC API"},
+		{key: "features.events", env: "FEATURE_EVENTS", value: boolVar{&cfg.Features.Events}, usage: "publish events to /events and WatchAssignments"},
+		{key: "features.idempotency", env: "FEATURE_IDEMPOTENCY", value: boolVar{&cfg.Features.Idempotency}, usage: "honor the Idempotency-Key header"},
+		{key: "features.metrics", env: "FEATURE_METRICS", value: boolVar{&cfg.Features.Metrics}, usage: "collect Prometheus metrics and serve /metrics"},
+	}
+}
+
+// loadConfig собирает конфигурацию: значения по умолчанию < YAML-файл
+// (-config или CONFIG_FILE) < переменные окружения < флаги. Флаги идут до
+// подкоманды; возвращаются оставшиеся аргументы.
+func loadConfig(args []string) (Config, []string, error) {
+	cfg := defaultConfig()
+	cfg.sources = map[string]string{}
+	list := settings(&cfg)
+
+	fs := flag.NewFlagSet("api", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "YAML config file")
+	fromFlags := map[string]string{}
+	for _, s := range list {
+		key := s.key
+		record := func(v string) error { fromFlags[key] = v; return nil }
+		if _, ok := s.value.(boolVar); ok {
+			fs.BoolFunc(key, s.usage, record)
+		} else {
+			fs.Func(key, s.usage, record)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return cfg, nil, fmt.Errorf("flags: %w", err)
+	}
+
+	var fromFile map[string]string
+	if *configFile != "" {
+		var err error
+		if fromFile, err = readConfigFile(*configFile); err != nil {
+			return cfg, nil, err
+		}
+	}
+
+	var errs []error
+	for _, s := range list {
+		apply := func(source, raw string) {
+			if err := s.value.Set(raw); err != nil {
+				errs = append(errs, fmt.Errorf("%s (%s): invalid value %q: %w", s.key, source, raw, err))
+				return
+			}
+			cfg.sources[s.key] = source
+		}
+		if raw, ok := fromFile[s.key]; ok {
+			apply("file "+*configFile, raw)
+		}
+		// Пустая переменная для числа, флага или длительности — как незаданная
+		// (так её оставляет шаблон .env); для строк и списков это пустое значение.
+		if raw, ok := os.LookupEnv(s.env); ok && (raw != "" || isText(s.value)) {
+			apply("env "+s.env, raw)
+		}
+		if raw, ok := fromFlags[s.key]; ok {
+			apply("flag -"+s.key, raw)
+		}
+	}
+	if len(errs) > 0 {
+		return cfg, nil, errors.Join(errs...)
+	}
+	if err := cfg.validate(); err != nil {
+		return cfg, nil, err
+	}
+	return cfg, fs.Args(), nil
+}
+
+// readConfigFile разбирает YAML в плоский набор «ключ через точку → значение».
+// Неизвестные ключи — ошибка: опечатка не должна молча оставлять значение по умолчанию.
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	values := map[string]string{}
+	if len(doc.Content) > 0 {
+		if err := flatten(doc.Content[0], "", values); err != nil {
+			return nil, fmt.Errorf("config file %s: %w", path, err)
+		}
+	}
+
+	known := map[string]bool{}
+	for _, s := range settings(&Config{}) {
+		known[s.key] = true
+	}
+	for key := range values {
+		if !known[key] {
+			return nil, fmt.Errorf("config file %s: unknown key %q", path, key)
+		}
+	}
+	return values, nil
+}
+
+func flatten(n *yaml.Node, prefix string, out map[string]string) error {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key := n.Content[i].Value
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			if err := flatten(n.Content[i+1], key, out); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(n.Content))
+		for _, item := range n.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("%s: expected a list of values", prefix)
+			}
+			items = append(items, item.Value)
+		}
+		out[prefix] = strings.Join(items, ",")
+	case yaml.ScalarNode:
+		if prefix == "" {
+			return errors.New("expected a mapping")
+		}
+		out[prefix] = n.Value
+	default:
+		return fmt.Errorf("%s: unsupported value", prefix)
+	}
+	return nil
+}
+
+const (
+	storagePostgres = "postgres"
+	storageMemory   = "memory"
+)
+
+var (
+	logLevels  = map[string]slog.Level{"debug": slog.LevelDebug, "info": slog.LevelInfo, "warn": slog.LevelWarn, "error": slog.LevelError}
+	logFormats = []string{"text", "json"}
+)
+
+// validate проверяет значения целиком; DSN проверяется при подключении к БД,
+// чтобы config print и справка работали без него.
+func (c Config) validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	check(c.Storage == storagePostgres || c.Storage == storageMemory, "storage: %q, want postgres or memory", c.Storage)
+	check(validPort(c.Port, false), "http.port: %q is not a port number", c.Port)
+	check(validPort(c.GRPCPort, true), "grpc.port: %q is not a port number", c.GRPCPort)
+	check(c.HTTP.ReadHeaderTimeout >= 0 && c.HTTP.ReadTimeout >= 0 && c.HTTP.WriteTimeout >= 0 && c.HTTP.IdleTimeout >= 0,
+		"http: timeouts must not be negative")
+	check(c.ShutdownGrace > 0, "shutdown_grace: must be positive")
+	check(c.ShutdownDelay >= 0, "shutdown_delay: must not be negative")
+	check(c.Health.CheckTimeout > 0, "health.check_timeout: must be positive")
+	check(c.Health.PoolMaxUsage > 0 && c.Health.PoolMaxUsage <= 1, "health.pool_max_usage: %v is not in (0, 1]", c.Health.PoolMaxUsage)
+	check(c.Pool.MaxConns >= 0 && c.Pool.MinConns >= 0, "db: max_conns and min_conns must not be negative")
+	check(c.Pool.MaxConns == 0 || c.Pool.MinConns <= c.Pool.MaxConns,
+		"db.min_conns (%d) must not exceed db.max_conns (%d)", c.Pool.MinConns, c.Pool.MaxConns)
+	check(c.Pool.MaxConnLifetime >= 0 && c.Pool.MaxConnIdleTime >= 0 && c.Pool.HealthCheckPeriod >= 0 && c.Pool.ConnectTimeout >= 0,
+		"db: durations must not be negative")
+	check(c.IdempotencyTTL > 0, "idempotency.ttl: must be positive")
+	_, ok := logLevels[c.Log.Level]
+	check(ok, "log.level: %q, want debug, info, warn or error", c.Log.Level)
+	check(validLogFormat(c.Log.Format), "log.format: %q, want text or json", c.Log.Format)
+	check(validTraceExporter(c.Tracing.Exporter), "tracing.exporter: %q, want none, otlp or stdout", c.Tracing.Exporter)
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio: %v is not between 0 and 1", c.Tracing.SampleRatio)
+	if c.Org != "" {
+		if err := tenant.Validate(c.Org); err != nil {
+			errs = append(errs, fmt.Errorf("org: %w", err))
+		}
+	}
+	if c.JWT.JWKSFile != "" && c.JWT.JWKSURL != "" {
+		errs = append(errs, errors.New("jwt: set only one of jwks_file and jwks_url"))
+	}
+	if err := auth.ValidateScopes(c.JWT.DefaultScopes); err != nil {
+		errs = append(errs, fmt.Errorf("jwt.default_scopes: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+func isText(v flag.Value) bool {
+	switch v.(type) {
+	case stringVar, listVar:
+		return true
+	}
+	return false
+}
+
+func validPort(p string, allowEmpty bool) bool {
+	if p == "" {
+		return allowEmpty
+	}
+	n, err := strconv.Atoi(p)
+	return err == nil && n >= 0 && n <= 65535
+}
+
+func validTraceExporter(e string) bool {
+	switch e {
+	case tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout:
+		return true
+	}
+	return false
+}
+
+func validLogFormat(f string) bool {
+	for _, v := range logFormats {
+		if v == f {
+			return true
+		}
+	}
+	return false
+}
+
+// newLogger создаёт логгер по log.level и log.format. К записям с контекстом
+// запроса добавляется request_id.
+func newLogger(cfg LogConfig, w io.Writer) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: logLevels[cfg.Level]}
+	var h slog.Handler = slog.NewJSONHandler(w, opts)
+	if cfg.Format == "text" {
+		h = slog.NewTextHandler(w, opts)
+	}
+	return slog.New(logging.NewHandler(h))
+}
+
+// runConfig реализует подкоманду:
+//
+//	api config print
+//
+// Печатает действующую конфигурацию в YAML; у каждого значения в комментарии
+// указан источник, пароль в DSN скрыт.
+func runConfig(cfg Config, args []string, stdout io.Writer) error {
+	if len(args) != 1 || args[0] != "print" {
+		return errors.New("usage: config print")
+	}
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	for _, s := range settings(&cfg) {
+		value := s.value.String()
+		if s.secret {
+			value = redactDSN(value)
+		}
+		source := cfg.sources[s.key]
+		if source == "" {
+			source = "default"
+		}
+		parent := root
+		parts := strings.Split(s.key, ".")
+		for _, part := range parts[:len(parts)-1] {
+			parent = child(parent, part)
+		}
+		parent.Content = append(parent.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: parts[len(parts)-1]},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: value, Tag: "!!str", LineComment: source})
+	}
+	enc := yaml.NewEncoder(stdout)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// child находит или добавляет вложенную секцию.
+func child(parent *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == key {
+			return parent.Content[i+1]
+		}
+	}
+	n := &yaml.Node{Kind: yaml.MappingNode}
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, n)
+	return n
+}
+
+var dsnPassword = regexp.MustCompile(`(password\s*=\s*)('[^']*'|\S+)`)
+
+// redactDSN скрывает пароль в DSN вида URL или key=value.
+func redactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.Scheme != "" {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), "REDACTED")
+		}
+		q := u.Query()
+		if q.Has("password") {
+			q.Set("password", "REDACTED")
+			u.RawQuery = q.Encode()
+		}
+		return u.String()
+	}
+	return dsnPassword.ReplaceAllString(dsn, "${1}REDACTED")
+}
+
+// --- flag.Value для полей Config ---
+
+type stringVar struct{ p *string }
+
+func (v stringVar) Set(s string) error { *v.p = s; return nil }
+func (v stringVar) String() string {
+	if v.p == nil {
+		return ""
+	}
+	return *v.p
+}
+
+type intVar struct{ p *int }
+
+func (v intVar) Set(s string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return errors.New("not an integer")
+	}
+	*v.p = n
+	return nil
+}
+func (v intVar) String() string {
+	if v.p == nil {
+		return "0"
+	}
+	return strconv.Itoa(*v.p)
+}
+
+type floatVar struct{ p *float64 }
+
+func (v floatVar) Set(s string) error {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return errors.New("not a number")
+	}
+	*v.p = f
+	return nil
+}
+func (v floatVar) String() string {
+	if v.p == nil {
+		return "0"
+	}
+	return strconv.FormatFloat(*v.p, 'g', -1, 64)
+}
+
+type boolVar struct{ p *bool }
+
+func (v boolVar) Set(s string) error {
+	b, err := strconv.ParseBool(strings.TrimSpace(s))
+	if err != nil {
+		return errors.New("not a boolean")
+	}
+	*v.p = b
+	return nil
+}
+func (v boolVar) String() string {
+	if v.p == nil {
+		return "false"
+	}
+	return strconv.FormatBool(*v.p)
+}
+
+type durationVar struct{ p *time.Duration }
+
+func (v durationVar) Set(s string) error {
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil {
+		return errors.New("not a duration like 30s or 5m")
+	}
+	*v.p = d
+	return nil
+}
+func (v durationVar) String() string {
+	if v.p == nil {
+		return "0s"
+	}
+	return v.p.String()
+}
+
+// listVar — список через запятую; пустая строка — пустой список.
+type listVar struct{ p *[]string }
+
+func (v listVar) Set(s string) error { *v.p = splitList(s); return nil }
+func (v listVar) String() string {
+	if v.p == nil {
+		return ""
+	}
+	return strings.Join(*v.p, ",")
+}
+
+// splitList разбирает список через запятую, пропуская пустые элементы.
+func splitList(s string) []string {
+	result := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/trainee/review-service/internal/importer"
+	"github.com/trainee/review-service/internal/model"
+	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/service"
+)
+
+// errImportIncomplete сигнализирует (ненулевым кодом выхода), что часть строк не импортирована.
+var errImportIncomplete = errors.New("import finished with row errors")
+
+// runImport реализует подкоманду:
+//
+//	api import [-file data.jsonl] [-format jsonl|csv] [-mode atomic|best_effort] [-dry-run]
+//
+// Отчёт печатается в stdout в формате JSON.
+func runImport(ctx context.Context, cfg Config, args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	file := fs.String("file", "-", "input file ('-' for stdin)")
+	formatName := fs.String("format", "", "input format: jsonl or csv (default: by file extension, jsonl for stdin)")
+	mode := fs.String("mode", string(model.ImportAtomic), "atomic (all-or-nothing) or best_effort")
+	dryRun := fs.Bool("dry-run", false, "validate only, do not save anything")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *formatName == "" {
+		*formatName = "jsonl"
+		if strings.EqualFold(filepath.Ext(*file), ".csv") {
+			*formatName = "csv"
+		}
+	}
+	format, err := importer.ParseFormat(*formatName)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, *formatName)
+	}
+
+	input := stdin
+	if *file != "-" {
+		f, err := os.Open(*file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+	batch, err := importer.Decode(input, format)
+	if err != nil {
+		return err
+	}
+
+	dbPool, err := openPool(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+
+	svc := service.NewService(repo.NewPostgresRepository(dbPool))
+	report, err := svc.Import(ctx, *batch, model.ImportOptions{Mode: model.ImportMode(*mode), DryRun: *dryRun})
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	if report.Failed > 0 {
+		return errImportIncomplete
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"log/slog"
 	"net"
@@ -12,78 +13,283 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc"
+
+	"github.com/trainee/review-service/internal/events"
+	"github.com/trainee/review-service/internal/grpcserver"
 	"github.com/trainee/review-service/internal/handler"
+	"github.com/trainee/review-service/internal/health"
 	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/repository/memory"
 	"github.com/trainee/review-service/internal/service"
+	"github.com/trainee/review-service/internal/tenant"
+	"github.com/trainee/review-service/internal/tracing"
+	"github.com/trainee/review-service/migrations"
 	"syscall"
 )
 
 var exit = os.Exit
 
-type Config struct {
-	DSN      string
-	Port     string
-	Listener net.Listener
-}
-
 func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	if err := run(ctx, loadConfig()); err != nil {
-		log.Printf("server failed: %v", err)
+	cfg, args, err := loadConfig(os.Args[1:])
+	if err != nil {
+		log.Printf("invalid configuration: %v", err)
 		exit(1)
+		return
+	}
+	slog.SetDefault(newLogger(cfg.Log, os.Stderr))
+
+	if err := execute(ctx, args, cfg); err != nil {
+		log.Printf("command failed: %v", err)
+		exit(1)
+	}
+}
+
+// execute выбирает подкоманду; без подкоманды запускается HTTP сервер.
+// Подкоманды работают с данными организации cfg.Org.
+func execute(ctx context.Context, args []string, cfg Config) error {
+	if len(args) > 0 {
+		if cfg.Storage == storageMemory && args[0] != "config" {
+			return fmt.Errorf("%s: not available with storage %q, data lives only inside the server", args[0], cfg.Storage)
+		}
+		if cfg.Org != "" {
+			if err := tenant.Validate(cfg.Org); err != nil {
+				return err
+			}
+			ctx = tenant.NewContext(ctx, cfg.Org)
+		}
+		switch args[0] {
+		case "import":
+			return runImport(ctx, cfg, args[1:], os.Stdin, os.Stdout)
+		case "export":
+			return runExport(ctx, cfg, args[1:], os.Stdout)
+		case "restore":
+			return runRestore(ctx, cfg, args[1:], os.Stdin, os.Stdout)
+		case "token":
+			return runToken(ctx, cfg, args[1:], os.Stdout)
+		case "role":
+			return runRole(ctx, cfg, args[1:], os.Stdout)
+		case "migrate":
+			return runMigrate(ctx, cfg, args[1:], os.Stdout)
+		case "config":
+			return runConfig(cfg, args[1:], os.Stdout)
+		}
+	}
+	return run(ctx, cfg)
+}
+
+// openPool подключается к БД и проверяет соединение.
+func openPool(ctx context.Context, cfg Config) (*pgxpool.Pool, error) {
+	if cfg.DSN == "" {
+		return nil, errors.New("DB_DSN environment variable is required")
+	}
+
+	poolCfg, err := pgxpool.ParseConfig(cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Pool.apply(poolCfg)
+	poolCfg.ConnConfig.Tracer = tracing.QueryTracer{}
+	if cfg.RowLevelSecurity {
+		repo.EnableRowLevelSecurity(poolCfg)
+	}
+	dbPool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Проверка подключения
+	if err := dbPool.Ping(ctx); err != nil {
+		dbPool.Close()
+		return nil, err
+	}
+	slog.Info("Database connected successfully")
+	return dbPool, nil
+}
+
+// cleanupIdempotencyKeys периодически удаляет истёкшие Idempotency-Key до остановки сервера.
+func cleanupIdempotencyKeys(ctx context.Context, store repo.IdempotencyRepository, interval time.Duration, worker *health.Worker) {
+	defer worker.Stopped()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := store.DeleteExpiredIdempotencyKeys(ctx, time.Now())
+			if err != nil {
+				worker.Failure(err)
+				slog.Error("Failed to delete expired idempotency keys", "error", err)
+				continue
+			}
+			worker.Success()
+			slog.Debug("Expired idempotency keys deleted", "count", n)
+		}
+	}
+}
+
+// storage — хранилище сервера: данные, API токены и ключи идемпотентности.
+type storage interface {
+	repo.Repository
+	repo.TokenRepository
+	repo.IdempotencyRepository
+}
+
+// openStorage открывает хранилище cfg.Storage и собирает проверки /readyz
+// для него; возвращённая функция освобождает соединения с БД.
+func openStorage(ctx context.Context, cfg Config) (storage, *health.Checker, func(), error) {
+	if cfg.Storage == storageMemory {
+		slog.Warn("Using in-memory storage, data will be lost on exit")
+		return memory.New(), health.NewChecker(cfg.Health.CheckTimeout), func() {}, nil
+	}
+
+	dbPool, err := openPool(ctx, cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := ensureSchema(ctx, dbPool, cfg.AutoMigrate); err != nil {
+		dbPool.Close()
+		return nil, nil, nil, err
 	}
+	checker, err := readinessChecks(cfg, dbPool)
+	if err != nil {
+		dbPool.Close()
+		return nil, nil, nil, err
+	}
+	return repo.NewPostgresRepository(dbPool), checker, dbPool.Close, nil
+}
+
+// readinessChecks собирает проверки /readyz: доступность БД, версию схемы и
+// загрузку пула. Проверки фоновых задач добавляются при их запуске.
+func readinessChecks(cfg Config, pool *pgxpool.Pool) (*health.Checker, error) {
+	m, err := migrations.New(pool)
+	if err != nil {
+		return nil, err
+	}
+	checker := health.NewChecker(cfg.Health.CheckTimeout)
+	checker.Add("database", health.Database(pool))
+	checker.Add("schema", health.Schema(m))
+	checker.Add("pool", health.Pool(health.PgxPoolStats(pool), cfg.Health.PoolMaxUsage))
+	return checker, nil
 }
 
-func loadConfig() Config {
-	port := os.Getenv("APP_PORT")
-	if port == "" {
-		port = "8080"
+// grpcListener открывает порт gRPC API; nil — gRPC отключён.
+func grpcListener(cfg Config) (net.Listener, error) {
+	if !cfg.Features.GRPC {
+		return nil, nil
 	}
-	return Config{
-		DSN:  os.Getenv("DB_DSN"),
-		Port: port,
+	if cfg.GRPCListener != nil {
+		return cfg.GRPCListener, nil
+	}
+	if cfg.GRPCPort == "" || cfg.GRPCPort == "0" {
+		return nil, nil
+	}
+	return net.Listen("tcp", ":"+cfg.GRPCPort)
+}
+
+// stopGRPC дожидается завершения текущих вызовов, а по истечении ctx обрывает их.
+func stopGRPC(ctx context.Context, srv *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		srv.Stop()
 	}
 }
 
 func run(ctx context.Context, cfg Config) error {
-	if cfg.DSN == "" {
+	if cfg.DSN == "" && cfg.Storage != storageMemory {
 		return errors.New("DB_DSN environment variable is required")
 	}
 	if cfg.Port == "" {
 		cfg.Port = "8080"
 	}
+	if cfg.ShutdownGrace <= 0 {
+		cfg.ShutdownGrace = defaultConfig().ShutdownGrace
+	}
 
-	// Инициализация пула подключений к БД
-	// Для продакшена здесь стоит добавить настройку параметров пула (MaxConns и т.д.)
-	dbPool, err := pgxpool.New(ctx, cfg.DSN)
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
 	if err != nil {
 		return err
 	}
-	defer dbPool.Close()
+	defer func() {
+		// Дописываем накопленные спаны, даже если ctx уже отменён сигналом.
+		flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			slog.Error("Failed to flush traces", "error", err)
+		}
+	}()
 
-	// Проверка подключения
-	if err := dbPool.Ping(ctx); err != nil {
+	repository, checker, closeStorage, err := openStorage(ctx, cfg)
+	if err != nil {
 		return err
 	}
-	slog.Info("Database connected successfully")
-
-	// Примечание: Миграции обрабатываются отдельным контейнером 'migrate' в docker-compose.
+	defer closeStorage()
 
 	// Внедрение зависимостей (Dependency Injection)
-	repository := repo.NewPostgresRepository(dbPool)
-	svc := service.NewService(repository)
-	hdlr := handler.NewHandler(svc)
+	var broker *events.Broker
+	if cfg.Features.Events {
+		broker = events.NewBroker()
+	}
+	svc := service.NewService(repository, service.WithEvents(broker))
+	authCfg, err := authConfig(cfg, repository)
+	if err != nil {
+		return err
+	}
+	var opts []handler.Option
+	grpcOpts := []grpcserver.Option{grpcserver.WithEvents(broker)}
+	if authCfg != nil {
+		opts = append(opts, handler.WithAuth(*authCfg))
+		grpcOpts = append(grpcOpts, grpcserver.WithAuthenticator(authCfg.Authenticator))
+	}
+	if broker != nil {
+		opts = append(opts, handler.WithEvents(broker))
+	}
+	opts = append(opts, handler.WithHealth(checker))
+	if cfg.Features.Idempotency {
+		opts = append(opts, handler.WithIdempotency(handler.IdempotencyConfig{Store: repository, TTL: cfg.IdempotencyTTL}))
+		worker := health.NewWorker(time.Hour)
+		checker.Add("worker.idempotency_cleanup", worker.Check)
+		cleanupCtx, stopCleanup := context.WithCancel(ctx)
+		cleanupDone := make(chan struct{})
+		go func() {
+			defer close(cleanupDone)
+			cleanupIdempotencyKeys(cleanupCtx, repository, time.Hour, worker)
+		}()
+		// Отложенные вызовы выполняются в обратном порядке: очистка завершится
+		// до closeStorage, в том числе при раннем выходе из run.
+		defer func() {
+			stopCleanup()
+			<-cleanupDone
+		}()
+	}
+	if !cfg.Features.Metrics {
+		opts = append(opts, handler.WithoutMetrics())
+	}
+	hdlr := handler.NewHandler(svc, opts...)
 	router := hdlr.SetupRouter()
 
 	// Конфигурация HTTP сервера
 	server := &http.Server{
 		Addr:              ":" + cfg.Port,
 		Handler:           router,
-		ReadHeaderTimeout: 5 * time.Second,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
 	}
 
+	// Оба листенера открываются до запуска серверов: если gRPC-порт занят,
+	// HTTP-сервер не должен остаться работать.
 	ln := cfg.Listener
 	if ln == nil {
 		ln, err = net.Listen("tcp", ":"+cfg.Port)
@@ -91,6 +297,11 @@ func run(ctx context.Context, cfg Config) error {
 			return err
 		}
 	}
+	grpcLn, err := grpcListener(cfg)
+	if err != nil {
+		ln.Close()
+		return err
+	}
 
 	// Запуск сервера в отдельной горутине
 	go func() {
@@ -100,15 +311,48 @@ func run(ctx context.Context, cfg Config) error {
 		}
 	}()
 
+	var grpcSrv *grpc.Server
+	if grpcLn != nil {
+		grpcSrv = grpcserver.NewServer(svc, grpcOpts...).GRPCServer()
+		go func() {
+			slog.Info("Starting gRPC server", "addr", grpcLn.Addr().String())
+			if err := grpcSrv.Serve(grpcLn); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+				log.Fatalf("Failed to start gRPC server: %v", err)
+			}
+		}()
+	}
+
 	// Graceful Shutdown
 	<-ctx.Done()
 	slog.Info("Shutting down server...")
 
-	// Даем 10 секунд на завершение текущих запросов
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	// /readyz сразу отвечает 503; shutdown_delay даёт балансировщику время это
+	// заметить, пока сервер ещё принимает запросы.
+	checker.ShutDown()
+	if cfg.ShutdownDelay > 0 {
+		time.Sleep(cfg.ShutdownDelay)
+	}
+
+	// Даём текущим запросам завершиться за shutdown_grace.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownGrace)
 	defer cancel()
 
-	if err := server.Shutdown(shutdownCtx); err != nil {
+	// Закрываем стримы событий (gRPC и SSE), иначе GracefulStop и Shutdown ждали бы их до таймаута.
+	if broker != nil {
+		broker.Close()
+	}
+	// Серверы останавливаются параллельно; хранилище закрывается только после
+	// того, как завершились запросы обоих.
+	grpcStopped := make(chan struct{})
+	go func() {
+		defer close(grpcStopped)
+		if grpcSrv != nil {
+			stopGRPC(shutdownCtx, grpcSrv)
+		}
+	}()
+	err = server.Shutdown(shutdownCtx)
+	<-grpcStopped
+	if err != nil {
 		return err
 	}
 
@@ -1,10 +1,19 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +21,15 @@ import (
 	"github.com/ory/dockertest/v3"
 	"github.com/ory/dockertest/v3/docker"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/trainee/review-service/internal/handler"
+	"github.com/trainee/review-service/internal/health"
+	"github.com/trainee/review-service/migrations"
+	reviewv1 "github.com/trainee/review-service/pkg/api/review/v1"
 )
 
 func startTestPostgres(t *testing.T) (dsn string, cleanup func()) {
@@ -21,6 +39,9 @@ func startTestPostgres(t *testing.T) (dsn string, cleanup func()) {
 	if err != nil {
 		t.Skipf("docker not available: %v", err)
 	}
+	if err := pool.Client.Ping(); err != nil {
+		t.Skipf("docker not available: %v", err)
+	}
 	runOpts := &dockertest.RunOptions{
 		Repository: "postgres",
 		Tag:        "15-alpine",
@@ -66,11 +87,16 @@ func TestRunStartsAndStops(t *testing.T) {
 
 	ln, err := net.Listen("tcp", "127.0.0.1:0")
 	require.NoError(t, err)
+	grpcLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	done := make(chan error, 1)
 	go func() {
-		done <- run(ctx, Config{DSN: dsn, Port: "0", Listener: ln})
+		done <- run(ctx, testConfig(func(c *Config) {
+			c.DSN, c.Port, c.Listener, c.GRPCListener, c.AutoMigrate = dsn, "0", ln, grpcLn, true
+			c.ShutdownDelay = 500 * time.Millisecond
+		}))
 	}()
 
 	// Даем серверу подняться.
@@ -82,8 +108,36 @@ func TestRunStartsAndStops(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 	resp.Body.Close()
 
-	// Останавливаем.
+	// Готовность: БД доступна, схема актуальна, пул и фоновые задачи в порядке.
+	readyz := "http://" + ln.Addr().String() + "/readyz"
+	resp, err = http.Get(readyz)
+	require.NoError(t, err)
+	var report health.Report
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, health.StatusReady, report.Status)
+	for _, name := range []string{"database", "schema", "pool", "worker.idempotency_cleanup"} {
+		require.Equal(t, health.StatusOK, report.Checks[name].Status, name)
+	}
+
+	// gRPC API работает на своём порту с тем же сервисом.
+	conn, err := grpc.NewClient(grpcLn.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = reviewv1.NewReviewServiceClient(conn).GetTeam(ctx, &reviewv1.GetTeamRequest{TeamName: "missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// Останавливаем: до остановки приёма запросов /readyz отвечает 503.
 	cancel()
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(readyz)
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusServiceUnavailable
+	}, 400*time.Millisecond, 20*time.Millisecond)
 
 	select {
 	case err := <-done:
@@ -93,8 +147,69 @@ func TestRunStartsAndStops(t *testing.T) {
 	}
 }
 
+func TestMigrateCommandsAndSchemaGuard(t *testing.T) {
+	dsn, cleanup := startTestPostgres(t)
+	defer cleanup()
+	ctx := context.Background()
+	cfg := testConfig(func(c *Config) { c.DSN = dsn })
+
+	// Пустая БД: без DB_AUTO_MIGRATE сервер не стартует.
+	err := run(ctx, cfg)
+	require.ErrorIs(t, err, migrations.ErrVersionMismatch)
+	require.ErrorContains(t, err, "api migrate up")
+
+	var out bytes.Buffer
+	require.NoError(t, runMigrate(ctx, cfg, []string{"up"}, &out))
+	out.Reset()
+	require.NoError(t, runMigrate(ctx, cfg, []string{"status"}, &out))
+	require.Contains(t, out.String(), fmt.Sprintf(`"version": %d`, migrations.Latest()))
+	require.Contains(t, out.String(), `"pending": []`)
+
+	// Все down-миграции откатываются, и схема поднимается заново.
+	out.Reset()
+	require.NoError(t, runMigrate(ctx, cfg, []string{"down", "-steps", "100"}, &out))
+	out.Reset()
+	require.NoError(t, runMigrate(ctx, cfg, []string{"status"}, &out))
+	require.Contains(t, out.String(), `"version": 0`)
+	require.NoError(t, runMigrate(ctx, cfg, []string{"up"}, &out))
+
+	// Схема новее бинарника тоже блокирует запуск, и автомиграция её не трогает.
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	defer pool.Close()
+	_, err = pool.Exec(ctx, "UPDATE schema_migrations SET version = version + 1")
+	require.NoError(t, err)
+	err = run(ctx, testConfig(func(c *Config) { c.DSN, c.AutoMigrate = dsn, true }))
+	require.ErrorIs(t, err, migrations.ErrVersionMismatch)
+	require.NotContains(t, err.Error(), "api migrate up")
+}
+
+func TestRunMigrateValidatesArguments(t *testing.T) {
+	var out bytes.Buffer
+	ctx := context.Background()
+	require.Error(t, runMigrate(ctx, Config{}, nil, &out))
+	require.ErrorContains(t, runMigrate(ctx, Config{}, []string{"redo"}, &out), "unknown migrate command")
+	require.ErrorContains(t, runMigrate(ctx, Config{}, []string{"down", "-steps", "0"}, &out), "-steps")
+	require.ErrorContains(t, runMigrate(ctx, Config{}, []string{"status"}, &out), "DB_DSN")
+}
+
+// testConfig — конфигурация по умолчанию с изменениями теста.
+func testConfig(edit func(*Config)) Config {
+	cfg := defaultConfig()
+	edit(&cfg)
+	return cfg
+}
+
+// mustLoadConfig загружает конфигурацию из окружения и флагов.
+func mustLoadConfig(t *testing.T, args ...string) Config {
+	t.Helper()
+	cfg, _, err := loadConfig(args)
+	require.NoError(t, err)
+	return cfg
+}
+
 func TestLoadConfigMissingDSN(t *testing.T) {
-	cfg := loadConfig()
+	cfg := mustLoadConfig(t)
 	require.Equal(t, "", cfg.DSN)
 }
 
@@ -103,10 +218,92 @@ func TestRunRequiresDSN(t *testing.T) {
 	require.Error(t, err)
 }
 
+// Если gRPC-порт занят, run завершается с ошибкой, не оставив работать HTTP-сервер.
+func TestRunGRPCListenerFailureStopsHTTP(t *testing.T) {
+	busy, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer busy.Close()
+	_, port, err := net.SplitHostPort(busy.Addr().String())
+	require.NoError(t, err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	err = run(context.Background(), testConfig(func(c *Config) {
+		c.Storage, c.Listener, c.Features.GRPC, c.GRPCPort = storageMemory, ln, true, port
+	}))
+	require.ErrorContains(t, err, "address already in use")
+
+	_, err = net.DialTimeout("tcp", ln.Addr().String(), time.Second)
+	require.Error(t, err)
+}
+
+// С хранилищем в памяти сервер не требует БД: данные живут до остановки.
+func TestRunWithMemoryStorage(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx, testConfig(func(c *Config) {
+			c.Storage, c.Listener, c.Features.GRPC = storageMemory, ln, false
+		}))
+	}()
+	base := "http://" + ln.Addr().String()
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(base + "/readyz")
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 2*time.Second, 20*time.Millisecond)
+
+	resp, err := http.Post(base+"/team/add", "application/json",
+		strings.NewReader(`{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp, err = http.Get(base + "/team/get?team_name=backend")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not stop in time")
+	}
+
+	// Подкомандам нечего читать: данные есть только внутри сервера.
+	err = execute(context.Background(), []string{"export"}, testConfig(func(c *Config) { c.Storage = storageMemory }))
+	require.ErrorContains(t, err, "not available with storage")
+	_, _, err = loadConfig([]string{"-storage", "sqlite"})
+	require.ErrorContains(t, err, "storage")
+}
+
 func TestMainMissingDSNExits(t *testing.T) {
 	t.Setenv("DB_DSN", "")
 	t.Setenv("APP_PORT", "0")
 
+	// Без подмены main разбирал бы флаги go test и падал на них, а не на DSN.
+	prevArgs := os.Args
+	os.Args = []string{"api"}
+	defer func() { os.Args = prevArgs }()
+
+	// main пишет ошибку в лог, который после настройки идёт в os.Stderr.
+	stderr, err := os.CreateTemp(t.TempDir(), "stderr")
+	require.NoError(t, err)
+	defer stderr.Close()
+	prevStderr, prevLogger := os.Stderr, slog.Default()
+	os.Stderr = stderr
+	defer func() {
+		os.Stderr = prevStderr
+		slog.SetDefault(prevLogger)
+	}()
+
 	called := make(chan int, 1)
 	prevExit := exit
 	exit = func(code int) { called <- code }
@@ -120,4 +317,240 @@ func TestMainMissingDSNExits(t *testing.T) {
 	case <-time.After(1 * time.Second):
 		t.Fatal("exit was not called")
 	}
+	output, err := os.ReadFile(stderr.Name())
+	require.NoError(t, err)
+	require.Contains(t, string(output), "DB_DSN environment variable is required")
+	require.NotContains(t, string(output), "flag provided but not defined")
+}
+
+func TestRunImportValidatesArguments(t *testing.T) {
+	var out bytes.Buffer
+	err := runImport(context.Background(), Config{}, []string{"-format", "xml"}, strings.NewReader(""), &out)
+	require.Error(t, err)
+
+	err = runImport(context.Background(), Config{}, []string{"-file", "missing.csv"}, strings.NewReader(""), &out)
+	require.Error(t, err)
+
+	// Вход разобран, но без DSN подключиться к БД нельзя.
+	err = runImport(context.Background(), Config{}, nil, strings.NewReader(`{"kind":"team","team_name":"t"}`), &out)
+	require.ErrorContains(t, err, "DB_DSN")
+}
+
+func TestRunExportRestoreValidateArguments(t *testing.T) {
+	var out bytes.Buffer
+	require.Error(t, runExport(context.Background(), Config{}, []string{"-bogus"}, &out))
+	require.ErrorContains(t, runExport(context.Background(), Config{}, nil, &out), "DB_DSN")
+
+	err := runRestore(context.Background(), Config{}, []string{"-file", "missing.jsonl"}, strings.NewReader(""), &out)
+	require.Error(t, err)
+	err = runRestore(context.Background(), Config{}, nil, strings.NewReader(""), &out)
+	require.ErrorContains(t, err, "DB_DSN")
+}
+
+func TestLoadConfigAuth(t *testing.T) {
+	t.Setenv("AUTH_ENABLED", "true")
+	t.Setenv("AUTH_PUBLIC_PATHS", " /health, ,/metrics ")
+	cfg := mustLoadConfig(t)
+	require.True(t, cfg.AuthEnabled)
+	require.Equal(t, []string{"/health", "/metrics"}, cfg.AuthPublicPaths)
+
+	// Пустое значение означает, что публичных путей нет.
+	t.Setenv("AUTH_PUBLIC_PATHS", "")
+	require.Equal(t, []string{}, mustLoadConfig(t).AuthPublicPaths)
+}
+
+func TestRunTokenValidatesArguments(t *testing.T) {
+	var out bytes.Buffer
+	ctx := context.Background()
+	require.Error(t, runToken(ctx, Config{}, nil, &out))
+	require.ErrorContains(t, runToken(ctx, Config{}, []string{"rotate"}, &out), "unknown token command")
+	require.ErrorContains(t, runToken(ctx, Config{}, []string{"create"}, &out), "-name")
+	require.ErrorContains(t, runToken(ctx, Config{}, []string{"create", "-name", "ops", "-scopes", "root"}, &out), "unknown scope")
+	require.ErrorContains(t, runToken(ctx, Config{}, []string{"revoke"}, &out), "-id")
+	require.ErrorContains(t, runToken(ctx, Config{}, []string{"create", "-name", "ops", "-scopes", "admin"}, &out), "DB_DSN")
+}
+
+func TestAuthConfig(t *testing.T) {
+	authCfg, err := authConfig(Config{}, nil)
+	require.NoError(t, err)
+	require.Nil(t, authCfg)
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks := fmt.Sprintf(`{"keys":[{"kty":"RSA","kid":"k1","n":%q,"e":"AQAB"}]}`,
+		base64.RawURLEncoding.EncodeToString(priv.N.Bytes()))
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, []byte(jwks), 0o600))
+
+	base := Config{AuthEnabled: true}
+	base.JWT = JWTConfig{JWKSFile: path, Issuer: "https://sso", Audience: "review-service", DefaultScopes: []string{"read"}}
+	authCfg, err = authConfig(base, nil)
+	require.NoError(t, err)
+	require.NotNil(t, authCfg)
+	require.Len(t, authCfg.Authenticator, 2)
+
+	cfg := base
+	cfg.JWT.JWKSURL = "https://sso/jwks"
+	_, err = authConfig(cfg, nil)
+	require.ErrorContains(t, err, "only one")
+
+	cfg = base
+	cfg.JWT.Audience = ""
+	_, err = authConfig(cfg, nil)
+	require.ErrorContains(t, err, "audience")
+
+	cfg = base
+	cfg.JWT.DefaultScopes = []string{"root"}
+	_, err = authConfig(cfg, nil)
+	require.ErrorContains(t, err, "unknown scope")
+
+	cfg = base
+	cfg.JWT.JWKSFile = filepath.Join(t.TempDir(), "missing.json")
+	_, err = authConfig(cfg, nil)
+	require.Error(t, err)
+}
+
+func TestRunRoleValidatesArguments(t *testing.T) {
+	var out bytes.Buffer
+	ctx := context.Background()
+	require.Error(t, runRole(ctx, Config{}, nil, &out))
+	require.ErrorContains(t, runRole(ctx, Config{}, []string{"grant"}, &out), "unknown role command")
+	require.ErrorContains(t, runRole(ctx, Config{}, []string{"assign", "-user", "u1"}, &out), "-role")
+	require.ErrorContains(t, runRole(ctx, Config{}, []string{"assign", "-user", "u1", "-role", "org_admin"}, &out), "DB_DSN")
+}
+
+func TestLoadConfigOrganization(t *testing.T) {
+	t.Setenv("ORG_ID", "acme")
+	t.Setenv("DB_ROW_LEVEL_SECURITY", "true")
+	t.Setenv("JWT_ORG_CLAIM", "org")
+	cfg := mustLoadConfig(t)
+	require.Equal(t, "acme", cfg.Org)
+	require.True(t, cfg.RowLevelSecurity)
+	require.Equal(t, "org", cfg.JWT.OrgClaim)
+
+	// Подкоманды не запускаются с некорректной организацией.
+	err := execute(context.Background(), []string{"export"}, Config{Org: "Not Valid"})
+	require.ErrorContains(t, err, "invalid organization")
+}
+
+func TestLoadConfigIdempotencyTTL(t *testing.T) {
+	require.Equal(t, handler.DefaultIdempotencyTTL, mustLoadConfig(t).IdempotencyTTL)
+
+	t.Setenv("IDEMPOTENCY_TTL", "2h")
+	require.Equal(t, 2*time.Hour, mustLoadConfig(t).IdempotencyTTL)
+
+	t.Setenv("IDEMPOTENCY_TTL", "soon")
+	_, _, err := loadConfig(nil)
+	require.ErrorContains(t, err, `idempotency.ttl (env IDEMPOTENCY_TTL): invalid value "soon"`)
+}
+
+func TestLoadConfigGRPCPort(t *testing.T) {
+	require.Equal(t, "50051", mustLoadConfig(t).GRPCPort)
+
+	t.Setenv("GRPC_PORT", "")
+	ln, err := grpcListener(mustLoadConfig(t))
+	require.NoError(t, err)
+	require.Nil(t, ln)
+}
+
+func TestLoadConfigAutoMigrate(t *testing.T) {
+	require.False(t, mustLoadConfig(t).AutoMigrate)
+	t.Setenv("DB_AUTO_MIGRATE", "true")
+	require.True(t, mustLoadConfig(t).AutoMigrate)
+}
+
+func TestLoadConfigLayers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+http:
+  port: 9000
+  write_timeout: 2m
+db:
+  dsn: postgres://app:secret@db:5432/reviews
+  max_conns: 20
+auth:
+  public_paths: [/health, /docs]
+features:
+  grpc: false
+`), 0o600))
+
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("APP_PORT", "9100")
+	t.Setenv("DB_MAX_CONNS", "")
+	cfg, rest, err := loadConfig([]string{"-http.port", "9200", "-db.auto_migrate", "export", "-out", "x"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"export", "-out", "x"}, rest)
+
+	// Флаг важнее окружения, окружение важнее файла.
+	require.Equal(t, "9200", cfg.Port)
+	require.Equal(t, 2*time.Minute, cfg.HTTP.WriteTimeout)
+	require.Equal(t, 20, cfg.Pool.MaxConns)
+	require.Equal(t, []string{"/health", "/docs"}, cfg.AuthPublicPaths)
+	require.True(t, cfg.AutoMigrate)
+	require.False(t, cfg.Features.GRPC)
+	require.True(t, cfg.Features.Events)
+	require.Equal(t, 5*time.Second, cfg.HTTP.ReadHeaderTimeout)
+
+	ln, err := grpcListener(cfg)
+	require.NoError(t, err)
+	require.Nil(t, ln)
+
+	var out bytes.Buffer
+	require.NoError(t, runConfig(cfg, []string{"print"}, &out))
+	printed := out.String()
+	require.Contains(t, printed, `port: "9200" # flag -http.port`)
+	require.Contains(t, printed, `write_timeout: 2m0s # file `+path)
+	require.Contains(t, printed, `dsn: postgres://app:REDACTED@db:5432/reviews # file `)
+	require.Contains(t, printed, `read_header_timeout: 5s # default`)
+	require.NotContains(t, printed, "secret")
+
+	require.Error(t, runConfig(cfg, nil, &out))
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(body), 0o600))
+		return path
+	}
+
+	tests := []struct {
+		name  string
+		env   map[string]string
+		args  []string
+		error string
+	}{
+		{"unknown file key", nil, []string{"-config", write("typo.yaml", "http:\n  prot: 80\n")}, `unknown key "http.prot"`},
+		{"bad yaml", nil, []string{"-config", write("bad.yaml", "http: [\n")}, "parse config file"},
+		{"missing file", nil, []string{"-config", filepath.Join(dir, "none.yaml")}, "read config file"},
+		{"unknown flag", nil, []string{"-http.prot", "80"}, "flag provided but not defined"},
+		{"bad flag value", nil, []string{"-db.max_conns", "many"}, `db.max_conns (flag -db.max_conns): invalid value "many"`},
+		{"bad env value", map[string]string{"FEATURE_EVENTS": "maybe"}, nil, "features.events (env FEATURE_EVENTS)"},
+		{"port", map[string]string{"APP_PORT": "http"}, nil, "http.port"},
+		{"pool bounds", map[string]string{"DB_MIN_CONNS": "10", "DB_MAX_CONNS": "5"}, nil, "must not exceed db.max_conns"},
+		{"shutdown grace", map[string]string{"SHUTDOWN_GRACE": "0s"}, nil, "shutdown_grace"},
+		{"pool usage", map[string]string{"HEALTH_POOL_MAX_USAGE": "0"}, nil, "health.pool_max_usage"},
+		{"log level", map[string]string{"LOG_LEVEL": "trace"}, nil, "log.level"},
+		{"trace exporter", map[string]string{"TRACING_EXPORTER": "zipkin"}, nil, "tracing.exporter"},
+		{"sample ratio", map[string]string{"TRACING_SAMPLE_RATIO": "1.5"}, nil, "tracing.sample_ratio"},
+		{"jwks", map[string]string{"JWT_JWKS_FILE": "a.json", "JWT_JWKS_URL": "https://sso/jwks"}, nil, "only one of jwks_file and jwks_url"},
+		{"scopes", map[string]string{"JWT_DEFAULT_SCOPES": "root"}, nil, "jwt.default_scopes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			_, _, err := loadConfig(tt.args)
+			require.ErrorContains(t, err, tt.error)
+		})
+	}
+}
+
+func TestRedactDSN(t *testing.T) {
+	require.Equal(t, "postgres://app:REDACTED@db/reviews?sslmode=disable", redactDSN("postgres://app:pw@db/reviews?sslmode=disable"))
+	require.Equal(t, "postgres://db/reviews?password=REDACTED", redactDSN("postgres://db/reviews?password=pw"))
+	require.Equal(t, "host=db user=app password=REDACTED dbname=reviews", redactDSN("host=db user=app password=pw dbname=reviews"))
+	require.Equal(t, "host=db password=REDACTED", redactDSN("host=db password='p w'"))
 }
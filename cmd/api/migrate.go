@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/trainee/review-service/migrations"
+)
+
+// runMigrate реализует подкоманды миграций схемы:
+//
+//	api migrate up
+//	api migrate down [-steps 1]
+//	api migrate status
+//
+// Миграции встроены в бинарник; результат печатается в stdout в формате JSON.
+func runMigrate(ctx context.Context, cfg Config, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: migrate up|down|status [flags]")
+	}
+	fs := flag.NewFlagSet("migrate "+args[0], flag.ContinueOnError)
+	steps := fs.Int("steps", 1, "number of migrations to roll back (down)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	switch args[0] {
+	case "up", "status":
+	case "down":
+		if *steps < 1 {
+			return errors.New("-steps must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q", args[0])
+	}
+
+	dbPool, err := openPool(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+	m, err := migrations.New(dbPool)
+	if err != nil {
+		return err
+	}
+
+	var result any
+	switch args[0] {
+	case "up":
+		applied, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		result = map[string]any{"applied": versions(applied)}
+	case "down":
+		reverted, err := m.Down(ctx, *steps)
+		if err != nil {
+			return err
+		}
+		result = map[string]any{"reverted": versions(reverted)}
+	case "status":
+		st, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		result = st
+	}
+
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
+
+// ensureSchema не даёт запустить сервер на схеме другой версии. С DB_AUTO_MIGRATE
+// недостающие миграции применяются при старте; более новая схема (после отката
+// бинарника) — всегда ошибка.
+func ensureSchema(ctx context.Context, pool *pgxpool.Pool, autoMigrate bool) error {
+	m, err := migrations.New(pool)
+	if err != nil {
+		return err
+	}
+	st, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	outdated := !st.Dirty && st.Version < st.Latest
+	if outdated && autoMigrate {
+		applied, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		slog.Info("Database migrated", "applied", versions(applied))
+	}
+	if err := m.Check(ctx); err != nil {
+		if outdated {
+			return fmt.Errorf("%w: run `api migrate up` or set DB_AUTO_MIGRATE=true", err)
+		}
+		return err
+	}
+	return nil
+}
+
+func versions(list []migrations.Migration) []uint {
+	result := make([]uint, 0, len(list))
+	for _, m := range list {
+		result = append(result, m.Version)
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/trainee/review-service/internal/model"
+	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/service"
+)
+
+// runRole реализует подкоманды управления ролями:
+//
+//	api role assign -user u1 -role org_admin
+//	api role assign -user u2 -role team_lead -team backend
+//	api role revoke -user u2 -role team_lead -team backend
+//	api role list [-user u1]
+//
+// Нужна для назначения первого администратора организации.
+func runRole(ctx context.Context, cfg Config, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: role assign|revoke|list [flags]")
+	}
+	fs := flag.NewFlagSet("role "+args[0], flag.ContinueOnError)
+	userID := fs.String("user", "", "user id")
+	role := fs.String("role", "", "org_admin or team_lead")
+	team := fs.String("team", "", "team name (team_lead only)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	assignment := model.RoleAssignment{UserID: *userID, Role: model.Role(*role), TeamName: *team}
+
+	switch args[0] {
+	case "assign", "revoke":
+		if *userID == "" || *role == "" {
+			return errors.New("-user and -role are required")
+		}
+	case "list":
+	default:
+		return fmt.Errorf("unknown role command %q", args[0])
+	}
+
+	dbPool, err := openPool(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+	svc := service.NewService(repo.NewPostgresRepository(dbPool))
+
+	var result any
+	switch args[0] {
+	case "assign":
+		err = svc.AssignRole(ctx, assignment)
+		result = map[string]any{"role": assignment}
+	case "revoke":
+		err = svc.RevokeRole(ctx, assignment)
+		result = map[string]any{"role": assignment, "revoked": true}
+	case "list":
+		var roles []model.RoleAssignment
+		roles, err = svc.ListRoles(ctx, *userID)
+		result = map[string]any{"roles": roles}
+	}
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/trainee/review-service/internal/auth"
+	repo "github.com/trainee/review-service/internal/repository"
+)
+
+// runToken реализует подкоманды управления API токенами:
+//
+//	api token create -name ops -scopes admin [-ttl 720h]
+//	api token list
+//	api token revoke -id tok_...
+//
+// Нужна в первую очередь для выпуска первого admin-токена, дальше токены можно
+// выпускать через /auth/token/create.
+func runToken(ctx context.Context, cfg Config, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: token create|list|revoke [flags]")
+	}
+	fs := flag.NewFlagSet("token "+args[0], flag.ContinueOnError)
+	name := fs.String("name", "", "token name (create)")
+	scopes := fs.String("scopes", auth.ScopeRead, "comma-separated scopes (create)")
+	ttl := fs.Duration("ttl", 0, "token lifetime, 0 for no expiry (create)")
+	id := fs.String("id", "", "token id (revoke)")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "create":
+		if *name == "" {
+			return errors.New("-name is required")
+		}
+		if err := auth.ValidateScopes(splitList(*scopes)); err != nil {
+			return err
+		}
+	case "revoke":
+		if *id == "" {
+			return errors.New("-id is required")
+		}
+	case "list":
+	default:
+		return fmt.Errorf("unknown token command %q", args[0])
+	}
+
+	dbPool, err := openPool(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer dbPool.Close()
+	tokens := auth.NewTokens(repo.NewPostgresRepository(dbPool))
+
+	var result any
+	switch args[0] {
+	case "create":
+		value, token, err := tokens.Issue(ctx, *name, splitList(*scopes), *ttl)
+		if err != nil {
+			return err
+		}
+		result = map[string]any{"token": value, "api_token": token}
+	case "list":
+		list, err := tokens.List(ctx)
+		if err != nil {
+			return err
+		}
+		result = map[string]any{"tokens": list}
+	case "revoke":
+		if err := tokens.Revoke(ctx, *id); err != nil {
+			return err
+		}
+		result = map[string]any{"token_id": *id, "revoked": true}
+	}
+
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(result)
+}
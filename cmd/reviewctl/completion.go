@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+)
+
+var completionCommands = []command{
+	{name: "bash", summary: "bash completion: source <(reviewctl completion bash)", setup: func(fs *flag.FlagSet) runFunc {
+		return completionRun(writeBash)
+	}},
+	{name: "zsh", summary: "zsh completion: source <(reviewctl completion zsh)", setup: func(fs *flag.FlagSet) runFunc {
+		return completionRun(writeZsh)
+	}},
+	{name: "fish", summary: "fish completion: reviewctl completion fish | source", setup: func(fs *flag.FlagSet) runFunc {
+		return completionRun(writeFish)
+	}},
+}
+
+func completionRun(write func(w io.Writer)) runFunc {
+	return func(_ context.Context, a *app, args []string) error {
+		if err := argN(args, 0, "completion bash|zsh|fish"); err != nil {
+			return err
+		}
+		write(a.stdout)
+		return nil
+	}
+}
+
+// commandFlags возвращает флаги подкоманды (без глобальных) и булевы флаги,
+// которые не принимают значение.
+func commandFlags(c command) (names, boolFlags []string) {
+	fs := flag.NewFlagSet(c.name, flag.ContinueOnError)
+	c.setup(fs)
+	fs.VisitAll(func(f *flag.Flag) {
+		names = append(names, "-"+f.Name)
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+			boolFlags = append(boolFlags, "-"+f.Name)
+		}
+	})
+	return names, boolFlags
+}
+
+func globalFlagNames() []string {
+	fs := flag.NewFlagSet("reviewctl", flag.ContinueOnError)
+	new(globalFlags).register(fs)
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) { names = append(names, "-"+f.Name) })
+	return names
+}
+
+func groupNames() []string {
+	names := make([]string, 0, len(groups))
+	for _, g := range groups {
+		names = append(names, g.name)
+	}
+	return names
+}
+
+// writeBash генерирует скрипт по дереву команд: группы, подкоманды, флаги и
+// значения -o. Слова после флагов со значением не считаются подкомандами.
+func writeBash(w io.Writer) {
+	global := globalFlagNames()
+	boolSet := map[string]bool{}
+
+	var commandCases, flagCases strings.Builder
+	for _, g := range groups {
+		names := make([]string, 0, len(g.commands))
+		for _, c := range g.commands {
+			names = append(names, c.name)
+			flags, bools := commandFlags(c)
+			for _, b := range bools {
+				boolSet[b] = true
+			}
+			fmt.Fprintf(&flagCases, "        %q) flags=%q ;;\n", g.name+" "+c.name, strings.Join(append(flags, global...), " "))
+		}
+		fmt.Fprintf(&commandCases, "            %s) COMPREPLY=($(compgen -W %q -- \"$cur\")) ;;\n", g.name, strings.Join(names, " "))
+	}
+	bools := sortedKeys(boolSet)
+	bools = append(bools, "-h", "-help")
+
+	fmt.Fprintf(w, `# bash completion for reviewctl
+_reviewctl() {
+    local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}"
+    local words=() skip=0 i w
+    for ((i = 1; i < COMP_CWORD; i++)); do
+        w="${COMP_WORDS[i]}"
+        if ((skip)); then skip=0; continue; fi
+        case "$w" in
+            %s|-*=*) ;;
+            -*) skip=1 ;;
+            *) words+=("$w") ;;
+        esac
+    done
+
+    if [[ "$prev" == "-o" ]]; then
+        COMPREPLY=($(compgen -W %q -- "$cur"))
+        return
+    fi
+    if [[ "$cur" == -* ]]; then
+        local flags=%q
+        case "${words[0]} ${words[1]}" in
+%s        esac
+        COMPREPLY=($(compgen -W "$flags" -- "$cur"))
+        return
+    fi
+    case ${#words[@]} in
+        0) COMPREPLY=($(compgen -W %q -- "$cur")) ;;
+        1)
+            case "${words[0]}" in
+%s            esac
+            ;;
+    esac
+}
+complete -o default -F _reviewctl reviewctl
+`, strings.Join(bools, "|"), strings.Join(formats, " "), strings.Join(global, " "),
+		flagCases.String(), strings.Join(groupNames(), " "), commandCases.String())
+}
+
+// writeZsh переиспользует bash-скрипт через bashcompinit.
+func writeZsh(w io.Writer) {
+	fmt.Fprintln(w, "# zsh completion for reviewctl")
+	fmt.Fprintln(w, "autoload -U +X compinit && compinit")
+	fmt.Fprintln(w, "autoload -U +X bashcompinit && bashcompinit")
+	writeBash(w)
+}
+
+func writeFish(w io.Writer) {
+	fmt.Fprintln(w, "# fish completion for reviewctl")
+	fmt.Fprintln(w, "complete -c reviewctl -f")
+	for _, name := range globalFlagNames() {
+		if name == "-o" {
+			continue
+		}
+		fmt.Fprintf(w, "complete -c reviewctl -o %s -r\n", strings.TrimPrefix(name, "-"))
+	}
+	fmt.Fprintf(w, "complete -c reviewctl -o o -x -a %q\n", strings.Join(formats, " "))
+
+	groupList := strings.Join(groupNames(), " ")
+	for _, g := range groups {
+		fmt.Fprintf(w, "complete -c reviewctl -n %q -a %s -d %q\n", "not __fish_seen_subcommand_from "+groupList, g.name, g.summary)
+		names := make([]string, 0, len(g.commands))
+		for _, c := range g.commands {
+			names = append(names, c.name)
+		}
+		sort.Strings(names)
+		for _, c := range g.commands {
+			cond := fmt.Sprintf("__fish_seen_subcommand_from %s; and not __fish_seen_subcommand_from %s", g.name, strings.Join(names, " "))
+			fmt.Fprintf(w, "complete -c reviewctl -n %q -a %s -d %q\n", cond, c.name, c.summary)
+			flags, bools := commandFlags(c)
+			for _, f := range flags {
+				require := " -r"
+				if slices.Contains(bools, f) {
+					require = ""
+				}
+				cond := fmt.Sprintf("__fish_seen_subcommand_from %s; and __fish_seen_subcommand_from %s", g.name, c.name)
+				fmt.Fprintf(w, "complete -c reviewctl -n %q -o %s%s\n", cond, strings.TrimPrefix(f, "-"), require)
+			}
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const defaultServer = "http://localhost:8080"
+
+// settings — параметры подключения одного профиля.
+type settings struct {
+	Server string `yaml:"server,omitempty" json:"server,omitempty"`
+	Token  string `yaml:"token,omitempty" json:"token,omitempty"`
+	Org    string `yaml:"org,omitempty" json:"org,omitempty"`
+	// Output — формат вывода по умолчанию для профиля.
+	Output string `yaml:"output,omitempty" json:"output,omitempty"`
+}
+
+// configFile — файл профилей, по одному на окружение:
+//
+//	current: staging
+//	profiles:
+//	  staging:
+//	    server: https://review.staging.example.com
+//	    token: rvw_...
+//	    org: acme
+type configFile struct {
+	Current  string              `yaml:"current,omitempty"`
+	Profiles map[string]settings `yaml:"profiles,omitempty"`
+}
+
+// configPath — путь из флага, REVIEWCTL_CONFIG или ~/.config/reviewctl/config.yaml.
+func configPath(flagValue string) (string, error) {
+	if flagValue != "" {
+		return flagValue, nil
+	}
+	if path := os.Getenv("REVIEWCTL_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "reviewctl", "config.yaml"), nil
+}
+
+// loadConfigFile читает файл профилей; отсутствующий файл — пустой конфиг.
+func loadConfigFile(path string) (*configFile, error) {
+	cfg := &configFile{Profiles: map[string]settings{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]settings{}
+	}
+	return cfg, nil
+}
+
+// save пишет конфиг с правами 0600: в нём хранятся токены.
+func (c *configFile) save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return nil
+}
+
+// resolve выбирает профиль и накладывает переопределения.
+// Приоритет: флаги > переменные окружения REVIEWCTL_* > профиль > значения по умолчанию.
+func (c *configFile) resolve(g globalFlags) (string, settings) {
+	name := firstNonEmpty(g.profile, os.Getenv("REVIEWCTL_PROFILE"), c.Current)
+	s := c.Profiles[name]
+	s.Server = firstNonEmpty(g.server, os.Getenv("REVIEWCTL_SERVER"), s.Server, defaultServer)
+	s.Token = firstNonEmpty(g.token, os.Getenv("REVIEWCTL_TOKEN"), s.Token)
+	s.Org = firstNonEmpty(g.org, os.Getenv("REVIEWCTL_ORG"), s.Org)
+	s.Output = firstNonEmpty(g.output, os.Getenv("REVIEWCTL_OUTPUT"), s.Output)
+	return name, s
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// redact скрывает токен при выводе профилей.
+func redact(token string) string {
+	if token == "" {
+		return ""
+	}
+	if len(token) <= 8 {
+		return "****"
+	}
+	return token[:4] + "****"
+}
+
+var configCommands = []command{
+	{name: "list", summary: "list profiles", setup: func(fs *flag.FlagSet) runFunc {
+		return configList
+	}},
+	{name: "current", summary: "show the effective connection settings", setup: func(fs *flag.FlagSet) runFunc {
+		return configCurrent
+	}},
+	{name: "set", args: "PROFILE", summary: "create or update a profile", setup: func(fs *flag.FlagSet) runFunc {
+		// Флаги -server, -token, -org и -o общие; здесь они задают значения профиля.
+		return configSet(fs)
+	}},
+	{name: "use", args: "PROFILE", summary: "make a profile current", setup: func(fs *flag.FlagSet) runFunc {
+		return configUse
+	}},
+	{name: "delete", args: "PROFILE", summary: "delete a profile", setup: func(fs *flag.FlagSet) runFunc {
+		return configDelete
+	}},
+}
+
+type profileView struct {
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+	settings
+}
+
+func configList(_ context.Context, a *app, args []string) error {
+	if err := argN(args, 0, "config list"); err != nil {
+		return err
+	}
+	views := make([]profileView, 0, len(a.config.Profiles))
+	t := table{header: []string{"CURRENT", "NAME", "SERVER", "ORG", "TOKEN"}}
+	for _, name := range sortedKeys(a.config.Profiles) {
+		s := a.config.Profiles[name]
+		s.Token = redact(s.Token)
+		v := profileView{Name: name, Current: name == a.config.Current, settings: s}
+		views = append(views, v)
+		mark := ""
+		if v.Current {
+			mark = "*"
+		}
+		t.add(mark, name, s.Server, s.Org, s.Token)
+	}
+	return a.print(map[string]any{"profiles": views}, t)
+}
+
+func configCurrent(_ context.Context, a *app, args []string) error {
+	if err := argN(args, 0, "config current"); err != nil {
+		return err
+	}
+	s := a.settings
+	s.Token = redact(s.Token)
+	v := profileView{Name: a.profile, Current: true, settings: s}
+	t := table{header: []string{"PROFILE", "SERVER", "ORG", "TOKEN", "OUTPUT"}}
+	t.add(v.Name, s.Server, s.Org, s.Token, a.format)
+	return a.print(v, t)
+}
+
+func configSet(fs *flag.FlagSet) runFunc {
+	return func(_ context.Context, a *app, args []string) error {
+		if err := argN(args, 1, "config set PROFILE [-server URL] [-token TOKEN] [-org ORG] [-o FORMAT]"); err != nil {
+			return err
+		}
+		name := args[0]
+		p := a.config.Profiles[name]
+		// Меняются только явно переданные флаги.
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "server":
+				p.Server = f.Value.String()
+			case "token":
+				p.Token = f.Value.String()
+			case "org":
+				p.Org = f.Value.String()
+			case "o":
+				p.Output = f.Value.String()
+			}
+		})
+		if p.Output != "" && !validFormat(p.Output) {
+			return fmt.Errorf("unknown output format %q", p.Output)
+		}
+		a.config.Profiles[name] = p
+		if a.config.Current == "" {
+			a.config.Current = name
+		}
+		if err := a.config.save(a.path); err != nil {
+			return err
+		}
+		fmt.Fprintf(a.stdout, "Profile %q saved to %s\n", name, a.path)
+		return nil
+	}
+}
+
+func configUse(_ context.Context, a *app, args []string) error {
+	if err := argN(args, 1, "config use PROFILE"); err != nil {
+		return err
+	}
+	if _, ok := a.config.Profiles[args[0]]; !ok {
+		return fmt.Errorf("profile %q not found", args[0])
+	}
+	a.config.Current = args[0]
+	if err := a.config.save(a.path); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Switched to profile %q\n", args[0])
+	return nil
+}
+
+func configDelete(_ context.Context, a *app, args []string) error {
+	if err := argN(args, 1, "config delete PROFILE"); err != nil {
+		return err
+	}
+	if _, ok := a.config.Profiles[args[0]]; !ok {
+		return fmt.Errorf("profile %q not found", args[0])
+	}
+	delete(a.config.Profiles, args[0])
+	if a.config.Current == args[0] {
+		a.config.Current = ""
+	}
+	if err := a.config.save(a.path); err != nil {
+		return err
+	}
+	fmt.Fprintf(a.stdout, "Profile %q deleted\n", args[0])
+	return nil
+}
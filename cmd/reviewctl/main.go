@@ -0,0 +1,267 @@
+// Command reviewctl — консольный клиент сервиса назначения ревьюеров.
+//
+//	reviewctl pr reviewers PR-123
+//	reviewctl user deactivate alice -handoff
+//	reviewctl team workload backend -o yaml
+//
+// Адрес сервиса, токен и организация берутся из профиля (reviewctl config),
+// переменных окружения REVIEWCTL_* или глобальных флагов.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/trainee/review-service/pkg/client"
+)
+
+var exit = os.Exit
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := execute(ctx, os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "reviewctl: %v\n", err)
+		exit(1)
+	}
+}
+
+// command — подкоманда «группа действие». setup регистрирует флаги и возвращает
+// обработчик; по зарегистрированным флагам строится и автодополнение.
+type command struct {
+	name    string
+	args    string
+	summary string
+	setup   func(fs *flag.FlagSet) runFunc
+}
+
+type runFunc func(ctx context.Context, a *app, args []string) error
+
+type group struct {
+	name     string
+	summary  string
+	commands []command
+}
+
+// groups заполняется в init: обработчики completion обращаются к самому дереву команд.
+var groups []group
+
+func init() {
+	groups = []group{
+		{name: "team", summary: "teams and their workload", commands: teamCommands},
+		{name: "user", summary: "users, their reviews and activity", commands: userCommands},
+		{name: "pr", summary: "pull requests and reviewers", commands: prCommands},
+		{name: "config", summary: "connection profiles", commands: configCommands},
+		{name: "completion", summary: "shell completion scripts", commands: completionCommands},
+	}
+}
+
+// globalFlags — параметры подключения и вывода, общие для всех команд.
+type globalFlags struct {
+	configPath string
+	profile    string
+	server     string
+	token      string
+	org        string
+	output     string
+}
+
+// register регистрирует флаги; текущие значения g становятся значениями по умолчанию,
+// чтобы повторная регистрация для подкоманды их не сбрасывала.
+func (g *globalFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&g.configPath, "config", g.configPath, "config file (default $REVIEWCTL_CONFIG or ~/.config/reviewctl/config.yaml)")
+	fs.StringVar(&g.profile, "profile", g.profile, "profile name (default $REVIEWCTL_PROFILE or the current profile)")
+	fs.StringVar(&g.server, "server", g.server, "service URL, overrides the profile")
+	fs.StringVar(&g.token, "token", g.token, "API token or JWT, overrides the profile")
+	fs.StringVar(&g.org, "org", g.org, "organization (X-Org-ID), overrides the profile")
+	fs.StringVar(&g.output, "o", g.output, "output format: table, json or yaml")
+}
+
+// execute разбирает глобальные флаги и запускает подкоманду.
+func execute(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	var g globalFlags
+	fs := flag.NewFlagSet("reviewctl", flag.ContinueOnError)
+	fs.SetOutput(stdout)
+	g.register(fs)
+	fs.Usage = func() { printUsage(stdout) }
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	args = fs.Args()
+	if len(args) == 0 || args[0] == "help" {
+		printUsage(stdout)
+		return nil
+	}
+
+	grp, ok := findGroup(args[0])
+	if !ok {
+		return fmt.Errorf("unknown command %q, see reviewctl help", args[0])
+	}
+	if len(args) < 2 {
+		printGroupUsage(stdout, grp)
+		return fmt.Errorf("%s: missing subcommand", grp.name)
+	}
+	cmd, ok := findCommand(grp, args[1])
+	if !ok {
+		return fmt.Errorf("unknown %s command %q", grp.name, args[1])
+	}
+
+	cfs := flag.NewFlagSet(grp.name+" "+cmd.name, flag.ContinueOnError)
+	cfs.SetOutput(stdout)
+	// Флаги подключения и вывода можно указывать и после подкоманды.
+	g.register(cfs)
+	run := cmd.setup(cfs)
+	cfs.Usage = func() {
+		fmt.Fprintf(stdout, "Usage: reviewctl %s %s [flags] %s\n\n%s\n\nFlags:\n", grp.name, cmd.name, cmd.args, cmd.summary)
+		cfs.PrintDefaults()
+	}
+	positional, err := parseInterspersed(cfs, args[2:])
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	a, err := newApp(g, stdin, stdout)
+	if err != nil {
+		return err
+	}
+	return run(ctx, a, positional)
+}
+
+// parseInterspersed разрешает флаги после позиционных аргументов:
+// «pr get PR-1 -o json» работает так же, как «pr get -o json PR-1».
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func findGroup(name string) (group, bool) {
+	for _, g := range groups {
+		if g.name == name {
+			return g, true
+		}
+	}
+	return group{}, false
+}
+
+func findCommand(g group, name string) (command, bool) {
+	for _, c := range g.commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: reviewctl [global flags] <group> <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Groups:")
+	for _, g := range groups {
+		fmt.Fprintf(w, "  %-11s %s\n", g.name, g.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Global flags:")
+	fs := flag.NewFlagSet("reviewctl", flag.ContinueOnError)
+	fs.SetOutput(w)
+	new(globalFlags).register(fs)
+	fs.PrintDefaults()
+}
+
+func printGroupUsage(w io.Writer, g group) {
+	fmt.Fprintf(w, "Usage: reviewctl %s <command> [flags] [args]\n\nCommands:\n", g.name)
+	for _, c := range g.commands {
+		fmt.Fprintf(w, "  %-28s %s\n", strings.TrimSpace(c.name+" "+c.args), c.summary)
+	}
+}
+
+// app — окружение выполнения команды: профиль, формат вывода и клиент API.
+type app struct {
+	stdin    io.Reader
+	stdout   io.Writer
+	format   string
+	settings settings
+	profile  string
+	config   *configFile
+	path     string
+
+	client *client.Client
+}
+
+func newApp(g globalFlags, stdin io.Reader, stdout io.Writer) (*app, error) {
+	path, err := configPath(g.configPath)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := loadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	name, s := cfg.resolve(g)
+	format := s.Output
+	if format == "" {
+		format = formatTable
+	}
+	if !validFormat(format) {
+		return nil, fmt.Errorf("unknown output format %q, want %s", format, strings.Join(formats, ", "))
+	}
+	return &app{stdin: stdin, stdout: stdout, format: format, settings: s, profile: name, config: cfg, path: path}, nil
+}
+
+// api лениво создаёт клиент: команды config и completion к сервису не обращаются.
+func (a *app) api() (*client.Client, error) {
+	if a.client != nil {
+		return a.client, nil
+	}
+	opts := []client.Option{client.WithOrg(a.settings.Org)}
+	if a.settings.Token != "" {
+		opts = append(opts, client.WithToken(a.settings.Token))
+	}
+	c, err := client.New(a.settings.Server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	a.client = c
+	return c, nil
+}
+
+// argN проверяет число позиционных аргументов.
+func argN(args []string, n int, usage string) error {
+	if len(args) != n {
+		return fmt.Errorf("usage: reviewctl %s", usage)
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// stubAPI — минимальный сервер /v2 с двумя командами и одним PR.
+type stubAPI struct {
+	mu         sync.Mutex
+	active     map[string]bool
+	reassigned []string
+	headers    http.Header
+}
+
+func newStubAPI(t *testing.T) (*stubAPI, *httptest.Server) {
+	t.Helper()
+	s := &stubAPI{active: map[string]bool{"u1": true, "u2": true, "u3": true}}
+	users := map[string]map[string]any{
+		"u1": {"user_id": "u1", "username": "alice", "team_name": "backend", "open_review_count": 2},
+		"u2": {"user_id": "u2", "username": "bob", "team_name": "backend", "open_review_count": 0},
+		"u3": {"user_id": "u3", "username": "carol", "team_name": "backend", "open_review_count": 1},
+	}
+	user := func(id string) map[string]any {
+		u := map[string]any{}
+		for k, v := range users[id] {
+			u[k] = v
+		}
+		u["is_active"] = s.active[id]
+		return u
+	}
+
+	mux := http.NewServeMux()
+	respond := func(w http.ResponseWriter, status int, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(v)
+	}
+	mux.HandleFunc("GET /v2/teams/{name}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("name") != "backend" {
+			respond(w, http.StatusNotFound, map[string]any{"error": map[string]string{"code": "NOT_FOUND", "message": "team not found"}})
+			return
+		}
+		respond(w, http.StatusOK, map[string]any{"team_name": "backend", "members": []any{user("u1"), user("u2"), user("u3")}})
+	})
+	mux.HandleFunc("GET /v2/users", func(w http.ResponseWriter, r *http.Request) {
+		// Две страницы: клиент должен пройти по курсору.
+		if r.URL.Query().Get("cursor") == "" {
+			respond(w, http.StatusOK, map[string]any{"users": []any{user("u1"), user("u2")}, "next_cursor": "c1"})
+			return
+		}
+		respond(w, http.StatusOK, map[string]any{"users": []any{user("u3")}})
+	})
+	mux.HandleFunc("GET /v2/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.headers = r.Header.Clone()
+		s.mu.Unlock()
+		w.Header().Set("ETag", `"3"`)
+		respond(w, http.StatusOK, user(r.PathValue("id")))
+	})
+	mux.HandleFunc("PATCH /v2/teams/{name}/members/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Match") != `"3"` {
+			respond(w, http.StatusPreconditionFailed, map[string]any{"error": map[string]string{"code": "PRECONDITION_FAILED", "message": "stale"}})
+			return
+		}
+		var body struct {
+			IsActive bool `json:"is_active"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		s.active[r.PathValue("id")] = body.IsActive
+		respond(w, http.StatusOK, user(r.PathValue("id")))
+	})
+	mux.HandleFunc("GET /v2/users/{id}/reviews", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, http.StatusOK, map[string]any{"pull_requests": []any{
+			map[string]any{"pull_request_id": "pr-1", "pull_request_name": "Add search", "author_id": "u2", "status": "OPEN"},
+			map[string]any{"pull_request_id": "pr-2", "pull_request_name": "Fix cache", "author_id": "u3", "status": "OPEN"},
+		}})
+	})
+	mux.HandleFunc("DELETE /v2/pull-requests/{id}/reviewers/{user}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.reassigned = append(s.reassigned, r.PathValue("id"))
+		s.mu.Unlock()
+		if r.PathValue("id") == "pr-2" {
+			respond(w, http.StatusConflict, map[string]any{"error": map[string]string{"code": "NO_CANDIDATE", "message": "no active replacement candidate in team"}})
+			return
+		}
+		respond(w, http.StatusOK, map[string]any{
+			"pull_request": map[string]any{"pull_request_id": r.PathValue("id"), "status": "OPEN", "assigned_reviewers": []string{"u3"}},
+			"replaced_by":  "u3",
+		})
+	})
+	mux.HandleFunc("GET /v2/pull-requests/{id}/reviewers", func(w http.ResponseWriter, r *http.Request) {
+		respond(w, http.StatusOK, map[string]any{"reviewers": []string{"u1", "u3"}})
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return s, srv
+}
+
+func runCLI(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	err := execute(context.Background(), args, strings.NewReader(""), &out)
+	return out.String(), err
+}
+
+func isolateConfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("REVIEWCTL_CONFIG", path)
+	for _, key := range []string{"REVIEWCTL_PROFILE", "REVIEWCTL_SERVER", "REVIEWCTL_TOKEN", "REVIEWCTL_ORG", "REVIEWCTL_OUTPUT"} {
+		t.Setenv(key, "")
+	}
+	return path
+}
+
+func TestOutputFormats(t *testing.T) {
+	isolateConfig(t)
+	_, srv := newStubAPI(t)
+
+	out, err := runCLI(t, "-server", srv.URL, "pr", "reviewers", "pr-1")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, []string{"USER_ID", "USERNAME", "TEAM", "ACTIVE", "OPEN_REVIEWS"}, strings.Fields(lines[0]))
+	require.Equal(t, []string{"u1", "alice", "backend", "yes", "2"}, strings.Fields(lines[1]))
+
+	// Флаги допускаются и после позиционных аргументов.
+	out, err = runCLI(t, "-server", srv.URL, "pr", "reviewers", "pr-1", "-o", "json")
+	require.NoError(t, err)
+	var payload struct {
+		PullRequestID string `json:"pull_request_id"`
+		Reviewers     []struct {
+			UserID   string `json:"user_id"`
+			Username string `json:"username"`
+		} `json:"reviewers"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &payload))
+	require.Equal(t, "pr-1", payload.PullRequestID)
+	require.Len(t, payload.Reviewers, 2)
+	require.Equal(t, "carol", payload.Reviewers[1].Username)
+
+	out, err = runCLI(t, "-server", srv.URL, "-o", "yaml", "user", "get", "u1")
+	require.NoError(t, err)
+	require.Equal(t, "user_id: u1\nusername: alice\nteam_name: backend\nis_active: true\nopen_review_count: 2\n", out)
+
+	_, err = runCLI(t, "-server", srv.URL, "-o", "xml", "user", "get", "u1")
+	require.ErrorContains(t, err, "unknown output format")
+}
+
+func TestTeamWorkload(t *testing.T) {
+	isolateConfig(t)
+	_, srv := newStubAPI(t)
+
+	out, err := runCLI(t, "-server", srv.URL, "-o", "json", "team", "workload", "backend")
+	require.NoError(t, err)
+	var payload struct {
+		Members []struct {
+			UserID          string `json:"user_id"`
+			OpenReviewCount int    `json:"open_review_count"`
+		} `json:"members"`
+		OpenReviews int `json:"open_reviews"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &payload))
+	require.Equal(t, 3, payload.OpenReviews)
+	require.Len(t, payload.Members, 3)
+	require.Equal(t, "u1", payload.Members[0].UserID)
+	require.Equal(t, "u2", payload.Members[2].UserID)
+
+	_, err = runCLI(t, "-server", srv.URL, "team", "workload", "frontend")
+	require.ErrorContains(t, err, "NOT_FOUND")
+}
+
+func TestDeactivateWithHandoff(t *testing.T) {
+	isolateConfig(t)
+	stub, srv := newStubAPI(t)
+
+	out, err := runCLI(t, "-server", srv.URL, "-o", "json", "user", "deactivate", "u1", "-handoff")
+	require.ErrorContains(t, err, "1 of 2 reviews were not handed off")
+	require.False(t, stub.active["u1"])
+	require.Equal(t, []string{"pr-1", "pr-2"}, stub.reassigned)
+
+	var payload struct {
+		User struct {
+			IsActive bool `json:"is_active"`
+		} `json:"user"`
+		Handoffs []handoffResult `json:"handoffs"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &payload))
+	require.False(t, payload.User.IsActive)
+	require.Equal(t, "u3", payload.Handoffs[0].ReplacedBy)
+	require.Contains(t, payload.Handoffs[1].Error, "NO_CANDIDATE")
+
+	// Без -handoff ревью не трогаются.
+	stub.reassigned = nil
+	_, err = runCLI(t, "-server", srv.URL, "user", "activate", "u1")
+	require.NoError(t, err)
+	require.True(t, stub.active["u1"])
+	require.Empty(t, stub.reassigned)
+}
+
+func TestProfiles(t *testing.T) {
+	path := isolateConfig(t)
+	stub, srv := newStubAPI(t)
+
+	_, err := runCLI(t, "config", "set", "staging", "-server", srv.URL, "-token", "rvw_secret_token", "-org", "acme")
+	require.NoError(t, err)
+	_, err = runCLI(t, "config", "set", "prod", "-server", "https://review.example.com", "-o", "yaml")
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// Первый сохранённый профиль становится текущим; токен в выводе скрыт.
+	out, err := runCLI(t, "config", "list")
+	require.NoError(t, err)
+	require.Contains(t, out, "rvw_****")
+	require.NotContains(t, out, "secret")
+	require.Regexp(t, `\*\s+staging`, out)
+
+	_, err = runCLI(t, "user", "get", "u2")
+	require.NoError(t, err)
+	require.Equal(t, "Bearer rvw_secret_token", stub.headers.Get("Authorization"))
+	require.Equal(t, "acme", stub.headers.Get("X-Org-ID"))
+
+	// Переменные окружения важнее профиля, флаги — важнее переменных.
+	t.Setenv("REVIEWCTL_ORG", "globex")
+	_, err = runCLI(t, "user", "get", "u2")
+	require.NoError(t, err)
+	require.Equal(t, "globex", stub.headers.Get("X-Org-ID"))
+	_, err = runCLI(t, "-org", "initech", "user", "get", "u2")
+	require.NoError(t, err)
+	require.Equal(t, "initech", stub.headers.Get("X-Org-ID"))
+
+	_, err = runCLI(t, "config", "use", "prod")
+	require.NoError(t, err)
+	out, err = runCLI(t, "config", "current")
+	require.NoError(t, err)
+	require.Contains(t, out, "name: prod")
+	require.Contains(t, out, "server: https://review.example.com")
+
+	_, err = runCLI(t, "config", "use", "dev")
+	require.ErrorContains(t, err, `profile "dev" not found`)
+	_, err = runCLI(t, "config", "delete", "prod")
+	require.NoError(t, err)
+	out, err = runCLI(t, "config", "current", "-o", "json")
+	require.NoError(t, err)
+	require.Contains(t, out, defaultServer)
+}
+
+func TestCommandErrors(t *testing.T) {
+	isolateConfig(t)
+
+	_, err := runCLI(t, "deploy")
+	require.ErrorContains(t, err, `unknown command "deploy"`)
+	_, err = runCLI(t, "pr", "close", "pr-1")
+	require.ErrorContains(t, err, `unknown pr command "close"`)
+	_, err = runCLI(t, "pr", "reassign", "pr-1")
+	require.ErrorContains(t, err, "usage: reviewctl pr reassign PR_ID USER_ID")
+	_, err = runCLI(t, "pr", "create", "pr-1", "-name", "x")
+	require.ErrorContains(t, err, "-name and -author are required")
+
+	out, err := runCLI(t, "help")
+	require.NoError(t, err)
+	require.Contains(t, out, "completion")
+}
+
+func TestCompletion(t *testing.T) {
+	isolateConfig(t)
+
+	out, err := runCLI(t, "completion", "bash")
+	require.NoError(t, err)
+	require.Contains(t, out, "complete -o default -F _reviewctl reviewctl")
+	require.Contains(t, out, `"user deactivate") flags="-handoff`)
+	require.Contains(t, out, `compgen -W "team user pr config completion"`)
+
+	out, err = runCLI(t, "completion", "zsh")
+	require.NoError(t, err)
+	require.Contains(t, out, "bashcompinit")
+
+	out, err = runCLI(t, "completion", "fish")
+	require.NoError(t, err)
+	require.Contains(t, out, "-a workload")
+	require.Contains(t, out, `complete -c reviewctl -o o -x -a "table json yaml"`)
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"strings"
+
+	"github.com/trainee/review-service/pkg/client"
+)
+
+var prCommands = []command{
+	{name: "get", args: "PR_ID", summary: "show a pull request", setup: func(fs *flag.FlagSet) runFunc {
+		return prGet
+	}},
+	{name: "reviewers", args: "PR_ID", summary: "who reviews the pull request", setup: func(fs *flag.FlagSet) runFunc {
+		return prReviewers
+	}},
+	{name: "list", summary: "search pull requests, newest first", setup: func(fs *flag.FlagSet) runFunc {
+		var filter client.PRFilter
+		status := fs.String("status", "", "OPEN or MERGED")
+		fs.StringVar(&filter.AuthorID, "author", "", "author user id")
+		fs.StringVar(&filter.ReviewerID, "reviewer", "", "reviewer user id")
+		fs.StringVar(&filter.TeamName, "team", "", "author's team")
+		fs.StringVar(&filter.NameContains, "name", "", "substring of the pull request name")
+		limit := fs.Int("limit", 50, "maximum number of pull requests, 0 for all")
+		return func(ctx context.Context, a *app, args []string) error {
+			filter.Status = client.PRStatus(strings.ToUpper(*status))
+			return prList(ctx, a, args, filter, *limit)
+		}
+	}},
+	{name: "create", args: "PR_ID", summary: "create a pull request and assign reviewers", setup: func(fs *flag.FlagSet) runFunc {
+		name := fs.String("name", "", "pull request name")
+		author := fs.String("author", "", "author user id")
+		return func(ctx context.Context, a *app, args []string) error {
+			return prCreate(ctx, a, args, *name, *author)
+		}
+	}},
+	{name: "merge", args: "PR_ID", summary: "mark a pull request merged", setup: func(fs *flag.FlagSet) runFunc {
+		return prMerge
+	}},
+	{name: "reassign", args: "PR_ID USER_ID", summary: "replace a reviewer with a teammate", setup: func(fs *flag.FlagSet) runFunc {
+		return prReassign
+	}},
+}
+
+func prGet(ctx context.Context, a *app, args []string) error {
+	if err := argN(args, 1, "pr get PR_ID"); err != nil {
+		return err
+	}
+	c, err := a.api()
+	if err != nil {
+		return err
+	}
+	pr, err := c.GetPullRequest(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return a.print(pr, prsTable([]client.PullRequest{*pr}))
+}
+
+// prReviewers отвечает на вопрос «кто ревьюит PR»: ревьюеры с именами и нагрузкой.
+func prReviewers(ctx context.Context, a *app, args []string) error {
+	if err := argN(args, 1, "pr reviewers PR_ID"); err != nil {
+		return err
+	}
+	c, err := a.api()
+	if err != nil {
+		return err
+	}
+	ids, err := c.ListReviewers(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	reviewers := make([]client.UserDetails, 0, len(ids))
+	for _, id := range ids {
+		u, err := c.GetUser(ctx, id)
+		if errors.Is(err, client.ErrNotFound) {
+			// Ревьюер мог быть удалён; показываем хотя бы ID.
+			reviewers = append(reviewers, client.UserDetails{User: client.User{UserID: id}})
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		reviewers = append(reviewers, *u)
+	}
+	return a.print(map[string]any{"pull_request_id": args[0], "reviewers": reviewers}, usersTable(reviewers))
+}
+
+func prList(ctx context.Context, a *app, args []string, filter client.PRFilter, limit int) error {
+	if err := argN(args, 0, "pr list [flags]"); err != nil {
+		return err
+	}
+	c, err := a.api()
+	if err != nil {
+		return err
+	}
+	prs := []client.PullRequest{}
+	var page client.PageRequest
+	for {
+		p, err := c.ListPullRequests(ctx, filter, page)
+		if err != nil {
+			return err
+		}
+		prs = append(prs, p.PullRequests...)
+		if p.NextCursor == "" || (limit > 0 && len(prs) >= limit) {
+			break
+		}
+		page.Cursor = p.NextCursor
+	}
+	if limit > 0 && len(prs) > limit {
+		prs = prs[:limit]
+	}
+	return a.print(map[string]any{"pull_requests": prs}, prsTable(prs))
+}
+
+func prCreate(ctx context.Context, a *app, args []string, name, author string) error {
+	if err := argN(args, 1, "pr create PR_ID -name NAME -author USER_ID"); err != nil {
+		return err
+	}
+	if name == "" || author == "" {
+		return errors.New("-name and -author are required")
+	}
+	c, err := a.api()
+	if err != nil {
+		return err
+	}
+	pr, err := c.CreatePullRequest(ctx, args[0], name, author)
+	if err != nil {
+		return err
+	}
+	return a.print(pr, prsTable([]client.PullRequest{*pr}))
+}
+
+func prMerge(ctx context.Context, a *app, args []string) error {
+	if err := argN(args, 1, "pr merge PR_ID"); err != nil {
+		return err
+	}
+	c, err := a.api()
+	if err != nil {
+		return err
+	}
+	pr, err := c.MergePullRequest(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return a.print(pr, prsTable([]client.PullRequest{*pr}))
+}
+
+func prReassign(ctx context.Context, a *app, args []string) error {
+	if err := argN(args, 2, "pr reassign PR_ID USER_ID"); err != nil {
+		return err
+	}
+	c, err := a.api()
+	if err != nil {
+		return err
+	}
+	pr, replacedBy, err := c.ReassignReviewer(ctx, args[0], args[1])
+	if err != nil {
+		return err
+	}
+	t := table{header: []string{"PULL_REQUEST_ID", "REMOVED", "REPLACED_BY", "REVIEWERS"}}
+	t.add(pr.ID, args[1], replacedBy, strings.Join(pr.AssignedReviewers, ","))
+	return a.print(map[string]any{"pull_request": pr, "replaced_by": replacedBy}, t)
+}
+
+func prsTable(prs []client.PullRequest) table {
+	t := table{header: []string{"PULL_REQUEST_ID", "NAME", "AUTHOR", "STATUS", "REVIEWERS", "CREATED", "MERGED"}}
+	for _, pr := range prs {
+		t.add(pr.ID, pr.Name, pr.AuthorID, string(pr.Status), orDash(strings.Join(pr.AssignedReviewers, ",")),
+			formatTime(pr.CreatedAt), formatTime(pr.MergedAt))
+	}
+	return t
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/trainee/review-service/pkg/client"
+)
+
+var teamCommands = []command{
+	{name: "get", args: "TEAM", summary: "show a team and its members", setup: func(fs *flag.FlagSet) runFunc {
+		return teamGet
+	}},
+	{name: "create", summary: "create a team from a JSON or YAML file", setup: func(fs *flag.FlagSet) runFunc {
+		file := fs.String("f", "-", "team file, - for stdin")
+		return func(ctx context.Context, a *app, args []string) error {
+			return teamCreate(ctx, a, args, *file)
+		}
+	}},
+	{name: "workload", args: "TEAM", summary: "open reviews per team member", setup: func(fs *flag.FlagSet) runFunc {
+		return teamWorkload
+	}},
+}
+
+func teamGet(ctx context.Context, a *app, args []string) error {
+	if err := argN(args, 1, "team get TEAM"); err != nil {
+		return err
+	}
+	c, err := a.api()
+	if err != nil {
+		return err
+	}
+	team, err := c.GetTeam(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return a.print(team, membersTable(team.Members))
+}
+
+// teamCreate создаёт команду из файла в формате тела POST /v2/teams:
+//
+//	team_name: backend
+//	members:
+//	  - {user_id: u1, username: alice, is_active: true}
+func teamCreate(ctx context.Context, a *app, args []string, file string) error {
+	if err := argN(args, 0, "team create -f FILE"); err != nil {
+		return err
+	}
+	var team client.Team
+	if err := readDocument(a, file, &team); err != nil {
+		return err
+	}
+	c, err := a.api()
+	if err != nil {
+		return err
+	}
+	created, err := c.CreateTeam(ctx, team)
+	if err != nil {
+		return err
+	}
+	return a.print(created, membersTable(created.Members))
+}
+
+// workloadRow — нагрузка участника команды.
+type workloadRow struct {
+	UserID          string `json:"user_id"`
+	Username        string `json:"username"`
+	IsActive        bool   `json:"is_active"`
+	OpenReviewCount int    `json:"open_review_count"`
+}
+
+// teamWorkload показывает число открытых ревью у участников команды,
+// от самых загруженных к свободным.
+func teamWorkload(ctx context.Context, a *app, args []string) error {
+	if err := argN(args, 1, "team workload TEAM"); err != nil {
+		return err
+	}
+	c, err := a.api()
+	if err != nil {
+		return err
+	}
+	// GET /v2/users с пустой командой вернул бы всех пользователей: проверяем, что команда есть.
+	if _, err := c.GetTeam(ctx, args[0]); err != nil {
+		return err
+	}
+	users, err := allUsers(ctx, c, client.UserFilter{TeamName: args[0]})
+	if err != nil {
+		return err
+	}
+	rows := make([]workloadRow, 0, len(users))
+	for _, u := range users {
+		rows = append(rows, workloadRow{UserID: u.UserID, Username: u.Username, IsActive: u.IsActive, OpenReviewCount: u.OpenReviewCount})
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].OpenReviewCount > rows[j].OpenReviewCount })
+
+	total := 0
+	t := table{header: []string{"USER_ID", "USERNAME", "ACTIVE", "OPEN_REVIEWS"}}
+	for _, r := range rows {
+		total += r.OpenReviewCount
+		t.add(r.UserID, r.Username, yesNo(r.IsActive), itoa(r.OpenReviewCount))
+	}
+	return a.print(map[string]any{"team_name": args[0], "members": rows, "open_reviews": total}, t)
+}
+
+func membersTable(members []client.TeamMember) table {
+	t := table{header: []string{"USER_ID", "USERNAME", "ACTIVE"}}
+	for _, m := range members {
+		t.add(m.UserID, m.Username, yesNo(m.IsActive))
+	}
+	return t
+}
+
+// allUsers читает все страницы справочника пользователей.
+func allUsers(ctx context.Context, c *client.Client, filter client.UserFilter) ([]client.UserDetails, error) {
+	var (
+		users []client.UserDetails
+		page  client.PageRequest
+	)
+	for {
+		p, err := c.ListUsers(ctx, filter, page)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, p.Users...)
+		if p.NextCursor == "" {
+			return users, nil
+		}
+		page.Cursor = p.NextCursor
+	}
+}
+
+// readDocument читает JSON или YAML из файла или stdin ("-") в out.
+// YAML приводится к JSON, чтобы использовать json-теги типов API.
+func readDocument(a *app, file string, out any) error {
+	var (
+		data []byte
+		err  error
+	)
+	if file == "-" {
+		data, err = io.ReadAll(a.stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return fmt.Errorf("read %s: %w", file, err)
+	}
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parse %s: %w", file, err)
+	}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", file, err)
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("parse %s: %w", file, err)
+	}
+	return nil
+}
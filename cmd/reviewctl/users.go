@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/trainee/review-service/pkg/client"
+)
+
+var userCommands = []command{
+	{name: "get", args: "USER_ID", summary: "show a user and their open review count", setup: func(fs *flag.FlagSet) runFunc {
+		return userGet
+	}},
+	{name: "list", summary: "list users", setup: func(fs *flag.FlagSet) runFunc {
+		var filter client.UserFilter
+		fs.StringVar(&filter.TeamName, "team", "", "team name")
+		fs.StringVar(&filter.UsernamePrefix, "prefix", "", "username prefix")
+		active := fs.String("active", "", "true or false")
+		return func(ctx context.Context, a *app, args []string) error {
+			if *active != "" {
+				v, err := strconv.ParseBool(*active)
+				if err != nil {
+					return fmt.Errorf("-active: %w", err)
+				}
+				filter.IsActive = &v
+			}
+			return userList(ctx, a, args, filter)
+		}
+	}},
+	{name: "reviews", args: "USER_ID", summary: "pull requests the user reviews", setup: func(fs *flag.FlagSet) runFunc {
+		status := fs.String("status", "", "OPEN (default), MERGED or ALL")
+		return func(ctx context.Context, a *app, args []string) error {
+			return userReviews(ctx, a, args, client.PRStatus(strings.ToUpper(*status)))
+		}
+	}},
+	{name: "activate", args: "USER_ID", summary: "mark a user active", setup: func(fs *flag.FlagSet) runFunc {
+		return func(ctx context.Context, a *app, args []string) error {
+			return userSetActive(ctx, a, args, true, false)
+		}
+	}},
+	{name: "deactivate", args: "USER_ID", summary: "mark a user inactive and optionally hand off their reviews", setup: func(fs *flag.FlagSet) runFunc {
+		handoff := fs.Bool("handoff", false, "reassign the user's open reviews to teammates")
+		return func(ctx context.Context, a *app, args []string) error {
+			return userSetActive(ctx, a, args, false, *handoff)
+		}
+	}},
+}
+
+func userGet(ctx context.Context, a *app, args []string) error {
+	if err := argN(args, 1, "user get USER_ID"); err != nil {
+		return err
+	}
+	c, err := a.api()
+	if err != nil {
+		return err
+	}
+	u, err := c.GetUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	return a.print(u, usersTable([]client.UserDetails{*u}))
+}
+
+func userList(ctx context.Context, a *app, args []string, filter client.UserFilter) error {
+	if err := argN(args, 0, "user list [-team TEAM] [-active BOOL] [-prefix PREFIX]"); err != nil {
+		return err
+	}
+	c, err := a.api()
+	if err != nil {
+		return err
+	}
+	users, err := allUsers(ctx, c, filter)
+	if err != nil {
+		return err
+	}
+	if users == nil {
+		users = []client.UserDetails{}
+	}
+	return a.print(map[string]any{"users": users}, usersTable(users))
+}
+
+func userReviews(ctx context.Context, a *app, args []string, status client.PRStatus) error {
+	if err := argN(args, 1, "user reviews USER_ID [-status OPEN|MERGED|ALL]"); err != nil {
+		return err
+	}
+	c, err := a.api()
+	if err != nil {
+		return err
+	}
+	prs, err := allReviews(ctx, c, args[0], status)
+	if err != nil {
+		return err
+	}
+	t := table{header: []string{"PULL_REQUEST_ID", "NAME", "AUTHOR", "STATUS"}}
+	for _, pr := range prs {
+		t.add(pr.ID, pr.Name, pr.AuthorID, string(pr.Status))
+	}
+	return a.print(map[string]any{"user_id": args[0], "pull_requests": prs}, t)
+}
+
+// handoffResult — итог передачи одного ревью.
+type handoffResult struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReplacedBy    string `json:"replaced_by,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// userSetActive меняет активность пользователя. При деактивации с handoff его
+// открытые ревью переназначаются на коллег; PR, для которых замены нет,
+// попадают в отчёт с ошибкой, а команда завершается с ошибкой после вывода отчёта.
+func userSetActive(ctx context.Context, a *app, args []string, active, handoff bool) error {
+	verb := "activate"
+	if !active {
+		verb = "deactivate"
+	}
+	if err := argN(args, 1, "user "+verb+" USER_ID"); err != nil {
+		return err
+	}
+	c, err := a.api()
+	if err != nil {
+		return err
+	}
+	// Ресурс участника адресуется через команду.
+	current, err := c.GetUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	user, err := c.SetUserActive(ctx, current.TeamName, current.UserID, active, client.WithIfMatch(current.Version))
+	if err != nil {
+		return err
+	}
+
+	result := map[string]any{"user": user}
+	t := usersTable([]client.UserDetails{{User: *user, OpenReviewCount: current.OpenReviewCount}})
+	if !handoff {
+		return a.print(result, t)
+	}
+
+	// Неактивный пользователь уже не попадёт в кандидаты, поэтому переназначаем после деактивации.
+	reviews, err := allReviews(ctx, c, user.UserID, client.StatusOpen)
+	if err != nil {
+		return err
+	}
+	handoffs := make([]handoffResult, 0, len(reviews))
+	failed := 0
+	t = table{header: []string{"PULL_REQUEST_ID", "REPLACED_BY", "ERROR"}}
+	for _, pr := range reviews {
+		r := handoffResult{PullRequestID: pr.ID}
+		if _, replacedBy, err := c.ReassignReviewer(ctx, pr.ID, user.UserID); err != nil {
+			r.Error = err.Error()
+			failed++
+		} else {
+			r.ReplacedBy = replacedBy
+		}
+		handoffs = append(handoffs, r)
+		t.add(r.PullRequestID, orDash(r.ReplacedBy), orDash(r.Error))
+	}
+	result["handoffs"] = handoffs
+	if err := a.print(result, t); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d reviews were not handed off", failed, len(reviews))
+	}
+	return nil
+}
+
+func usersTable(users []client.UserDetails) table {
+	t := table{header: []string{"USER_ID", "USERNAME", "TEAM", "ACTIVE", "OPEN_REVIEWS"}}
+	for _, u := range users {
+		t.add(u.UserID, u.Username, u.TeamName, yesNo(u.IsActive), itoa(u.OpenReviewCount))
+	}
+	return t
+}
+
+// allReviews читает все страницы PR, где пользователь ревьюер.
+func allReviews(ctx context.Context, c *client.Client, userID string, status client.PRStatus) ([]client.PullRequestShort, error) {
+	prs := []client.PullRequestShort{}
+	var page client.PageRequest
+	for {
+		p, err := c.GetUserReviews(ctx, userID, status, page)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, p.PullRequests...)
+		if p.NextCursor == "" {
+			return prs, nil
+		}
+		page.Cursor = p.NextCursor
+	}
+}
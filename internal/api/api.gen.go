@@ -0,0 +1,6556 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.1 DO NOT EDIT.
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/oapi-codegen/runtime"
+	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
+)
+
+const (
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for APITokenScopes.
+const (
+	APITokenScopesAdmin      APITokenScopes = "admin"
+	APITokenScopesPrsWrite   APITokenScopes = "prs:write"
+	APITokenScopesRead       APITokenScopes = "read"
+	APITokenScopesTeamsWrite APITokenScopes = "teams:write"
+)
+
+// Defines values for CheckResultStatus.
+const (
+	CheckResultStatusFailing CheckResultStatus = "failing"
+	CheckResultStatusOk      CheckResultStatus = "ok"
+)
+
+// Defines values for ErrorResponseErrorCode.
+const (
+	BADREQUEST            ErrorResponseErrorCode = "BAD_REQUEST"
+	FORBIDDEN             ErrorResponseErrorCode = "FORBIDDEN"
+	IDEMPOTENCYINPROGRESS ErrorResponseErrorCode = "IDEMPOTENCY_IN_PROGRESS"
+	IDEMPOTENCYKEYREUSED  ErrorResponseErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	INSUFFICIENTSCOPE     ErrorResponseErrorCode = "INSUFFICIENT_SCOPE"
+	INTERNALERROR         ErrorResponseErrorCode = "INTERNAL_ERROR"
+	NOCANDIDATE           ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTASSIGNED           ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTEMPTY              ErrorResponseErrorCode = "NOT_EMPTY"
+	NOTFOUND              ErrorResponseErrorCode = "NOT_FOUND"
+	PRECONDITIONFAILED    ErrorResponseErrorCode = "PRECONDITION_FAILED"
+	PREXISTS              ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED              ErrorResponseErrorCode = "PR_MERGED"
+	TEAMEXISTS            ErrorResponseErrorCode = "TEAM_EXISTS"
+	UNAUTHORIZED          ErrorResponseErrorCode = "UNAUTHORIZED"
+	USERINUSE             ErrorResponseErrorCode = "USER_IN_USE"
+)
+
+// Defines values for HealthReportStatus.
+const (
+	NotReady HealthReportStatus = "not_ready"
+	Ready    HealthReportStatus = "ready"
+)
+
+// Defines values for HealthStatusStatus.
+const (
+	HealthStatusStatusOk HealthStatusStatus = "ok"
+)
+
+// Defines values for PRStatus.
+const (
+	PRStatusMERGED PRStatus = "MERGED"
+	PRStatusOPEN   PRStatus = "OPEN"
+)
+
+// Defines values for ReviewStatus.
+const (
+	ReviewStatusALL    ReviewStatus = "ALL"
+	ReviewStatusMERGED ReviewStatus = "MERGED"
+	ReviewStatusOPEN   ReviewStatus = "OPEN"
+)
+
+// Defines values for RoleAssignmentRole.
+const (
+	OrgAdmin RoleAssignmentRole = "org_admin"
+	TeamLead RoleAssignmentRole = "team_lead"
+)
+
+// Defines values for CreateTokenJSONBodyScopes.
+const (
+	CreateTokenJSONBodyScopesAdmin      CreateTokenJSONBodyScopes = "admin"
+	CreateTokenJSONBodyScopesPrsWrite   CreateTokenJSONBodyScopes = "prs:write"
+	CreateTokenJSONBodyScopesRead       CreateTokenJSONBodyScopes = "read"
+	CreateTokenJSONBodyScopesTeamsWrite CreateTokenJSONBodyScopes = "teams:write"
+)
+
+// Defines values for UpdatePullRequestV2JSONBodyStatus.
+const (
+	MERGED UpdatePullRequestV2JSONBodyStatus = "MERGED"
+)
+
+// APIToken defines model for APIToken.
+type APIToken struct {
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Name      string     `json:"name"`
+
+	// OrgId Организация, в которой выпущен токен
+	OrgId     *string          `json:"org_id,omitempty"`
+	RevokedAt *time.Time       `json:"revoked_at,omitempty"`
+	Scopes    []APITokenScopes `json:"scopes"`
+	TokenId   string           `json:"token_id"`
+}
+
+// APITokenScopes defines model for APIToken.Scopes.
+type APITokenScopes string
+
+// CheckResult defines model for CheckResult.
+type CheckResult struct {
+	Details    *map[string]interface{} `json:"details,omitempty"`
+	DurationMs float64                 `json:"duration_ms"`
+	Error      *string                 `json:"error,omitempty"`
+	Status     CheckResultStatus       `json:"status"`
+}
+
+// CheckResultStatus defines model for CheckResult.Status.
+type CheckResultStatus string
+
+// DisplayName Отображаемое имя; не пустое и не из одних пробелов
+type DisplayName = string
+
+// EntityID Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+// символов и без пробелов по краям
+type EntityID = string
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse struct {
+	Error struct {
+		Code ErrorResponseErrorCode `json:"code"`
+
+		// Details Нарушения по полям; только для BAD_REQUEST
+		Details *[]FieldError `json:"details,omitempty"`
+		Message string        `json:"message"`
+	} `json:"error"`
+}
+
+// ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
+type ErrorResponseErrorCode string
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Field Поле тела через точку (`members.0.user_id`), имя параметра или заголовка;
+	// `body` — тело целиком
+	Field   string `json:"field"`
+	Message string `json:"message"`
+
+	// Rule Ключевое слово схемы (`required`, `type`, `enum`, `pattern`, `maxLength`,
+	// `minimum`, `additionalProperties`...) или проверка вне схемы: `unique`,
+	// `format`, `syntax`, `trailingData`, `maxBodySize`
+	Rule string `json:"rule"`
+}
+
+// HealthReport defines model for HealthReport.
+type HealthReport struct {
+	Checks map[string]CheckResult `json:"checks"`
+	Status HealthReportStatus     `json:"status"`
+}
+
+// HealthReportStatus defines model for HealthReport.Status.
+type HealthReportStatus string
+
+// HealthStatus defines model for HealthStatus.
+type HealthStatus struct {
+	Status HealthStatusStatus `json:"status"`
+}
+
+// HealthStatusStatus defines model for HealthStatus.Status.
+type HealthStatusStatus string
+
+// IssuedToken defines model for IssuedToken.
+type IssuedToken struct {
+	ApiToken APIToken `json:"api_token"`
+
+	// Token Значение токена; больше нигде не возвращается
+	Token string `json:"token"`
+}
+
+// NewTeam Команда в запросе создания; без members (или с null) создаётся пустая команда
+type NewTeam struct {
+	Members *[]TeamMember `json:"members"`
+
+	// TeamName Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+	// символов и без пробелов по краям
+	TeamName EntityID `json:"team_name"`
+}
+
+// PRStatus defines model for PRStatus.
+type PRStatus string
+
+// PullRequest defines model for PullRequest.
+type PullRequest struct {
+	// AssignedReviewers user_id назначенных ревьюверов (0..2)
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	AuthorId          string     `json:"author_id"`
+	CreatedAt         *time.Time `json:"createdAt,omitempty"`
+
+	// MergedAt Время merge; отсутствует у открытых PR
+	MergedAt        *time.Time `json:"mergedAt,omitempty"`
+	PullRequestId   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	Status          PRStatus   `json:"status"`
+}
+
+// PullRequestPage defines model for PullRequestPage.
+type PullRequestPage struct {
+	NextCursor   *string       `json:"next_cursor,omitempty"`
+	PullRequests []PullRequest `json:"pull_requests"`
+}
+
+// PullRequestResponse defines model for PullRequestResponse.
+type PullRequestResponse struct {
+	Pr PullRequest `json:"pr"`
+}
+
+// PullRequestShort defines model for PullRequestShort.
+type PullRequestShort struct {
+	AuthorId        string   `json:"author_id"`
+	PullRequestId   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	Status          PRStatus `json:"status"`
+}
+
+// ReassignResult defines model for ReassignResult.
+type ReassignResult struct {
+	Pr PullRequest `json:"pr"`
+
+	// ReplacedBy user_id нового ревьювера
+	ReplacedBy string `json:"replaced_by"`
+}
+
+// ReviewPage defines model for ReviewPage.
+type ReviewPage struct {
+	// NextCursor Курсор следующей страницы (отсутствует на последней)
+	NextCursor   *string            `json:"next_cursor,omitempty"`
+	PullRequests []PullRequestShort `json:"pull_requests"`
+	UserId       string             `json:"user_id"`
+}
+
+// ReviewStatus defines model for ReviewStatus.
+type ReviewStatus string
+
+// ReviewerList defines model for ReviewerList.
+type ReviewerList struct {
+	PullRequestId string   `json:"pull_request_id"`
+	Reviewers     []string `json:"reviewers"`
+}
+
+// ReviewerReplacement defines model for ReviewerReplacement.
+type ReviewerReplacement struct {
+	PullRequest PullRequest `json:"pull_request"`
+
+	// ReplacedBy user_id нового ревьювера
+	ReplacedBy string `json:"replaced_by"`
+}
+
+// RoleAssignment team_name обязательна для team_lead и запрещена для org_admin
+type RoleAssignment struct {
+	Role RoleAssignmentRole `json:"role"`
+
+	// TeamName Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+	// символов и без пробелов по краям
+	TeamName *EntityID `json:"team_name,omitempty"`
+
+	// UserId Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+	// символов и без пробелов по краям
+	UserId EntityID `json:"user_id"`
+}
+
+// RoleAssignmentRole defines model for RoleAssignment.Role.
+type RoleAssignmentRole string
+
+// RoleList defines model for RoleList.
+type RoleList struct {
+	Roles []RoleAssignment `json:"roles"`
+}
+
+// RoleResponse defines model for RoleResponse.
+type RoleResponse struct {
+	// Role team_name обязательна для team_lead и запрещена для org_admin
+	Role RoleAssignment `json:"role"`
+}
+
+// RoleRevocation defines model for RoleRevocation.
+type RoleRevocation struct {
+	Revoked bool `json:"revoked"`
+
+	// Role team_name обязательна для team_lead и запрещена для org_admin
+	Role RoleAssignment `json:"role"`
+}
+
+// Team defines model for Team.
+type Team struct {
+	Members  []TeamMember `json:"members"`
+	TeamName string       `json:"team_name"`
+}
+
+// TeamMember defines model for TeamMember.
+type TeamMember struct {
+	IsActive bool `json:"is_active"`
+
+	// UserId Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+	// символов и без пробелов по краям
+	UserId EntityID `json:"user_id"`
+
+	// Username Отображаемое имя; не пустое и не из одних пробелов
+	Username DisplayName `json:"username"`
+}
+
+// TeamMemberUpdate defines model for TeamMemberUpdate.
+type TeamMemberUpdate struct {
+	IsActive bool `json:"is_active"`
+
+	// Username Отображаемое имя; не пустое и не из одних пробелов
+	Username DisplayName `json:"username"`
+}
+
+// TeamResponse defines model for TeamResponse.
+type TeamResponse struct {
+	Team Team `json:"team"`
+}
+
+// TokenList defines model for TokenList.
+type TokenList struct {
+	Tokens []APIToken `json:"tokens"`
+}
+
+// TokenRevocation defines model for TokenRevocation.
+type TokenRevocation struct {
+	Revoked bool   `json:"revoked"`
+	TokenId string `json:"token_id"`
+}
+
+// User defines model for User.
+type User struct {
+	IsActive bool   `json:"is_active"`
+	TeamName string `json:"team_name"`
+	UserId   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// UserDetails defines model for UserDetails.
+type UserDetails struct {
+	IsActive bool `json:"is_active"`
+
+	// OpenReviewCount Количество открытых PR, где пользователь назначен ревьювером
+	OpenReviewCount int    `json:"open_review_count"`
+	TeamName        string `json:"team_name"`
+	UserId          string `json:"user_id"`
+	Username        string `json:"username"`
+}
+
+// UserDetailsResponse defines model for UserDetailsResponse.
+type UserDetailsResponse struct {
+	User UserDetails `json:"user"`
+}
+
+// UserPage defines model for UserPage.
+type UserPage struct {
+	// NextCursor Курсор следующей страницы (отсутствует на последней)
+	NextCursor *string       `json:"next_cursor,omitempty"`
+	Users      []UserDetails `json:"users"`
+}
+
+// UserResponse defines model for UserResponse.
+type UserResponse struct {
+	User User `json:"user"`
+}
+
+// CreatedFromFilter defines model for CreatedFromFilter.
+type CreatedFromFilter = time.Time
+
+// CreatedToFilter defines model for CreatedToFilter.
+type CreatedToFilter = time.Time
+
+// CursorQuery defines model for CursorQuery.
+type CursorQuery = string
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// IfNoneMatch defines model for IfNoneMatch.
+type IfNoneMatch = string
+
+// LimitQuery defines model for LimitQuery.
+type LimitQuery = int
+
+// MergedFromFilter defines model for MergedFromFilter.
+type MergedFromFilter = time.Time
+
+// MergedToFilter defines model for MergedToFilter.
+type MergedToFilter = time.Time
+
+// PRAuthorFilter defines model for PRAuthorFilter.
+type PRAuthorFilter = string
+
+// PRNameFilter defines model for PRNameFilter.
+type PRNameFilter = string
+
+// PRReviewerFilter defines model for PRReviewerFilter.
+type PRReviewerFilter = string
+
+// PRStatusFilter defines model for PRStatusFilter.
+type PRStatusFilter = PRStatus
+
+// PRTeamFilter defines model for PRTeamFilter.
+type PRTeamFilter = string
+
+// PullRequestIdPath Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+// символов и без пробелов по краям
+type PullRequestIdPath = EntityID
+
+// ReviewStatusFilter defines model for ReviewStatusFilter.
+type ReviewStatusFilter = ReviewStatus
+
+// TeamNamePath Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+// символов и без пробелов по краям
+type TeamNamePath = EntityID
+
+// TeamNameQuery Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+// символов и без пробелов по краям
+type TeamNameQuery = EntityID
+
+// UserActiveFilter defines model for UserActiveFilter.
+type UserActiveFilter = bool
+
+// UserIdPath Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+// символов и без пробелов по краям
+type UserIdPath = EntityID
+
+// UserIdQuery Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+// символов и без пробелов по краям
+type UserIdQuery = EntityID
+
+// UserTeamFilter defines model for UserTeamFilter.
+type UserTeamFilter = string
+
+// UsernamePrefixFilter defines model for UsernamePrefixFilter.
+type UsernamePrefixFilter = string
+
+// BadRequest defines model for BadRequest.
+type BadRequest = ErrorResponse
+
+// Conflict defines model for Conflict.
+type Conflict = ErrorResponse
+
+// Forbidden defines model for Forbidden.
+type Forbidden = ErrorResponse
+
+// IdempotencyKeyReused defines model for IdempotencyKeyReused.
+type IdempotencyKeyReused = ErrorResponse
+
+// InsufficientScope defines model for InsufficientScope.
+type InsufficientScope = ErrorResponse
+
+// NotFound defines model for NotFound.
+type NotFound = ErrorResponse
+
+// PreconditionFailed defines model for PreconditionFailed.
+type PreconditionFailed = ErrorResponse
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = ErrorResponse
+
+// CreateTokenJSONBody defines parameters for CreateToken.
+type CreateTokenJSONBody struct {
+	// ExpiresIn Время жизни в формате Go duration (например, 720h); без поля — бессрочно
+	ExpiresIn *string `json:"expires_in,omitempty"`
+
+	// Name Отображаемое имя; не пустое и не из одних пробелов
+	Name   DisplayName                 `json:"name"`
+	Scopes []CreateTokenJSONBodyScopes `json:"scopes"`
+}
+
+// CreateTokenJSONBodyScopes defines parameters for CreateToken.
+type CreateTokenJSONBodyScopes string
+
+// RevokeTokenJSONBody defines parameters for RevokeToken.
+type RevokeTokenJSONBody struct {
+	TokenId string `json:"token_id"`
+}
+
+// CreatePullRequestJSONBody defines parameters for CreatePullRequest.
+type CreatePullRequestJSONBody struct {
+	// AuthorId Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+	// символов и без пробелов по краям
+	AuthorId EntityID `json:"author_id"`
+
+	// PullRequestId Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+	// символов и без пробелов по краям
+	PullRequestId EntityID `json:"pull_request_id"`
+
+	// PullRequestName Отображаемое имя; не пустое и не из одних пробелов
+	PullRequestName DisplayName `json:"pull_request_name"`
+}
+
+// CreatePullRequestParams defines parameters for CreatePullRequest.
+type CreatePullRequestParams struct {
+	// IdempotencyKey Ключ повтора. Повтор с тем же ключом и телом возвращает сохранённый ответ
+	// (заголовок `Idempotent-Replayed: true`) без повторного выполнения; с другим
+	// телом — 422 IDEMPOTENCY_KEY_REUSED, пока исходный запрос выполняется — 409
+	// IDEMPOTENCY_IN_PROGRESS. Ключ действует в пределах организации и вызывающего
+	// в течение IDEMPOTENCY_TTL (по умолчанию 24h); ответы 5xx не сохраняются.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetPullRequestParams defines parameters for GetPullRequest.
+type GetPullRequestParams struct {
+	PullRequestId EntityID `form:"pull_request_id" json:"pull_request_id"`
+
+	// IfNoneMatch ETag из предыдущего ответа; при совпадении возвращается 304
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// ListPullRequestsParams defines parameters for ListPullRequests.
+type ListPullRequestsParams struct {
+	Status     *PRStatusFilter   `form:"status,omitempty" json:"status,omitempty"`
+	AuthorId   *PRAuthorFilter   `form:"author_id,omitempty" json:"author_id,omitempty"`
+	ReviewerId *PRReviewerFilter `form:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+
+	// TeamName Команда автора PR
+	TeamName *PRTeamFilter `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// Name Подстрока названия PR (без учёта регистра)
+	Name *PRNameFilter `form:"name,omitempty" json:"name,omitempty"`
+
+	// CreatedFrom Нижняя граница createdAt (включительно)
+	CreatedFrom *CreatedFromFilter `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo Верхняя граница createdAt (не включительно)
+	CreatedTo  *CreatedToFilter  `form:"created_to,omitempty" json:"created_to,omitempty"`
+	MergedFrom *MergedFromFilter `form:"merged_from,omitempty" json:"merged_from,omitempty"`
+	MergedTo   *MergedToFilter   `form:"merged_to,omitempty" json:"merged_to,omitempty"`
+
+	// Limit Размер страницы (значения больше 200 ограничиваются)
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Непрозрачный курсор из поля next_cursor предыдущей страницы
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// MergePullRequestJSONBody defines parameters for MergePullRequest.
+type MergePullRequestJSONBody struct {
+	// PullRequestId Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+	// символов и без пробелов по краям
+	PullRequestId EntityID `json:"pull_request_id"`
+}
+
+// MergePullRequestParams defines parameters for MergePullRequest.
+type MergePullRequestParams struct {
+	// IdempotencyKey Ключ повтора. Повтор с тем же ключом и телом возвращает сохранённый ответ
+	// (заголовок `Idempotent-Replayed: true`) без повторного выполнения; с другим
+	// телом — 422 IDEMPOTENCY_KEY_REUSED, пока исходный запрос выполняется — 409
+	// IDEMPOTENCY_IN_PROGRESS. Ключ действует в пределах организации и вызывающего
+	// в течение IDEMPOTENCY_TTL (по умолчанию 24h); ответы 5xx не сохраняются.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+
+	// IfMatch ETag из предыдущего ответа. Изменение выполняется, только если версия
+	// ресурса не изменилась, иначе — 412 PRECONDITION_FAILED. `*` или отсутствие
+	// заголовка — без проверки.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// ReassignReviewerJSONBody defines parameters for ReassignReviewer.
+type ReassignReviewerJSONBody struct {
+	// OldReviewerId Синоним old_user_id; используется, если old_user_id не передан
+	// Deprecated: this property has been marked as deprecated upstream, but no `x-deprecated-reason` was set
+	OldReviewerId *string `json:"old_reviewer_id,omitempty"`
+
+	// OldUserId Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+	// символов и без пробелов по краям
+	OldUserId *EntityID `json:"old_user_id,omitempty"`
+
+	// PullRequestId Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+	// символов и без пробелов по краям
+	PullRequestId EntityID `json:"pull_request_id"`
+}
+
+// ReassignReviewerParams defines parameters for ReassignReviewer.
+type ReassignReviewerParams struct {
+	// IdempotencyKey Ключ повтора. Повтор с тем же ключом и телом возвращает сохранённый ответ
+	// (заголовок `Idempotent-Replayed: true`) без повторного выполнения; с другим
+	// телом — 422 IDEMPOTENCY_KEY_REUSED, пока исходный запрос выполняется — 409
+	// IDEMPOTENCY_IN_PROGRESS. Ключ действует в пределах организации и вызывающего
+	// в течение IDEMPOTENCY_TTL (по умолчанию 24h); ответы 5xx не сохраняются.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+
+	// IfMatch ETag из предыдущего ответа. Изменение выполняется, только если версия
+	// ресурса не изменилась, иначе — 412 PRECONDITION_FAILED. `*` или отсутствие
+	// заголовка — без проверки.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// AssignRoleParams defines parameters for AssignRole.
+type AssignRoleParams struct {
+	// IdempotencyKey Ключ повтора. Повтор с тем же ключом и телом возвращает сохранённый ответ
+	// (заголовок `Idempotent-Replayed: true`) без повторного выполнения; с другим
+	// телом — 422 IDEMPOTENCY_KEY_REUSED, пока исходный запрос выполняется — 409
+	// IDEMPOTENCY_IN_PROGRESS. Ключ действует в пределах организации и вызывающего
+	// в течение IDEMPOTENCY_TTL (по умолчанию 24h); ответы 5xx не сохраняются.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// ListRolesParams defines parameters for ListRoles.
+type ListRolesParams struct {
+	// UserId Только роли указанного пользователя
+	UserId *string `form:"user_id,omitempty" json:"user_id,omitempty"`
+}
+
+// RevokeRoleParams defines parameters for RevokeRole.
+type RevokeRoleParams struct {
+	// IdempotencyKey Ключ повтора. Повтор с тем же ключом и телом возвращает сохранённый ответ
+	// (заголовок `Idempotent-Replayed: true`) без повторного выполнения; с другим
+	// телом — 422 IDEMPOTENCY_KEY_REUSED, пока исходный запрос выполняется — 409
+	// IDEMPOTENCY_IN_PROGRESS. Ключ действует в пределах организации и вызывающего
+	// в течение IDEMPOTENCY_TTL (по умолчанию 24h); ответы 5xx не сохраняются.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// CreateTeamParams defines parameters for CreateTeam.
+type CreateTeamParams struct {
+	// IdempotencyKey Ключ повтора. Повтор с тем же ключом и телом возвращает сохранённый ответ
+	// (заголовок `Idempotent-Replayed: true`) без повторного выполнения; с другим
+	// телом — 422 IDEMPOTENCY_KEY_REUSED, пока исходный запрос выполняется — 409
+	// IDEMPOTENCY_IN_PROGRESS. Ключ действует в пределах организации и вызывающего
+	// в течение IDEMPOTENCY_TTL (по умолчанию 24h); ответы 5xx не сохраняются.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetTeamParams defines parameters for GetTeam.
+type GetTeamParams struct {
+	// TeamName Уникальное имя команды
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
+
+	// IfNoneMatch ETag из предыдущего ответа; при совпадении возвращается 304
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// GetUserParams defines parameters for GetUser.
+type GetUserParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+
+	// IfNoneMatch ETag из предыдущего ответа; при совпадении возвращается 304
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// GetUserReviewsParams defines parameters for GetUserReviews.
+type GetUserReviewsParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+
+	// Status Фильтр по статусу PR; ALL возвращает PR в любом статусе
+	Status *ReviewStatusFilter `form:"status,omitempty" json:"status,omitempty"`
+
+	// Limit Размер страницы (значения больше 200 ограничиваются)
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Непрозрачный курсор из поля next_cursor предыдущей страницы
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListUsersParams defines parameters for ListUsers.
+type ListUsersParams struct {
+	TeamName *UserTeamFilter   `form:"team_name,omitempty" json:"team_name,omitempty"`
+	IsActive *UserActiveFilter `form:"is_active,omitempty" json:"is_active,omitempty"`
+
+	// UsernamePrefix Префикс username (без учёта регистра)
+	UsernamePrefix *UsernamePrefixFilter `form:"username_prefix,omitempty" json:"username_prefix,omitempty"`
+
+	// Limit Размер страницы (значения больше 200 ограничиваются)
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Непрозрачный курсор из поля next_cursor предыдущей страницы
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// SetUserIsActiveJSONBody defines parameters for SetUserIsActive.
+type SetUserIsActiveJSONBody struct {
+	IsActive bool `json:"is_active"`
+
+	// UserId Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+	// символов и без пробелов по краям
+	UserId EntityID `json:"user_id"`
+}
+
+// SetUserIsActiveParams defines parameters for SetUserIsActive.
+type SetUserIsActiveParams struct {
+	// IdempotencyKey Ключ повтора. Повтор с тем же ключом и телом возвращает сохранённый ответ
+	// (заголовок `Idempotent-Replayed: true`) без повторного выполнения; с другим
+	// телом — 422 IDEMPOTENCY_KEY_REUSED, пока исходный запрос выполняется — 409
+	// IDEMPOTENCY_IN_PROGRESS. Ключ действует в пределах организации и вызывающего
+	// в течение IDEMPOTENCY_TTL (по умолчанию 24h); ответы 5xx не сохраняются.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+
+	// IfMatch ETag из предыдущего ответа. Изменение выполняется, только если версия
+	// ресурса не изменилась, иначе — 412 PRECONDITION_FAILED. `*` или отсутствие
+	// заголовка — без проверки.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// ListPullRequestsV2Params defines parameters for ListPullRequestsV2.
+type ListPullRequestsV2Params struct {
+	Status     *PRStatusFilter   `form:"status,omitempty" json:"status,omitempty"`
+	AuthorId   *PRAuthorFilter   `form:"author_id,omitempty" json:"author_id,omitempty"`
+	ReviewerId *PRReviewerFilter `form:"reviewer_id,omitempty" json:"reviewer_id,omitempty"`
+
+	// TeamName Команда автора PR
+	TeamName *PRTeamFilter `form:"team_name,omitempty" json:"team_name,omitempty"`
+
+	// Name Подстрока названия PR (без учёта регистра)
+	Name *PRNameFilter `form:"name,omitempty" json:"name,omitempty"`
+
+	// CreatedFrom Нижняя граница createdAt (включительно)
+	CreatedFrom *CreatedFromFilter `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo Верхняя граница createdAt (не включительно)
+	CreatedTo  *CreatedToFilter  `form:"created_to,omitempty" json:"created_to,omitempty"`
+	MergedFrom *MergedFromFilter `form:"merged_from,omitempty" json:"merged_from,omitempty"`
+	MergedTo   *MergedToFilter   `form:"merged_to,omitempty" json:"merged_to,omitempty"`
+
+	// Limit Размер страницы (значения больше 200 ограничиваются)
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Непрозрачный курсор из поля next_cursor предыдущей страницы
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// CreatePullRequestV2JSONBody defines parameters for CreatePullRequestV2.
+type CreatePullRequestV2JSONBody struct {
+	// AuthorId Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+	// символов и без пробелов по краям
+	AuthorId EntityID `json:"author_id"`
+
+	// PullRequestId Идентификатор пользователя, команды или PR: до 128 символов, без управляющих
+	// символов и без пробелов по краям
+	PullRequestId EntityID `json:"pull_request_id"`
+
+	// PullRequestName Отображаемое имя; не пустое и не из одних пробелов
+	PullRequestName DisplayName `json:"pull_request_name"`
+}
+
+// CreatePullRequestV2Params defines parameters for CreatePullRequestV2.
+type CreatePullRequestV2Params struct {
+	// IdempotencyKey Ключ повтора. Повтор с тем же ключом и телом возвращает сохранённый ответ
+	// (заголовок `Idempotent-Replayed: true`) без повторного выполнения; с другим
+	// телом — 422 IDEMPOTENCY_KEY_REUSED, пока исходный запрос выполняется — 409
+	// IDEMPOTENCY_IN_PROGRESS. Ключ действует в пределах организации и вызывающего
+	// в течение IDEMPOTENCY_TTL (по умолчанию 24h); ответы 5xx не сохраняются.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetPullRequestV2Params defines parameters for GetPullRequestV2.
+type GetPullRequestV2Params struct {
+	// IfNoneMatch ETag из предыдущего ответа; при совпадении возвращается 304
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// UpdatePullRequestV2JSONBody defines parameters for UpdatePullRequestV2.
+type UpdatePullRequestV2JSONBody struct {
+	Status UpdatePullRequestV2JSONBodyStatus `json:"status"`
+}
+
+// UpdatePullRequestV2Params defines parameters for UpdatePullRequestV2.
+type UpdatePullRequestV2Params struct {
+	// IdempotencyKey Ключ повтора. Повтор с тем же ключом и телом возвращает сохранённый ответ
+	// (заголовок `Idempotent-Replayed: true`) без повторного выполнения; с другим
+	// телом — 422 IDEMPOTENCY_KEY_REUSED, пока исходный запрос выполняется — 409
+	// IDEMPOTENCY_IN_PROGRESS. Ключ действует в пределах организации и вызывающего
+	// в течение IDEMPOTENCY_TTL (по умолчанию 24h); ответы 5xx не сохраняются.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+
+	// IfMatch ETag из предыдущего ответа. Изменение выполняется, только если версия
+	// ресурса не изменилась, иначе — 412 PRECONDITION_FAILED. `*` или отсутствие
+	// заголовка — без проверки.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// UpdatePullRequestV2JSONBodyStatus defines parameters for UpdatePullRequestV2.
+type UpdatePullRequestV2JSONBodyStatus string
+
+// ListReviewersV2Params defines parameters for ListReviewersV2.
+type ListReviewersV2Params struct {
+	// IfNoneMatch ETag из предыдущего ответа; при совпадении возвращается 304
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// RemoveReviewerV2Params defines parameters for RemoveReviewerV2.
+type RemoveReviewerV2Params struct {
+	// IdempotencyKey Ключ повтора. Повтор с тем же ключом и телом возвращает сохранённый ответ
+	// (заголовок `Idempotent-Replayed: true`) без повторного выполнения; с другим
+	// телом — 422 IDEMPOTENCY_KEY_REUSED, пока исходный запрос выполняется — 409
+	// IDEMPOTENCY_IN_PROGRESS. Ключ действует в пределах организации и вызывающего
+	// в течение IDEMPOTENCY_TTL (по умолчанию 24h); ответы 5xx не сохраняются.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+
+	// IfMatch ETag из предыдущего ответа. Изменение выполняется, только если версия
+	// ресурса не изменилась, иначе — 412 PRECONDITION_FAILED. `*` или отсутствие
+	// заголовка — без проверки.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// CreateTeamV2Params defines parameters for CreateTeamV2.
+type CreateTeamV2Params struct {
+	// IdempotencyKey Ключ повтора. Повтор с тем же ключом и телом возвращает сохранённый ответ
+	// (заголовок `Idempotent-Replayed: true`) без повторного выполнения; с другим
+	// телом — 422 IDEMPOTENCY_KEY_REUSED, пока исходный запрос выполняется — 409
+	// IDEMPOTENCY_IN_PROGRESS. Ключ действует в пределах организации и вызывающего
+	// в течение IDEMPOTENCY_TTL (по умолчанию 24h); ответы 5xx не сохраняются.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// GetTeamV2Params defines parameters for GetTeamV2.
+type GetTeamV2Params struct {
+	// IfNoneMatch ETag из предыдущего ответа; при совпадении возвращается 304
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// RemoveTeamMemberV2Params defines parameters for RemoveTeamMemberV2.
+type RemoveTeamMemberV2Params struct {
+	// IdempotencyKey Ключ повтора. Повтор с тем же ключом и телом возвращает сохранённый ответ
+	// (заголовок `Idempotent-Replayed: true`) без повторного выполнения; с другим
+	// телом — 422 IDEMPOTENCY_KEY_REUSED, пока исходный запрос выполняется — 409
+	// IDEMPOTENCY_IN_PROGRESS. Ключ действует в пределах организации и вызывающего
+	// в течение IDEMPOTENCY_TTL (по умолчанию 24h); ответы 5xx не сохраняются.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+
+	// IfMatch ETag из предыдущего ответа. Изменение выполняется, только если версия
+	// ресурса не изменилась, иначе — 412 PRECONDITION_FAILED. `*` или отсутствие
+	// заголовка — без проверки.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// GetTeamMemberV2Params defines parameters for GetTeamMemberV2.
+type GetTeamMemberV2Params struct {
+	// IfNoneMatch ETag из предыдущего ответа; при совпадении возвращается 304
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// UpdateTeamMemberV2JSONBody defines parameters for UpdateTeamMemberV2.
+type UpdateTeamMemberV2JSONBody struct {
+	IsActive bool `json:"is_active"`
+}
+
+// UpdateTeamMemberV2Params defines parameters for UpdateTeamMemberV2.
+type UpdateTeamMemberV2Params struct {
+	// IdempotencyKey Ключ повтора. Повтор с тем же ключом и телом возвращает сохранённый ответ
+	// (заголовок `Idempotent-Replayed: true`) без повторного выполнения; с другим
+	// телом — 422 IDEMPOTENCY_KEY_REUSED, пока исходный запрос выполняется — 409
+	// IDEMPOTENCY_IN_PROGRESS. Ключ действует в пределах организации и вызывающего
+	// в течение IDEMPOTENCY_TTL (по умолчанию 24h); ответы 5xx не сохраняются.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+
+	// IfMatch ETag из предыдущего ответа. Изменение выполняется, только если версия
+	// ресурса не изменилась, иначе — 412 PRECONDITION_FAILED. `*` или отсутствие
+	// заголовка — без проверки.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PutTeamMemberV2Params defines parameters for PutTeamMemberV2.
+type PutTeamMemberV2Params struct {
+	// IdempotencyKey Ключ повтора. Повтор с тем же ключом и телом возвращает сохранённый ответ
+	// (заголовок `Idempotent-Replayed: true`) без повторного выполнения; с другим
+	// телом — 422 IDEMPOTENCY_KEY_REUSED, пока исходный запрос выполняется — 409
+	// IDEMPOTENCY_IN_PROGRESS. Ключ действует в пределах организации и вызывающего
+	// в течение IDEMPOTENCY_TTL (по умолчанию 24h); ответы 5xx не сохраняются.
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+
+	// IfMatch ETag из предыдущего ответа. Изменение выполняется, только если версия
+	// ресурса не изменилась, иначе — 412 PRECONDITION_FAILED. `*` или отсутствие
+	// заголовка — без проверки.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// ListUsersV2Params defines parameters for ListUsersV2.
+type ListUsersV2Params struct {
+	TeamName *UserTeamFilter   `form:"team_name,omitempty" json:"team_name,omitempty"`
+	IsActive *UserActiveFilter `form:"is_active,omitempty" json:"is_active,omitempty"`
+
+	// UsernamePrefix Префикс username (без учёта регистра)
+	UsernamePrefix *UsernamePrefixFilter `form:"username_prefix,omitempty" json:"username_prefix,omitempty"`
+
+	// Limit Размер страницы (значения больше 200 ограничиваются)
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Непрозрачный курсор из поля next_cursor предыдущей страницы
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// GetUserV2Params defines parameters for GetUserV2.
+type GetUserV2Params struct {
+	// IfNoneMatch ETag из предыдущего ответа; при совпадении возвращается 304
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// GetUserReviewsV2Params defines parameters for GetUserReviewsV2.
+type GetUserReviewsV2Params struct {
+	// Status Фильтр по статусу PR; ALL возвращает PR в любом статусе
+	Status *ReviewStatusFilter `form:"status,omitempty" json:"status,omitempty"`
+
+	// Limit Размер страницы (значения больше 200 ограничиваются)
+	Limit *LimitQuery `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Непрозрачный курсор из поля next_cursor предыдущей страницы
+	Cursor *CursorQuery `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// CreateTokenJSONRequestBody defines body for CreateToken for application/json ContentType.
+type CreateTokenJSONRequestBody CreateTokenJSONBody
+
+// RevokeTokenJSONRequestBody defines body for RevokeToken for application/json ContentType.
+type RevokeTokenJSONRequestBody RevokeTokenJSONBody
+
+// CreatePullRequestJSONRequestBody defines body for CreatePullRequest for application/json ContentType.
+type CreatePullRequestJSONRequestBody CreatePullRequestJSONBody
+
+// MergePullRequestJSONRequestBody defines body for MergePullRequest for application/json ContentType.
+type MergePullRequestJSONRequestBody MergePullRequestJSONBody
+
+// ReassignReviewerJSONRequestBody defines body for ReassignReviewer for application/json ContentType.
+type ReassignReviewerJSONRequestBody ReassignReviewerJSONBody
+
+// AssignRoleJSONRequestBody defines body for AssignRole for application/json ContentType.
+type AssignRoleJSONRequestBody = RoleAssignment
+
+// RevokeRoleJSONRequestBody defines body for RevokeRole for application/json ContentType.
+type RevokeRoleJSONRequestBody = RoleAssignment
+
+// CreateTeamJSONRequestBody defines body for CreateTeam for application/json ContentType.
+type CreateTeamJSONRequestBody = NewTeam
+
+// SetUserIsActiveJSONRequestBody defines body for SetUserIsActive for application/json ContentType.
+type SetUserIsActiveJSONRequestBody SetUserIsActiveJSONBody
+
+// CreatePullRequestV2JSONRequestBody defines body for CreatePullRequestV2 for application/json ContentType.
+type CreatePullRequestV2JSONRequestBody CreatePullRequestV2JSONBody
+
+// UpdatePullRequestV2JSONRequestBody defines body for UpdatePullRequestV2 for application/json ContentType.
+type UpdatePullRequestV2JSONRequestBody UpdatePullRequestV2JSONBody
+
+// CreateTeamV2JSONRequestBody defines body for CreateTeamV2 for application/json ContentType.
+type CreateTeamV2JSONRequestBody = NewTeam
+
+// UpdateTeamMemberV2JSONRequestBody defines body for UpdateTeamMemberV2 for application/json ContentType.
+type UpdateTeamMemberV2JSONRequestBody UpdateTeamMemberV2JSONBody
+
+// PutTeamMemberV2JSONRequestBody defines body for PutTeamMemberV2 for application/json ContentType.
+type PutTeamMemberV2JSONRequestBody = TeamMemberUpdate
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Выпустить API токен (scope admin)
+	// (POST /auth/token/create)
+	CreateToken(w http.ResponseWriter, r *http.Request)
+	// Список API токенов без значений (scope admin)
+	// (GET /auth/token/list)
+	ListTokens(w http.ResponseWriter, r *http.Request)
+	// Отозвать API токен (scope admin)
+	// (POST /auth/token/revoke)
+	RevokeToken(w http.ResponseWriter, r *http.Request)
+	// То же, что /livez (для совместимости)
+	// (GET /health)
+	Health(w http.ResponseWriter, r *http.Request)
+	// Живость процесса; зависимости не проверяются
+	// (GET /livez)
+	Livez(w http.ResponseWriter, r *http.Request)
+	// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+	// (POST /pullRequest/create)
+	CreatePullRequest(w http.ResponseWriter, r *http.Request, params CreatePullRequestParams)
+	// Получить PR по идентификатору
+	// (GET /pullRequest/get)
+	GetPullRequest(w http.ResponseWriter, r *http.Request, params GetPullRequestParams)
+	// Поиск PR с фильтрами и keyset-пагинацией (от новых к старым по createdAt)
+	// (GET /pullRequest/list)
+	ListPullRequests(w http.ResponseWriter, r *http.Request, params ListPullRequestsParams)
+	// Пометить PR как MERGED (идемпотентная операция)
+	// (POST /pullRequest/merge)
+	MergePullRequest(w http.ResponseWriter, r *http.Request, params MergePullRequestParams)
+	// Переназначить конкретного ревьювера на другого из его команды
+	// (POST /pullRequest/reassign)
+	ReassignReviewer(w http.ResponseWriter, r *http.Request, params ReassignReviewerParams)
+	// Готовность принимать запросы (БД, схема, пул, фоновые задачи)
+	// (GET /readyz)
+	Readyz(w http.ResponseWriter, r *http.Request)
+	// Назначить роль (только org_admin)
+	// (POST /roles/assign)
+	AssignRole(w http.ResponseWriter, r *http.Request, params AssignRoleParams)
+	// Список назначенных ролей
+	// (GET /roles/list)
+	ListRoles(w http.ResponseWriter, r *http.Request, params ListRolesParams)
+	// Отозвать роль (только org_admin)
+	// (POST /roles/revoke)
+	RevokeRole(w http.ResponseWriter, r *http.Request, params RevokeRoleParams)
+	// Создать команду с участниками (создаёт/обновляет пользователей)
+	// (POST /team/add)
+	CreateTeam(w http.ResponseWriter, r *http.Request, params CreateTeamParams)
+	// Получить команду с участниками
+	// (GET /team/get)
+	GetTeam(w http.ResponseWriter, r *http.Request, params GetTeamParams)
+	// Получить пользователя с текущей нагрузкой на ревью
+	// (GET /users/get)
+	GetUser(w http.ResponseWriter, r *http.Request, params GetUserParams)
+	// Получить PR'ы, где пользователь назначен ревьювером (от новых к старым)
+	// (GET /users/getReview)
+	GetUserReviews(w http.ResponseWriter, r *http.Request, params GetUserReviewsParams)
+	// Список пользователей с фильтрами и курсорной пагинацией (по user_id)
+	// (GET /users/list)
+	ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams)
+	// Установить флаг активности пользователя
+	// (POST /users/setIsActive)
+	SetUserIsActive(w http.ResponseWriter, r *http.Request, params SetUserIsActiveParams)
+	// Поиск PR с фильтрами и keyset-пагинацией (от новых к старым по createdAt)
+	// (GET /v2/pull-requests)
+	ListPullRequestsV2(w http.ResponseWriter, r *http.Request, params ListPullRequestsV2Params)
+	// Создать PR и назначить до 2 ревьюверов из команды автора
+	// (POST /v2/pull-requests)
+	CreatePullRequestV2(w http.ResponseWriter, r *http.Request, params CreatePullRequestV2Params)
+	// Текущее состояние PR
+	// (GET /v2/pull-requests/{id})
+	GetPullRequestV2(w http.ResponseWriter, r *http.Request, id PullRequestIdPath, params GetPullRequestV2Params)
+	// Перевести PR в MERGED (идемпотентно); другие изменения не поддерживаются
+	// (PATCH /v2/pull-requests/{id})
+	UpdatePullRequestV2(w http.ResponseWriter, r *http.Request, id PullRequestIdPath, params UpdatePullRequestV2Params)
+	// Назначенные ревьюверы PR
+	// (GET /v2/pull-requests/{id}/reviewers)
+	ListReviewersV2(w http.ResponseWriter, r *http.Request, id PullRequestIdPath, params ListReviewersV2Params)
+	// Снять ревьювера; замена назначается из его команды
+	// (DELETE /v2/pull-requests/{id}/reviewers/{user_id})
+	RemoveReviewerV2(w http.ResponseWriter, r *http.Request, id PullRequestIdPath, userId UserIdPath, params RemoveReviewerV2Params)
+	// Создать команду с участниками (создаёт/обновляет пользователей)
+	// (POST /v2/teams)
+	CreateTeamV2(w http.ResponseWriter, r *http.Request, params CreateTeamV2Params)
+	// Команда с участниками
+	// (GET /v2/teams/{name})
+	GetTeamV2(w http.ResponseWriter, r *http.Request, name TeamNamePath, params GetTeamV2Params)
+	// Удалить участника команды
+	// (DELETE /v2/teams/{name}/members/{user_id})
+	RemoveTeamMemberV2(w http.ResponseWriter, r *http.Request, name TeamNamePath, userId EntityID, params RemoveTeamMemberV2Params)
+	// Участник команды; пользователь другой команды — 404
+	// (GET /v2/teams/{name}/members/{user_id})
+	GetTeamMemberV2(w http.ResponseWriter, r *http.Request, name TeamNamePath, userId UserIdPath, params GetTeamMemberV2Params)
+	// Изменить активность участника команды
+	// (PATCH /v2/teams/{name}/members/{user_id})
+	UpdateTeamMemberV2(w http.ResponseWriter, r *http.Request, name TeamNamePath, userId UserIdPath, params UpdateTeamMemberV2Params)
+	// Добавить пользователя в команду или перевести из другой
+	// (PUT /v2/teams/{name}/members/{user_id})
+	PutTeamMemberV2(w http.ResponseWriter, r *http.Request, name TeamNamePath, userId UserIdPath, params PutTeamMemberV2Params)
+	// Список пользователей с фильтрами и курсорной пагинацией (по user_id)
+	// (GET /v2/users)
+	ListUsersV2(w http.ResponseWriter, r *http.Request, params ListUsersV2Params)
+	// Пользователь с текущей нагрузкой на ревью
+	// (GET /v2/users/{user_id})
+	GetUserV2(w http.ResponseWriter, r *http.Request, userId UserIdPath, params GetUserV2Params)
+	// PR, где пользователь назначен ревьювером (от новых к старым)
+	// (GET /v2/users/{user_id}/reviews)
+	GetUserReviewsV2(w http.ResponseWriter, r *http.Request, userId UserIdPath, params GetUserReviewsV2Params)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// Выпустить API токен (scope admin)
+// (POST /auth/token/create)
+func (_ Unimplemented) CreateToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Список API токенов без значений (scope admin)
+// (GET /auth/token/list)
+func (_ Unimplemented) ListTokens(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отозвать API токен (scope admin)
+// (POST /auth/token/revoke)
+func (_ Unimplemented) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// То же, что /livez (для совместимости)
+// (GET /health)
+func (_ Unimplemented) Health(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Живость процесса; зависимости не проверяются
+// (GET /livez)
+func (_ Unimplemented) Livez(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+// (POST /pullRequest/create)
+func (_ Unimplemented) CreatePullRequest(w http.ResponseWriter, r *http.Request, params CreatePullRequestParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить PR по идентификатору
+// (GET /pullRequest/get)
+func (_ Unimplemented) GetPullRequest(w http.ResponseWriter, r *http.Request, params GetPullRequestParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Поиск PR с фильтрами и keyset-пагинацией (от новых к старым по createdAt)
+// (GET /pullRequest/list)
+func (_ Unimplemented) ListPullRequests(w http.ResponseWriter, r *http.Request, params ListPullRequestsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Пометить PR как MERGED (идемпотентная операция)
+// (POST /pullRequest/merge)
+func (_ Unimplemented) MergePullRequest(w http.ResponseWriter, r *http.Request, params MergePullRequestParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Переназначить конкретного ревьювера на другого из его команды
+// (POST /pullRequest/reassign)
+func (_ Unimplemented) ReassignReviewer(w http.ResponseWriter, r *http.Request, params ReassignReviewerParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Готовность принимать запросы (БД, схема, пул, фоновые задачи)
+// (GET /readyz)
+func (_ Unimplemented) Readyz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Назначить роль (только org_admin)
+// (POST /roles/assign)
+func (_ Unimplemented) AssignRole(w http.ResponseWriter, r *http.Request, params AssignRoleParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Список назначенных ролей
+// (GET /roles/list)
+func (_ Unimplemented) ListRoles(w http.ResponseWriter, r *http.Request, params ListRolesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Отозвать роль (только org_admin)
+// (POST /roles/revoke)
+func (_ Unimplemented) RevokeRole(w http.ResponseWriter, r *http.Request, params RevokeRoleParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать команду с участниками (создаёт/обновляет пользователей)
+// (POST /team/add)
+func (_ Unimplemented) CreateTeam(w http.ResponseWriter, r *http.Request, params CreateTeamParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить команду с участниками
+// (GET /team/get)
+func (_ Unimplemented) GetTeam(w http.ResponseWriter, r *http.Request, params GetTeamParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить пользователя с текущей нагрузкой на ревью
+// (GET /users/get)
+func (_ Unimplemented) GetUser(w http.ResponseWriter, r *http.Request, params GetUserParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Получить PR'ы, где пользователь назначен ревьювером (от новых к старым)
+// (GET /users/getReview)
+func (_ Unimplemented) GetUserReviews(w http.ResponseWriter, r *http.Request, params GetUserReviewsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Список пользователей с фильтрами и курсорной пагинацией (по user_id)
+// (GET /users/list)
+func (_ Unimplemented) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Установить флаг активности пользователя
+// (POST /users/setIsActive)
+func (_ Unimplemented) SetUserIsActive(w http.ResponseWriter, r *http.Request, params SetUserIsActiveParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Поиск PR с фильтрами и keyset-пагинацией (от новых к старым по createdAt)
+// (GET /v2/pull-requests)
+func (_ Unimplemented) ListPullRequestsV2(w http.ResponseWriter, r *http.Request, params ListPullRequestsV2Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать PR и назначить до 2 ревьюверов из команды автора
+// (POST /v2/pull-requests)
+func (_ Unimplemented) CreatePullRequestV2(w http.ResponseWriter, r *http.Request, params CreatePullRequestV2Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Текущее состояние PR
+// (GET /v2/pull-requests/{id})
+func (_ Unimplemented) GetPullRequestV2(w http.ResponseWriter, r *http.Request, id PullRequestIdPath, params GetPullRequestV2Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Перевести PR в MERGED (идемпотентно); другие изменения не поддерживаются
+// (PATCH /v2/pull-requests/{id})
+func (_ Unimplemented) UpdatePullRequestV2(w http.ResponseWriter, r *http.Request, id PullRequestIdPath, params UpdatePullRequestV2Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Назначенные ревьюверы PR
+// (GET /v2/pull-requests/{id}/reviewers)
+func (_ Unimplemented) ListReviewersV2(w http.ResponseWriter, r *http.Request, id PullRequestIdPath, params ListReviewersV2Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Снять ревьювера; замена назначается из его команды
+// (DELETE /v2/pull-requests/{id}/reviewers/{user_id})
+func (_ Unimplemented) RemoveReviewerV2(w http.ResponseWriter, r *http.Request, id PullRequestIdPath, userId UserIdPath, params RemoveReviewerV2Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Создать команду с участниками (создаёт/обновляет пользователей)
+// (POST /v2/teams)
+func (_ Unimplemented) CreateTeamV2(w http.ResponseWriter, r *http.Request, params CreateTeamV2Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Команда с участниками
+// (GET /v2/teams/{name})
+func (_ Unimplemented) GetTeamV2(w http.ResponseWriter, r *http.Request, name TeamNamePath, params GetTeamV2Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Удалить участника команды
+// (DELETE /v2/teams/{name}/members/{user_id})
+func (_ Unimplemented) RemoveTeamMemberV2(w http.ResponseWriter, r *http.Request, name TeamNamePath, userId EntityID, params RemoveTeamMemberV2Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Участник команды; пользователь другой команды — 404
+// (GET /v2/teams/{name}/members/{user_id})
+func (_ Unimplemented) GetTeamMemberV2(w http.ResponseWriter, r *http.Request, name TeamNamePath, userId UserIdPath, params GetTeamMemberV2Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Изменить активность участника команды
+// (PATCH /v2/teams/{name}/members/{user_id})
+func (_ Unimplemented) UpdateTeamMemberV2(w http.ResponseWriter, r *http.Request, name TeamNamePath, userId UserIdPath, params UpdateTeamMemberV2Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Добавить пользователя в команду или перевести из другой
+// (PUT /v2/teams/{name}/members/{user_id})
+func (_ Unimplemented) PutTeamMemberV2(w http.ResponseWriter, r *http.Request, name TeamNamePath, userId UserIdPath, params PutTeamMemberV2Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Список пользователей с фильтрами и курсорной пагинацией (по user_id)
+// (GET /v2/users)
+func (_ Unimplemented) ListUsersV2(w http.ResponseWriter, r *http.Request, params ListUsersV2Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Пользователь с текущей нагрузкой на ревью
+// (GET /v2/users/{user_id})
+func (_ Unimplemented) GetUserV2(w http.ResponseWriter, r *http.Request, userId UserIdPath, params GetUserV2Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// PR, где пользователь назначен ревьювером (от новых к старым)
+// (GET /v2/users/{user_id}/reviews)
+func (_ Unimplemented) GetUserReviewsV2(w http.ResponseWriter, r *http.Request, userId UserIdPath, params GetUserReviewsV2Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// CreateToken operation middleware
+func (siw *ServerInterfaceWrapper) CreateToken(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListTokens operation middleware
+func (siw *ServerInterfaceWrapper) ListTokens(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTokens(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeToken operation middleware
+func (siw *ServerInterfaceWrapper) RevokeToken(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeToken(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Health operation middleware
+func (siw *ServerInterfaceWrapper) Health(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Health(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Livez operation middleware
+func (siw *ServerInterfaceWrapper) Livez(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Livez(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreatePullRequest operation middleware
+func (siw *ServerInterfaceWrapper) CreatePullRequest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreatePullRequestParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreatePullRequest(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPullRequest operation middleware
+func (siw *ServerInterfaceWrapper) GetPullRequest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPullRequestParams
+
+	// ------------- Required query parameter "pull_request_id" -------------
+
+	if paramValue := r.URL.Query().Get("pull_request_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "pull_request_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "pull_request_id", r.URL.Query(), &params.PullRequestId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pull_request_id", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPullRequest(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListPullRequests operation middleware
+func (siw *ServerInterfaceWrapper) ListPullRequests(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListPullRequestsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "author_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author_id", r.URL.Query(), &params.AuthorId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "reviewer_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reviewer_id", r.URL.Query(), &params.ReviewerId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reviewer_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", r.URL.Query(), &params.Name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_from", r.URL.Query(), &params.CreatedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_to", r.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "merged_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "merged_from", r.URL.Query(), &params.MergedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merged_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "merged_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "merged_to", r.URL.Query(), &params.MergedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merged_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPullRequests(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// MergePullRequest operation middleware
+func (siw *ServerInterfaceWrapper) MergePullRequest(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params MergePullRequestParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MergePullRequest(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReassignReviewer operation middleware
+func (siw *ServerInterfaceWrapper) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ReassignReviewerParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReassignReviewer(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Readyz operation middleware
+func (siw *ServerInterfaceWrapper) Readyz(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Readyz(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AssignRole operation middleware
+func (siw *ServerInterfaceWrapper) AssignRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AssignRoleParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AssignRole(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListRoles operation middleware
+func (siw *ServerInterfaceWrapper) ListRoles(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListRolesParams
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListRoles(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RevokeRole operation middleware
+func (siw *ServerInterfaceWrapper) RevokeRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RevokeRoleParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeRole(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateTeam operation middleware
+func (siw *ServerInterfaceWrapper) CreateTeam(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateTeamParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateTeam(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTeam operation middleware
+func (siw *ServerInterfaceWrapper) GetTeam(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTeamParams
+
+	// ------------- Required query parameter "team_name" -------------
+
+	if paramValue := r.URL.Query().Get("team_name"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "team_name"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTeam(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUser operation middleware
+func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := r.URL.Query().Get("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUser(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUserReviews operation middleware
+func (siw *ServerInterfaceWrapper) GetUserReviews(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserReviewsParams
+
+	// ------------- Required query parameter "user_id" -------------
+
+	if paramValue := r.URL.Query().Get("user_id"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "user_id"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserReviews(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListUsers operation middleware
+func (siw *ServerInterfaceWrapper) ListUsers(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUsersParams
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "is_active" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "is_active", r.URL.Query(), &params.IsActive)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "is_active", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "username_prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "username_prefix", r.URL.Query(), &params.UsernamePrefix)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username_prefix", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetUserIsActive operation middleware
+func (siw *ServerInterfaceWrapper) SetUserIsActive(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SetUserIsActiveParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetUserIsActive(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListPullRequestsV2 operation middleware
+func (siw *ServerInterfaceWrapper) ListPullRequestsV2(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListPullRequestsV2Params
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "author_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "author_id", r.URL.Query(), &params.AuthorId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "author_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "reviewer_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reviewer_id", r.URL.Query(), &params.ReviewerId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reviewer_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", r.URL.Query(), &params.Name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_from", r.URL.Query(), &params.CreatedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_to", r.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "merged_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "merged_from", r.URL.Query(), &params.MergedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merged_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "merged_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "merged_to", r.URL.Query(), &params.MergedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merged_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListPullRequestsV2(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreatePullRequestV2 operation middleware
+func (siw *ServerInterfaceWrapper) CreatePullRequestV2(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreatePullRequestV2Params
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreatePullRequestV2(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPullRequestV2 operation middleware
+func (siw *ServerInterfaceWrapper) GetPullRequestV2(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PullRequestIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPullRequestV2Params
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPullRequestV2(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdatePullRequestV2 operation middleware
+func (siw *ServerInterfaceWrapper) UpdatePullRequestV2(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PullRequestIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdatePullRequestV2Params
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdatePullRequestV2(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListReviewersV2 operation middleware
+func (siw *ServerInterfaceWrapper) ListReviewersV2(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PullRequestIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListReviewersV2Params
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListReviewersV2(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveReviewerV2 operation middleware
+func (siw *ServerInterfaceWrapper) RemoveReviewerV2(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PullRequestIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId UserIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RemoveReviewerV2Params
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveReviewerV2(w, r, id, userId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateTeamV2 operation middleware
+func (siw *ServerInterfaceWrapper) CreateTeamV2(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateTeamV2Params
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateTeamV2(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTeamV2 operation middleware
+func (siw *ServerInterfaceWrapper) GetTeamV2(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name TeamNamePath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTeamV2Params
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTeamV2(w, r, name, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RemoveTeamMemberV2 operation middleware
+func (siw *ServerInterfaceWrapper) RemoveTeamMemberV2(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name TeamNamePath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId EntityID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RemoveTeamMemberV2Params
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveTeamMemberV2(w, r, name, userId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetTeamMemberV2 operation middleware
+func (siw *ServerInterfaceWrapper) GetTeamMemberV2(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name TeamNamePath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId UserIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTeamMemberV2Params
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTeamMemberV2(w, r, name, userId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateTeamMemberV2 operation middleware
+func (siw *ServerInterfaceWrapper) UpdateTeamMemberV2(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name TeamNamePath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId UserIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateTeamMemberV2Params
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateTeamMemberV2(w, r, name, userId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutTeamMemberV2 operation middleware
+func (siw *ServerInterfaceWrapper) PutTeamMemberV2(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name TeamNamePath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "user_id" -------------
+	var userId UserIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutTeamMemberV2Params
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutTeamMemberV2(w, r, name, userId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListUsersV2 operation middleware
+func (siw *ServerInterfaceWrapper) ListUsersV2(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListUsersV2Params
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", r.URL.Query(), &params.TeamName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "team_name", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "is_active" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "is_active", r.URL.Query(), &params.IsActive)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "is_active", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "username_prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "username_prefix", r.URL.Query(), &params.UsernamePrefix)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "username_prefix", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUsersV2(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUserV2 operation middleware
+func (siw *ServerInterfaceWrapper) GetUserV2(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId UserIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserV2Params
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserV2(w, r, userId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetUserReviewsV2 operation middleware
+func (siw *ServerInterfaceWrapper) GetUserReviewsV2(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId UserIdPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUserReviewsV2Params
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetUserReviewsV2(w, r, userId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
+}
+
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
+
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/token/create", wrapper.CreateToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/token/list", wrapper.ListTokens)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/token/revoke", wrapper.RevokeToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/health", wrapper.Health)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/livez", wrapper.Livez)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/create", wrapper.CreatePullRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pullRequest/get", wrapper.GetPullRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/pullRequest/list", wrapper.ListPullRequests)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/merge", wrapper.MergePullRequest)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/pullRequest/reassign", wrapper.ReassignReviewer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/readyz", wrapper.Readyz)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/roles/assign", wrapper.AssignRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/roles/list", wrapper.ListRoles)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/roles/revoke", wrapper.RevokeRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/team/add", wrapper.CreateTeam)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/team/get", wrapper.GetTeam)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/get", wrapper.GetUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/getReview", wrapper.GetUserReviews)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/list", wrapper.ListUsers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/setIsActive", wrapper.SetUserIsActive)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v2/pull-requests", wrapper.ListPullRequestsV2)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v2/pull-requests", wrapper.CreatePullRequestV2)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v2/pull-requests/{id}", wrapper.GetPullRequestV2)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/v2/pull-requests/{id}", wrapper.UpdatePullRequestV2)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v2/pull-requests/{id}/reviewers", wrapper.ListReviewersV2)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v2/pull-requests/{id}/reviewers/{user_id}", wrapper.RemoveReviewerV2)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v2/teams", wrapper.CreateTeamV2)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v2/teams/{name}", wrapper.GetTeamV2)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v2/teams/{name}/members/{user_id}", wrapper.RemoveTeamMemberV2)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v2/teams/{name}/members/{user_id}", wrapper.GetTeamMemberV2)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/v2/teams/{name}/members/{user_id}", wrapper.UpdateTeamMemberV2)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v2/teams/{name}/members/{user_id}", wrapper.PutTeamMemberV2)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v2/users", wrapper.ListUsersV2)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v2/users/{user_id}", wrapper.GetUserV2)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v2/users/{user_id}/reviews", wrapper.GetUserReviewsV2)
+	})
+
+	return r
+}
+
+type BadRequestJSONResponse ErrorResponse
+
+type ConflictJSONResponse ErrorResponse
+
+type ForbiddenJSONResponse ErrorResponse
+
+type IdempotencyKeyReusedJSONResponse ErrorResponse
+
+type InsufficientScopeJSONResponse ErrorResponse
+
+type NotFoundJSONResponse ErrorResponse
+
+type NotModifiedResponseHeaders struct {
+	ETag string
+}
+type NotModifiedResponse struct {
+	Headers NotModifiedResponseHeaders
+}
+
+type PreconditionFailedJSONResponse ErrorResponse
+
+type UnauthorizedResponseHeaders struct {
+	WWWAuthenticate string
+}
+type UnauthorizedJSONResponse struct {
+	Body ErrorResponse
+
+	Headers UnauthorizedResponseHeaders
+}
+
+type CreateTokenRequestObject struct {
+	Body *CreateTokenJSONRequestBody
+}
+
+type CreateTokenResponseObject interface {
+	VisitCreateTokenResponse(w http.ResponseWriter) error
+}
+
+type CreateToken201JSONResponse IssuedToken
+
+func (response CreateToken201JSONResponse) VisitCreateTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateToken400JSONResponse ErrorResponse
+
+func (response CreateToken400JSONResponse) VisitCreateTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateToken401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CreateToken401JSONResponse) VisitCreateTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateToken403JSONResponse struct{ InsufficientScopeJSONResponse }
+
+func (response CreateToken403JSONResponse) VisitCreateTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTokensRequestObject struct {
+}
+
+type ListTokensResponseObject interface {
+	VisitListTokensResponse(w http.ResponseWriter) error
+}
+
+type ListTokens200JSONResponse TokenList
+
+func (response ListTokens200JSONResponse) VisitListTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTokens401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListTokens401JSONResponse) VisitListTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListTokens403JSONResponse struct{ InsufficientScopeJSONResponse }
+
+func (response ListTokens403JSONResponse) VisitListTokensResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeTokenRequestObject struct {
+	Body *RevokeTokenJSONRequestBody
+}
+
+type RevokeTokenResponseObject interface {
+	VisitRevokeTokenResponse(w http.ResponseWriter) error
+}
+
+type RevokeToken200JSONResponse TokenRevocation
+
+func (response RevokeToken200JSONResponse) VisitRevokeTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeToken400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response RevokeToken400JSONResponse) VisitRevokeTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeToken401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RevokeToken401JSONResponse) VisitRevokeTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RevokeToken403JSONResponse struct{ InsufficientScopeJSONResponse }
+
+func (response RevokeToken403JSONResponse) VisitRevokeTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeToken404JSONResponse ErrorResponse
+
+func (response RevokeToken404JSONResponse) VisitRevokeTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HealthRequestObject struct {
+}
+
+type HealthResponseObject interface {
+	VisitHealthResponse(w http.ResponseWriter) error
+}
+
+type Health200JSONResponse HealthStatus
+
+func (response Health200JSONResponse) VisitHealthResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LivezRequestObject struct {
+}
+
+type LivezResponseObject interface {
+	VisitLivezResponse(w http.ResponseWriter) error
+}
+
+type Livez200JSONResponse HealthStatus
+
+func (response Livez200JSONResponse) VisitLivezResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePullRequestRequestObject struct {
+	Params CreatePullRequestParams
+	Body   *CreatePullRequestJSONRequestBody
+}
+
+type CreatePullRequestResponseObject interface {
+	VisitCreatePullRequestResponse(w http.ResponseWriter) error
+}
+
+type CreatePullRequest201ResponseHeaders struct {
+	ETag string
+}
+
+type CreatePullRequest201JSONResponse struct {
+	Body    PullRequestResponse
+	Headers CreatePullRequest201ResponseHeaders
+}
+
+func (response CreatePullRequest201JSONResponse) VisitCreatePullRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreatePullRequest400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreatePullRequest400JSONResponse) VisitCreatePullRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePullRequest404JSONResponse ErrorResponse
+
+func (response CreatePullRequest404JSONResponse) VisitCreatePullRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePullRequest409JSONResponse ErrorResponse
+
+func (response CreatePullRequest409JSONResponse) VisitCreatePullRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePullRequest422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response CreatePullRequest422JSONResponse) VisitCreatePullRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPullRequestRequestObject struct {
+	Params GetPullRequestParams
+}
+
+type GetPullRequestResponseObject interface {
+	VisitGetPullRequestResponse(w http.ResponseWriter) error
+}
+
+type GetPullRequest200ResponseHeaders struct {
+	ETag string
+}
+
+type GetPullRequest200JSONResponse struct {
+	Body    PullRequestResponse
+	Headers GetPullRequest200ResponseHeaders
+}
+
+func (response GetPullRequest200JSONResponse) VisitGetPullRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetPullRequest304Response = NotModifiedResponse
+
+func (response GetPullRequest304Response) VisitGetPullRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetPullRequest400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetPullRequest400JSONResponse) VisitGetPullRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPullRequest404JSONResponse ErrorResponse
+
+func (response GetPullRequest404JSONResponse) VisitGetPullRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPullRequestsRequestObject struct {
+	Params ListPullRequestsParams
+}
+
+type ListPullRequestsResponseObject interface {
+	VisitListPullRequestsResponse(w http.ResponseWriter) error
+}
+
+type ListPullRequests200JSONResponse PullRequestPage
+
+func (response ListPullRequests200JSONResponse) VisitListPullRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPullRequests400JSONResponse ErrorResponse
+
+func (response ListPullRequests400JSONResponse) VisitListPullRequestsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergePullRequestRequestObject struct {
+	Params MergePullRequestParams
+	Body   *MergePullRequestJSONRequestBody
+}
+
+type MergePullRequestResponseObject interface {
+	VisitMergePullRequestResponse(w http.ResponseWriter) error
+}
+
+type MergePullRequest200ResponseHeaders struct {
+	ETag string
+}
+
+type MergePullRequest200JSONResponse struct {
+	Body    PullRequestResponse
+	Headers MergePullRequest200ResponseHeaders
+}
+
+func (response MergePullRequest200JSONResponse) VisitMergePullRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type MergePullRequest400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response MergePullRequest400JSONResponse) VisitMergePullRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergePullRequest404JSONResponse ErrorResponse
+
+func (response MergePullRequest404JSONResponse) VisitMergePullRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergePullRequest412JSONResponse struct{ PreconditionFailedJSONResponse }
+
+func (response MergePullRequest412JSONResponse) VisitMergePullRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MergePullRequest422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response MergePullRequest422JSONResponse) VisitMergePullRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReassignReviewerRequestObject struct {
+	Params ReassignReviewerParams
+	Body   *ReassignReviewerJSONRequestBody
+}
+
+type ReassignReviewerResponseObject interface {
+	VisitReassignReviewerResponse(w http.ResponseWriter) error
+}
+
+type ReassignReviewer200ResponseHeaders struct {
+	ETag string
+}
+
+type ReassignReviewer200JSONResponse struct {
+	Body    ReassignResult
+	Headers ReassignReviewer200ResponseHeaders
+}
+
+func (response ReassignReviewer200JSONResponse) VisitReassignReviewerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReassignReviewer400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ReassignReviewer400JSONResponse) VisitReassignReviewerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReassignReviewer404JSONResponse ErrorResponse
+
+func (response ReassignReviewer404JSONResponse) VisitReassignReviewerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReassignReviewer409JSONResponse ErrorResponse
+
+func (response ReassignReviewer409JSONResponse) VisitReassignReviewerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReassignReviewer412JSONResponse struct{ PreconditionFailedJSONResponse }
+
+func (response ReassignReviewer412JSONResponse) VisitReassignReviewerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReassignReviewer422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response ReassignReviewer422JSONResponse) VisitReassignReviewerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReadyzRequestObject struct {
+}
+
+type ReadyzResponseObject interface {
+	VisitReadyzResponse(w http.ResponseWriter) error
+}
+
+type Readyz200ResponseHeaders struct {
+	CacheControl string
+}
+
+type Readyz200JSONResponse struct {
+	Body    HealthReport
+	Headers Readyz200ResponseHeaders
+}
+
+func (response Readyz200JSONResponse) VisitReadyzResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type Readyz503ResponseHeaders struct {
+	CacheControl string
+}
+
+type Readyz503JSONResponse struct {
+	Body    HealthReport
+	Headers Readyz503ResponseHeaders
+}
+
+func (response Readyz503JSONResponse) VisitReadyzResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprint(response.Headers.CacheControl))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type AssignRoleRequestObject struct {
+	Params AssignRoleParams
+	Body   *AssignRoleJSONRequestBody
+}
+
+type AssignRoleResponseObject interface {
+	VisitAssignRoleResponse(w http.ResponseWriter) error
+}
+
+type AssignRole200JSONResponse RoleResponse
+
+func (response AssignRole200JSONResponse) VisitAssignRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignRole400JSONResponse ErrorResponse
+
+func (response AssignRole400JSONResponse) VisitAssignRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignRole401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response AssignRole401JSONResponse) VisitAssignRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type AssignRole403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response AssignRole403JSONResponse) VisitAssignRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignRole404JSONResponse ErrorResponse
+
+func (response AssignRole404JSONResponse) VisitAssignRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignRole422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response AssignRole422JSONResponse) VisitAssignRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRolesRequestObject struct {
+	Params ListRolesParams
+}
+
+type ListRolesResponseObject interface {
+	VisitListRolesResponse(w http.ResponseWriter) error
+}
+
+type ListRoles200JSONResponse RoleList
+
+func (response ListRoles200JSONResponse) VisitListRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListRoles401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListRoles401JSONResponse) VisitListRolesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RevokeRoleRequestObject struct {
+	Params RevokeRoleParams
+	Body   *RevokeRoleJSONRequestBody
+}
+
+type RevokeRoleResponseObject interface {
+	VisitRevokeRoleResponse(w http.ResponseWriter) error
+}
+
+type RevokeRole200JSONResponse RoleRevocation
+
+func (response RevokeRole200JSONResponse) VisitRevokeRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeRole400JSONResponse ErrorResponse
+
+func (response RevokeRole400JSONResponse) VisitRevokeRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeRole401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RevokeRole401JSONResponse) VisitRevokeRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", fmt.Sprint(response.Headers.WWWAuthenticate))
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RevokeRole403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response RevokeRole403JSONResponse) VisitRevokeRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeRole404JSONResponse ErrorResponse
+
+func (response RevokeRole404JSONResponse) VisitRevokeRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RevokeRole422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response RevokeRole422JSONResponse) VisitRevokeRoleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTeamRequestObject struct {
+	Params CreateTeamParams
+	Body   *CreateTeamJSONRequestBody
+}
+
+type CreateTeamResponseObject interface {
+	VisitCreateTeamResponse(w http.ResponseWriter) error
+}
+
+type CreateTeam201JSONResponse TeamResponse
+
+func (response CreateTeam201JSONResponse) VisitCreateTeamResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTeam400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateTeam400JSONResponse) VisitCreateTeamResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTeam409JSONResponse ErrorResponse
+
+func (response CreateTeam409JSONResponse) VisitCreateTeamResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTeam422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response CreateTeam422JSONResponse) VisitCreateTeamResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTeamRequestObject struct {
+	Params GetTeamParams
+}
+
+type GetTeamResponseObject interface {
+	VisitGetTeamResponse(w http.ResponseWriter) error
+}
+
+type GetTeam200ResponseHeaders struct {
+	ETag string
+}
+
+type GetTeam200JSONResponse struct {
+	Body    Team
+	Headers GetTeam200ResponseHeaders
+}
+
+func (response GetTeam200JSONResponse) VisitGetTeamResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetTeam304Response = NotModifiedResponse
+
+func (response GetTeam304Response) VisitGetTeamResponse(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetTeam400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetTeam400JSONResponse) VisitGetTeamResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTeam404JSONResponse ErrorResponse
+
+func (response GetTeam404JSONResponse) VisitGetTeamResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserRequestObject struct {
+	Params GetUserParams
+}
+
+type GetUserResponseObject interface {
+	VisitGetUserResponse(w http.ResponseWriter) error
+}
+
+type GetUser200ResponseHeaders struct {
+	ETag string
+}
+
+type GetUser200JSONResponse struct {
+	Body    UserDetailsResponse
+	Headers GetUser200ResponseHeaders
+}
+
+func (response GetUser200JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetUser304Response = NotModifiedResponse
+
+func (response GetUser304Response) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetUser400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetUser400JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUser404JSONResponse ErrorResponse
+
+func (response GetUser404JSONResponse) VisitGetUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserReviewsRequestObject struct {
+	Params GetUserReviewsParams
+}
+
+type GetUserReviewsResponseObject interface {
+	VisitGetUserReviewsResponse(w http.ResponseWriter) error
+}
+
+type GetUserReviews200JSONResponse ReviewPage
+
+func (response GetUserReviews200JSONResponse) VisitGetUserReviewsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserReviews400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetUserReviews400JSONResponse) VisitGetUserReviewsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserReviews404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetUserReviews404JSONResponse) VisitGetUserReviewsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUsersRequestObject struct {
+	Params ListUsersParams
+}
+
+type ListUsersResponseObject interface {
+	VisitListUsersResponse(w http.ResponseWriter) error
+}
+
+type ListUsers200JSONResponse UserPage
+
+func (response ListUsers200JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUsers400JSONResponse ErrorResponse
+
+func (response ListUsers400JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserIsActiveRequestObject struct {
+	Params SetUserIsActiveParams
+	Body   *SetUserIsActiveJSONRequestBody
+}
+
+type SetUserIsActiveResponseObject interface {
+	VisitSetUserIsActiveResponse(w http.ResponseWriter) error
+}
+
+type SetUserIsActive200ResponseHeaders struct {
+	ETag string
+}
+
+type SetUserIsActive200JSONResponse struct {
+	Body    UserResponse
+	Headers SetUserIsActive200ResponseHeaders
+}
+
+func (response SetUserIsActive200JSONResponse) VisitSetUserIsActiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SetUserIsActive400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response SetUserIsActive400JSONResponse) VisitSetUserIsActiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserIsActive404JSONResponse ErrorResponse
+
+func (response SetUserIsActive404JSONResponse) VisitSetUserIsActiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserIsActive412JSONResponse struct{ PreconditionFailedJSONResponse }
+
+func (response SetUserIsActive412JSONResponse) VisitSetUserIsActiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetUserIsActive422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response SetUserIsActive422JSONResponse) VisitSetUserIsActiveResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPullRequestsV2RequestObject struct {
+	Params ListPullRequestsV2Params
+}
+
+type ListPullRequestsV2ResponseObject interface {
+	VisitListPullRequestsV2Response(w http.ResponseWriter) error
+}
+
+type ListPullRequestsV2200JSONResponse PullRequestPage
+
+func (response ListPullRequestsV2200JSONResponse) VisitListPullRequestsV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListPullRequestsV2400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ListPullRequestsV2400JSONResponse) VisitListPullRequestsV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePullRequestV2RequestObject struct {
+	Params CreatePullRequestV2Params
+	Body   *CreatePullRequestV2JSONRequestBody
+}
+
+type CreatePullRequestV2ResponseObject interface {
+	VisitCreatePullRequestV2Response(w http.ResponseWriter) error
+}
+
+type CreatePullRequestV2201ResponseHeaders struct {
+	ETag     string
+	Location string
+}
+
+type CreatePullRequestV2201JSONResponse struct {
+	Body    PullRequest
+	Headers CreatePullRequestV2201ResponseHeaders
+}
+
+func (response CreatePullRequestV2201JSONResponse) VisitCreatePullRequestV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreatePullRequestV2400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreatePullRequestV2400JSONResponse) VisitCreatePullRequestV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePullRequestV2404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response CreatePullRequestV2404JSONResponse) VisitCreatePullRequestV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePullRequestV2409JSONResponse struct{ ConflictJSONResponse }
+
+func (response CreatePullRequestV2409JSONResponse) VisitCreatePullRequestV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePullRequestV2422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response CreatePullRequestV2422JSONResponse) VisitCreatePullRequestV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPullRequestV2RequestObject struct {
+	Id     PullRequestIdPath `json:"id"`
+	Params GetPullRequestV2Params
+}
+
+type GetPullRequestV2ResponseObject interface {
+	VisitGetPullRequestV2Response(w http.ResponseWriter) error
+}
+
+type GetPullRequestV2200ResponseHeaders struct {
+	ETag string
+}
+
+type GetPullRequestV2200JSONResponse struct {
+	Body    PullRequest
+	Headers GetPullRequestV2200ResponseHeaders
+}
+
+func (response GetPullRequestV2200JSONResponse) VisitGetPullRequestV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetPullRequestV2304Response = NotModifiedResponse
+
+func (response GetPullRequestV2304Response) VisitGetPullRequestV2Response(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetPullRequestV2404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetPullRequestV2404JSONResponse) VisitGetPullRequestV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdatePullRequestV2RequestObject struct {
+	Id     PullRequestIdPath `json:"id"`
+	Params UpdatePullRequestV2Params
+	Body   *UpdatePullRequestV2JSONRequestBody
+}
+
+type UpdatePullRequestV2ResponseObject interface {
+	VisitUpdatePullRequestV2Response(w http.ResponseWriter) error
+}
+
+type UpdatePullRequestV2200ResponseHeaders struct {
+	ETag string
+}
+
+type UpdatePullRequestV2200JSONResponse struct {
+	Body    PullRequest
+	Headers UpdatePullRequestV2200ResponseHeaders
+}
+
+func (response UpdatePullRequestV2200JSONResponse) VisitUpdatePullRequestV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UpdatePullRequestV2400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdatePullRequestV2400JSONResponse) VisitUpdatePullRequestV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdatePullRequestV2404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdatePullRequestV2404JSONResponse) VisitUpdatePullRequestV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdatePullRequestV2412JSONResponse struct{ PreconditionFailedJSONResponse }
+
+func (response UpdatePullRequestV2412JSONResponse) VisitUpdatePullRequestV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdatePullRequestV2422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response UpdatePullRequestV2422JSONResponse) VisitUpdatePullRequestV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListReviewersV2RequestObject struct {
+	Id     PullRequestIdPath `json:"id"`
+	Params ListReviewersV2Params
+}
+
+type ListReviewersV2ResponseObject interface {
+	VisitListReviewersV2Response(w http.ResponseWriter) error
+}
+
+type ListReviewersV2200ResponseHeaders struct {
+	ETag string
+}
+
+type ListReviewersV2200JSONResponse struct {
+	Body    ReviewerList
+	Headers ListReviewersV2200ResponseHeaders
+}
+
+func (response ListReviewersV2200JSONResponse) VisitListReviewersV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListReviewersV2304Response = NotModifiedResponse
+
+func (response ListReviewersV2304Response) VisitListReviewersV2Response(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type ListReviewersV2404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response ListReviewersV2404JSONResponse) VisitListReviewersV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveReviewerV2RequestObject struct {
+	Id     PullRequestIdPath `json:"id"`
+	UserId UserIdPath        `json:"user_id"`
+	Params RemoveReviewerV2Params
+}
+
+type RemoveReviewerV2ResponseObject interface {
+	VisitRemoveReviewerV2Response(w http.ResponseWriter) error
+}
+
+type RemoveReviewerV2200ResponseHeaders struct {
+	ETag string
+}
+
+type RemoveReviewerV2200JSONResponse struct {
+	Body    ReviewerReplacement
+	Headers RemoveReviewerV2200ResponseHeaders
+}
+
+func (response RemoveReviewerV2200JSONResponse) VisitRemoveReviewerV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RemoveReviewerV2404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response RemoveReviewerV2404JSONResponse) VisitRemoveReviewerV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveReviewerV2409JSONResponse struct{ ConflictJSONResponse }
+
+func (response RemoveReviewerV2409JSONResponse) VisitRemoveReviewerV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveReviewerV2412JSONResponse struct{ PreconditionFailedJSONResponse }
+
+func (response RemoveReviewerV2412JSONResponse) VisitRemoveReviewerV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveReviewerV2422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response RemoveReviewerV2422JSONResponse) VisitRemoveReviewerV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTeamV2RequestObject struct {
+	Params CreateTeamV2Params
+	Body   *CreateTeamV2JSONRequestBody
+}
+
+type CreateTeamV2ResponseObject interface {
+	VisitCreateTeamV2Response(w http.ResponseWriter) error
+}
+
+type CreateTeamV2201ResponseHeaders struct {
+	Location string
+}
+
+type CreateTeamV2201JSONResponse struct {
+	Body    Team
+	Headers CreateTeamV2201ResponseHeaders
+}
+
+func (response CreateTeamV2201JSONResponse) VisitCreateTeamV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateTeamV2400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateTeamV2400JSONResponse) VisitCreateTeamV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTeamV2409JSONResponse struct{ ConflictJSONResponse }
+
+func (response CreateTeamV2409JSONResponse) VisitCreateTeamV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateTeamV2422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response CreateTeamV2422JSONResponse) VisitCreateTeamV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTeamV2RequestObject struct {
+	Name   TeamNamePath `json:"name"`
+	Params GetTeamV2Params
+}
+
+type GetTeamV2ResponseObject interface {
+	VisitGetTeamV2Response(w http.ResponseWriter) error
+}
+
+type GetTeamV2200ResponseHeaders struct {
+	ETag string
+}
+
+type GetTeamV2200JSONResponse struct {
+	Body    Team
+	Headers GetTeamV2200ResponseHeaders
+}
+
+func (response GetTeamV2200JSONResponse) VisitGetTeamV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetTeamV2304Response = NotModifiedResponse
+
+func (response GetTeamV2304Response) VisitGetTeamV2Response(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetTeamV2404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetTeamV2404JSONResponse) VisitGetTeamV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveTeamMemberV2RequestObject struct {
+	Name   TeamNamePath `json:"name"`
+	UserId EntityID     `json:"user_id"`
+	Params RemoveTeamMemberV2Params
+}
+
+type RemoveTeamMemberV2ResponseObject interface {
+	VisitRemoveTeamMemberV2Response(w http.ResponseWriter) error
+}
+
+type RemoveTeamMemberV2204Response struct {
+}
+
+func (response RemoveTeamMemberV2204Response) VisitRemoveTeamMemberV2Response(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RemoveTeamMemberV2403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response RemoveTeamMemberV2403JSONResponse) VisitRemoveTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveTeamMemberV2404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response RemoveTeamMemberV2404JSONResponse) VisitRemoveTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveTeamMemberV2409JSONResponse struct{ ConflictJSONResponse }
+
+func (response RemoveTeamMemberV2409JSONResponse) VisitRemoveTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveTeamMemberV2412JSONResponse struct{ PreconditionFailedJSONResponse }
+
+func (response RemoveTeamMemberV2412JSONResponse) VisitRemoveTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RemoveTeamMemberV2422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response RemoveTeamMemberV2422JSONResponse) VisitRemoveTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTeamMemberV2RequestObject struct {
+	Name   TeamNamePath `json:"name"`
+	UserId UserIdPath   `json:"user_id"`
+	Params GetTeamMemberV2Params
+}
+
+type GetTeamMemberV2ResponseObject interface {
+	VisitGetTeamMemberV2Response(w http.ResponseWriter) error
+}
+
+type GetTeamMemberV2200ResponseHeaders struct {
+	ETag string
+}
+
+type GetTeamMemberV2200JSONResponse struct {
+	Body    UserDetails
+	Headers GetTeamMemberV2200ResponseHeaders
+}
+
+func (response GetTeamMemberV2200JSONResponse) VisitGetTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetTeamMemberV2304Response = NotModifiedResponse
+
+func (response GetTeamMemberV2304Response) VisitGetTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetTeamMemberV2404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetTeamMemberV2404JSONResponse) VisitGetTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTeamMemberV2RequestObject struct {
+	Name   TeamNamePath `json:"name"`
+	UserId UserIdPath   `json:"user_id"`
+	Params UpdateTeamMemberV2Params
+	Body   *UpdateTeamMemberV2JSONRequestBody
+}
+
+type UpdateTeamMemberV2ResponseObject interface {
+	VisitUpdateTeamMemberV2Response(w http.ResponseWriter) error
+}
+
+type UpdateTeamMemberV2200ResponseHeaders struct {
+	ETag string
+}
+
+type UpdateTeamMemberV2200JSONResponse struct {
+	Body    User
+	Headers UpdateTeamMemberV2200ResponseHeaders
+}
+
+func (response UpdateTeamMemberV2200JSONResponse) VisitUpdateTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UpdateTeamMemberV2400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateTeamMemberV2400JSONResponse) VisitUpdateTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTeamMemberV2404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdateTeamMemberV2404JSONResponse) VisitUpdateTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTeamMemberV2412JSONResponse struct{ PreconditionFailedJSONResponse }
+
+func (response UpdateTeamMemberV2412JSONResponse) VisitUpdateTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTeamMemberV2422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response UpdateTeamMemberV2422JSONResponse) VisitUpdateTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutTeamMemberV2RequestObject struct {
+	Name   TeamNamePath `json:"name"`
+	UserId UserIdPath   `json:"user_id"`
+	Params PutTeamMemberV2Params
+	Body   *PutTeamMemberV2JSONRequestBody
+}
+
+type PutTeamMemberV2ResponseObject interface {
+	VisitPutTeamMemberV2Response(w http.ResponseWriter) error
+}
+
+type PutTeamMemberV2200ResponseHeaders struct {
+	ETag string
+}
+
+type PutTeamMemberV2200JSONResponse struct {
+	Body    User
+	Headers PutTeamMemberV2200ResponseHeaders
+}
+
+func (response PutTeamMemberV2200JSONResponse) VisitPutTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PutTeamMemberV2400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response PutTeamMemberV2400JSONResponse) VisitPutTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutTeamMemberV2403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response PutTeamMemberV2403JSONResponse) VisitPutTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutTeamMemberV2404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response PutTeamMemberV2404JSONResponse) VisitPutTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutTeamMemberV2412JSONResponse struct{ PreconditionFailedJSONResponse }
+
+func (response PutTeamMemberV2412JSONResponse) VisitPutTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutTeamMemberV2422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response PutTeamMemberV2422JSONResponse) VisitPutTeamMemberV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUsersV2RequestObject struct {
+	Params ListUsersV2Params
+}
+
+type ListUsersV2ResponseObject interface {
+	VisitListUsersV2Response(w http.ResponseWriter) error
+}
+
+type ListUsersV2200JSONResponse UserPage
+
+func (response ListUsersV2200JSONResponse) VisitListUsersV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListUsersV2400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ListUsersV2400JSONResponse) VisitListUsersV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserV2RequestObject struct {
+	UserId UserIdPath `json:"user_id"`
+	Params GetUserV2Params
+}
+
+type GetUserV2ResponseObject interface {
+	VisitGetUserV2Response(w http.ResponseWriter) error
+}
+
+type GetUserV2200ResponseHeaders struct {
+	ETag string
+}
+
+type GetUserV2200JSONResponse struct {
+	Body    UserDetails
+	Headers GetUserV2200ResponseHeaders
+}
+
+func (response GetUserV2200JSONResponse) VisitGetUserV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetUserV2304Response = NotModifiedResponse
+
+func (response GetUserV2304Response) VisitGetUserV2Response(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetUserV2404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetUserV2404JSONResponse) VisitGetUserV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserReviewsV2RequestObject struct {
+	UserId UserIdPath `json:"user_id"`
+	Params GetUserReviewsV2Params
+}
+
+type GetUserReviewsV2ResponseObject interface {
+	VisitGetUserReviewsV2Response(w http.ResponseWriter) error
+}
+
+type GetUserReviewsV2200JSONResponse ReviewPage
+
+func (response GetUserReviewsV2200JSONResponse) VisitGetUserReviewsV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserReviewsV2400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response GetUserReviewsV2400JSONResponse) VisitGetUserReviewsV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUserReviewsV2404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetUserReviewsV2404JSONResponse) VisitGetUserReviewsV2Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Выпустить API токен (scope admin)
+	// (POST /auth/token/create)
+	CreateToken(ctx context.Context, request CreateTokenRequestObject) (CreateTokenResponseObject, error)
+	// Список API токенов без значений (scope admin)
+	// (GET /auth/token/list)
+	ListTokens(ctx context.Context, request ListTokensRequestObject) (ListTokensResponseObject, error)
+	// Отозвать API токен (scope admin)
+	// (POST /auth/token/revoke)
+	RevokeToken(ctx context.Context, request RevokeTokenRequestObject) (RevokeTokenResponseObject, error)
+	// То же, что /livez (для совместимости)
+	// (GET /health)
+	Health(ctx context.Context, request HealthRequestObject) (HealthResponseObject, error)
+	// Живость процесса; зависимости не проверяются
+	// (GET /livez)
+	Livez(ctx context.Context, request LivezRequestObject) (LivezResponseObject, error)
+	// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+	// (POST /pullRequest/create)
+	CreatePullRequest(ctx context.Context, request CreatePullRequestRequestObject) (CreatePullRequestResponseObject, error)
+	// Получить PR по идентификатору
+	// (GET /pullRequest/get)
+	GetPullRequest(ctx context.Context, request GetPullRequestRequestObject) (GetPullRequestResponseObject, error)
+	// Поиск PR с фильтрами и keyset-пагинацией (от новых к старым по createdAt)
+	// (GET /pullRequest/list)
+	ListPullRequests(ctx context.Context, request ListPullRequestsRequestObject) (ListPullRequestsResponseObject, error)
+	// Пометить PR как MERGED (идемпотентная операция)
+	// (POST /pullRequest/merge)
+	MergePullRequest(ctx context.Context, request MergePullRequestRequestObject) (MergePullRequestResponseObject, error)
+	// Переназначить конкретного ревьювера на другого из его команды
+	// (POST /pullRequest/reassign)
+	ReassignReviewer(ctx context.Context, request ReassignReviewerRequestObject) (ReassignReviewerResponseObject, error)
+	// Готовность принимать запросы (БД, схема, пул, фоновые задачи)
+	// (GET /readyz)
+	Readyz(ctx context.Context, request ReadyzRequestObject) (ReadyzResponseObject, error)
+	// Назначить роль (только org_admin)
+	// (POST /roles/assign)
+	AssignRole(ctx context.Context, request AssignRoleRequestObject) (AssignRoleResponseObject, error)
+	// Список назначенных ролей
+	// (GET /roles/list)
+	ListRoles(ctx context.Context, request ListRolesRequestObject) (ListRolesResponseObject, error)
+	// Отозвать роль (только org_admin)
+	// (POST /roles/revoke)
+	RevokeRole(ctx context.Context, request RevokeRoleRequestObject) (RevokeRoleResponseObject, error)
+	// Создать команду с участниками (создаёт/обновляет пользователей)
+	// (POST /team/add)
+	CreateTeam(ctx context.Context, request CreateTeamRequestObject) (CreateTeamResponseObject, error)
+	// Получить команду с участниками
+	// (GET /team/get)
+	GetTeam(ctx context.Context, request GetTeamRequestObject) (GetTeamResponseObject, error)
+	// Получить пользователя с текущей нагрузкой на ревью
+	// (GET /users/get)
+	GetUser(ctx context.Context, request GetUserRequestObject) (GetUserResponseObject, error)
+	// Получить PR'ы, где пользователь назначен ревьювером (от новых к старым)
+	// (GET /users/getReview)
+	GetUserReviews(ctx context.Context, request GetUserReviewsRequestObject) (GetUserReviewsResponseObject, error)
+	// Список пользователей с фильтрами и курсорной пагинацией (по user_id)
+	// (GET /users/list)
+	ListUsers(ctx context.Context, request ListUsersRequestObject) (ListUsersResponseObject, error)
+	// Установить флаг активности пользователя
+	// (POST /users/setIsActive)
+	SetUserIsActive(ctx context.Context, request SetUserIsActiveRequestObject) (SetUserIsActiveResponseObject, error)
+	// Поиск PR с фильтрами и keyset-пагинацией (от новых к старым по createdAt)
+	// (GET /v2/pull-requests)
+	ListPullRequestsV2(ctx context.Context, request ListPullRequestsV2RequestObject) (ListPullRequestsV2ResponseObject, error)
+	// Создать PR и назначить до 2 ревьюверов из команды автора
+	// (POST /v2/pull-requests)
+	CreatePullRequestV2(ctx context.Context, request CreatePullRequestV2RequestObject) (CreatePullRequestV2ResponseObject, error)
+	// Текущее состояние PR
+	// (GET /v2/pull-requests/{id})
+	GetPullRequestV2(ctx context.Context, request GetPullRequestV2RequestObject) (GetPullRequestV2ResponseObject, error)
+	// Перевести PR в MERGED (идемпотентно); другие изменения не поддерживаются
+	// (PATCH /v2/pull-requests/{id})
+	UpdatePullRequestV2(ctx context.Context, request UpdatePullRequestV2RequestObject) (UpdatePullRequestV2ResponseObject, error)
+	// Назначенные ревьюверы PR
+	// (GET /v2/pull-requests/{id}/reviewers)
+	ListReviewersV2(ctx context.Context, request ListReviewersV2RequestObject) (ListReviewersV2ResponseObject, error)
+	// Снять ревьювера; замена назначается из его команды
+	// (DELETE /v2/pull-requests/{id}/reviewers/{user_id})
+	RemoveReviewerV2(ctx context.Context, request RemoveReviewerV2RequestObject) (RemoveReviewerV2ResponseObject, error)
+	// Создать команду с участниками (создаёт/обновляет пользователей)
+	// (POST /v2/teams)
+	CreateTeamV2(ctx context.Context, request CreateTeamV2RequestObject) (CreateTeamV2ResponseObject, error)
+	// Команда с участниками
+	// (GET /v2/teams/{name})
+	GetTeamV2(ctx context.Context, request GetTeamV2RequestObject) (GetTeamV2ResponseObject, error)
+	// Удалить участника команды
+	// (DELETE /v2/teams/{name}/members/{user_id})
+	RemoveTeamMemberV2(ctx context.Context, request RemoveTeamMemberV2RequestObject) (RemoveTeamMemberV2ResponseObject, error)
+	// Участник команды; пользователь другой команды — 404
+	// (GET /v2/teams/{name}/members/{user_id})
+	GetTeamMemberV2(ctx context.Context, request GetTeamMemberV2RequestObject) (GetTeamMemberV2ResponseObject, error)
+	// Изменить активность участника команды
+	// (PATCH /v2/teams/{name}/members/{user_id})
+	UpdateTeamMemberV2(ctx context.Context, request UpdateTeamMemberV2RequestObject) (UpdateTeamMemberV2ResponseObject, error)
+	// Добавить пользователя в команду или перевести из другой
+	// (PUT /v2/teams/{name}/members/{user_id})
+	PutTeamMemberV2(ctx context.Context, request PutTeamMemberV2RequestObject) (PutTeamMemberV2ResponseObject, error)
+	// Список пользователей с фильтрами и курсорной пагинацией (по user_id)
+	// (GET /v2/users)
+	ListUsersV2(ctx context.Context, request ListUsersV2RequestObject) (ListUsersV2ResponseObject, error)
+	// Пользователь с текущей нагрузкой на ревью
+	// (GET /v2/users/{user_id})
+	GetUserV2(ctx context.Context, request GetUserV2RequestObject) (GetUserV2ResponseObject, error)
+	// PR, где пользователь назначен ревьювером (от новых к старым)
+	// (GET /v2/users/{user_id}/reviews)
+	GetUserReviewsV2(ctx context.Context, request GetUserReviewsV2RequestObject) (GetUserReviewsV2ResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// CreateToken operation middleware
+func (sh *strictHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	var request CreateTokenRequestObject
+
+	var body CreateTokenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateToken(ctx, request.(CreateTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateToken")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateTokenResponseObject); ok {
+		if err := validResponse.VisitCreateTokenResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListTokens operation middleware
+func (sh *strictHandler) ListTokens(w http.ResponseWriter, r *http.Request) {
+	var request ListTokensRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListTokens(ctx, request.(ListTokensRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListTokens")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListTokensResponseObject); ok {
+		if err := validResponse.VisitListTokensResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RevokeToken operation middleware
+func (sh *strictHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	var request RevokeTokenRequestObject
+
+	var body RevokeTokenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeToken(ctx, request.(RevokeTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeToken")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevokeTokenResponseObject); ok {
+		if err := validResponse.VisitRevokeTokenResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Health operation middleware
+func (sh *strictHandler) Health(w http.ResponseWriter, r *http.Request) {
+	var request HealthRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Health(ctx, request.(HealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Health")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(HealthResponseObject); ok {
+		if err := validResponse.VisitHealthResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Livez operation middleware
+func (sh *strictHandler) Livez(w http.ResponseWriter, r *http.Request) {
+	var request LivezRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Livez(ctx, request.(LivezRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Livez")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(LivezResponseObject); ok {
+		if err := validResponse.VisitLivezResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreatePullRequest operation middleware
+func (sh *strictHandler) CreatePullRequest(w http.ResponseWriter, r *http.Request, params CreatePullRequestParams) {
+	var request CreatePullRequestRequestObject
+
+	request.Params = params
+
+	var body CreatePullRequestJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreatePullRequest(ctx, request.(CreatePullRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreatePullRequest")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreatePullRequestResponseObject); ok {
+		if err := validResponse.VisitCreatePullRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPullRequest operation middleware
+func (sh *strictHandler) GetPullRequest(w http.ResponseWriter, r *http.Request, params GetPullRequestParams) {
+	var request GetPullRequestRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPullRequest(ctx, request.(GetPullRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPullRequest")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPullRequestResponseObject); ok {
+		if err := validResponse.VisitGetPullRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListPullRequests operation middleware
+func (sh *strictHandler) ListPullRequests(w http.ResponseWriter, r *http.Request, params ListPullRequestsParams) {
+	var request ListPullRequestsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListPullRequests(ctx, request.(ListPullRequestsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListPullRequests")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListPullRequestsResponseObject); ok {
+		if err := validResponse.VisitListPullRequestsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// MergePullRequest operation middleware
+func (sh *strictHandler) MergePullRequest(w http.ResponseWriter, r *http.Request, params MergePullRequestParams) {
+	var request MergePullRequestRequestObject
+
+	request.Params = params
+
+	var body MergePullRequestJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.MergePullRequest(ctx, request.(MergePullRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "MergePullRequest")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(MergePullRequestResponseObject); ok {
+		if err := validResponse.VisitMergePullRequestResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReassignReviewer operation middleware
+func (sh *strictHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request, params ReassignReviewerParams) {
+	var request ReassignReviewerRequestObject
+
+	request.Params = params
+
+	var body ReassignReviewerJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReassignReviewer(ctx, request.(ReassignReviewerRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReassignReviewer")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReassignReviewerResponseObject); ok {
+		if err := validResponse.VisitReassignReviewerResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Readyz operation middleware
+func (sh *strictHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	var request ReadyzRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Readyz(ctx, request.(ReadyzRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Readyz")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReadyzResponseObject); ok {
+		if err := validResponse.VisitReadyzResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AssignRole operation middleware
+func (sh *strictHandler) AssignRole(w http.ResponseWriter, r *http.Request, params AssignRoleParams) {
+	var request AssignRoleRequestObject
+
+	request.Params = params
+
+	var body AssignRoleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AssignRole(ctx, request.(AssignRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AssignRole")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AssignRoleResponseObject); ok {
+		if err := validResponse.VisitAssignRoleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListRoles operation middleware
+func (sh *strictHandler) ListRoles(w http.ResponseWriter, r *http.Request, params ListRolesParams) {
+	var request ListRolesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListRoles(ctx, request.(ListRolesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListRoles")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListRolesResponseObject); ok {
+		if err := validResponse.VisitListRolesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RevokeRole operation middleware
+func (sh *strictHandler) RevokeRole(w http.ResponseWriter, r *http.Request, params RevokeRoleParams) {
+	var request RevokeRoleRequestObject
+
+	request.Params = params
+
+	var body RevokeRoleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeRole(ctx, request.(RevokeRoleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeRole")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RevokeRoleResponseObject); ok {
+		if err := validResponse.VisitRevokeRoleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateTeam operation middleware
+func (sh *strictHandler) CreateTeam(w http.ResponseWriter, r *http.Request, params CreateTeamParams) {
+	var request CreateTeamRequestObject
+
+	request.Params = params
+
+	var body CreateTeamJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateTeam(ctx, request.(CreateTeamRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateTeam")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateTeamResponseObject); ok {
+		if err := validResponse.VisitCreateTeamResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetTeam operation middleware
+func (sh *strictHandler) GetTeam(w http.ResponseWriter, r *http.Request, params GetTeamParams) {
+	var request GetTeamRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTeam(ctx, request.(GetTeamRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTeam")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTeamResponseObject); ok {
+		if err := validResponse.VisitGetTeamResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUser operation middleware
+func (sh *strictHandler) GetUser(w http.ResponseWriter, r *http.Request, params GetUserParams) {
+	var request GetUserRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUser(ctx, request.(GetUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetUserResponseObject); ok {
+		if err := validResponse.VisitGetUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUserReviews operation middleware
+func (sh *strictHandler) GetUserReviews(w http.ResponseWriter, r *http.Request, params GetUserReviewsParams) {
+	var request GetUserReviewsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUserReviews(ctx, request.(GetUserReviewsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUserReviews")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetUserReviewsResponseObject); ok {
+		if err := validResponse.VisitGetUserReviewsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListUsers operation middleware
+func (sh *strictHandler) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams) {
+	var request ListUsersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListUsers(ctx, request.(ListUsersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListUsers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListUsersResponseObject); ok {
+		if err := validResponse.VisitListUsersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetUserIsActive operation middleware
+func (sh *strictHandler) SetUserIsActive(w http.ResponseWriter, r *http.Request, params SetUserIsActiveParams) {
+	var request SetUserIsActiveRequestObject
+
+	request.Params = params
+
+	var body SetUserIsActiveJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetUserIsActive(ctx, request.(SetUserIsActiveRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetUserIsActive")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetUserIsActiveResponseObject); ok {
+		if err := validResponse.VisitSetUserIsActiveResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListPullRequestsV2 operation middleware
+func (sh *strictHandler) ListPullRequestsV2(w http.ResponseWriter, r *http.Request, params ListPullRequestsV2Params) {
+	var request ListPullRequestsV2RequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListPullRequestsV2(ctx, request.(ListPullRequestsV2RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListPullRequestsV2")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListPullRequestsV2ResponseObject); ok {
+		if err := validResponse.VisitListPullRequestsV2Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreatePullRequestV2 operation middleware
+func (sh *strictHandler) CreatePullRequestV2(w http.ResponseWriter, r *http.Request, params CreatePullRequestV2Params) {
+	var request CreatePullRequestV2RequestObject
+
+	request.Params = params
+
+	var body CreatePullRequestV2JSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreatePullRequestV2(ctx, request.(CreatePullRequestV2RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreatePullRequestV2")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreatePullRequestV2ResponseObject); ok {
+		if err := validResponse.VisitCreatePullRequestV2Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPullRequestV2 operation middleware
+func (sh *strictHandler) GetPullRequestV2(w http.ResponseWriter, r *http.Request, id PullRequestIdPath, params GetPullRequestV2Params) {
+	var request GetPullRequestV2RequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPullRequestV2(ctx, request.(GetPullRequestV2RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPullRequestV2")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPullRequestV2ResponseObject); ok {
+		if err := validResponse.VisitGetPullRequestV2Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdatePullRequestV2 operation middleware
+func (sh *strictHandler) UpdatePullRequestV2(w http.ResponseWriter, r *http.Request, id PullRequestIdPath, params UpdatePullRequestV2Params) {
+	var request UpdatePullRequestV2RequestObject
+
+	request.Id = id
+	request.Params = params
+
+	var body UpdatePullRequestV2JSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdatePullRequestV2(ctx, request.(UpdatePullRequestV2RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdatePullRequestV2")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdatePullRequestV2ResponseObject); ok {
+		if err := validResponse.VisitUpdatePullRequestV2Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListReviewersV2 operation middleware
+func (sh *strictHandler) ListReviewersV2(w http.ResponseWriter, r *http.Request, id PullRequestIdPath, params ListReviewersV2Params) {
+	var request ListReviewersV2RequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListReviewersV2(ctx, request.(ListReviewersV2RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListReviewersV2")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListReviewersV2ResponseObject); ok {
+		if err := validResponse.VisitListReviewersV2Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemoveReviewerV2 operation middleware
+func (sh *strictHandler) RemoveReviewerV2(w http.ResponseWriter, r *http.Request, id PullRequestIdPath, userId UserIdPath, params RemoveReviewerV2Params) {
+	var request RemoveReviewerV2RequestObject
+
+	request.Id = id
+	request.UserId = userId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveReviewerV2(ctx, request.(RemoveReviewerV2RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveReviewerV2")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RemoveReviewerV2ResponseObject); ok {
+		if err := validResponse.VisitRemoveReviewerV2Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateTeamV2 operation middleware
+func (sh *strictHandler) CreateTeamV2(w http.ResponseWriter, r *http.Request, params CreateTeamV2Params) {
+	var request CreateTeamV2RequestObject
+
+	request.Params = params
+
+	var body CreateTeamV2JSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateTeamV2(ctx, request.(CreateTeamV2RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateTeamV2")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateTeamV2ResponseObject); ok {
+		if err := validResponse.VisitCreateTeamV2Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetTeamV2 operation middleware
+func (sh *strictHandler) GetTeamV2(w http.ResponseWriter, r *http.Request, name TeamNamePath, params GetTeamV2Params) {
+	var request GetTeamV2RequestObject
+
+	request.Name = name
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTeamV2(ctx, request.(GetTeamV2RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTeamV2")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTeamV2ResponseObject); ok {
+		if err := validResponse.VisitGetTeamV2Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RemoveTeamMemberV2 operation middleware
+func (sh *strictHandler) RemoveTeamMemberV2(w http.ResponseWriter, r *http.Request, name TeamNamePath, userId EntityID, params RemoveTeamMemberV2Params) {
+	var request RemoveTeamMemberV2RequestObject
+
+	request.Name = name
+	request.UserId = userId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RemoveTeamMemberV2(ctx, request.(RemoveTeamMemberV2RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RemoveTeamMemberV2")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RemoveTeamMemberV2ResponseObject); ok {
+		if err := validResponse.VisitRemoveTeamMemberV2Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetTeamMemberV2 operation middleware
+func (sh *strictHandler) GetTeamMemberV2(w http.ResponseWriter, r *http.Request, name TeamNamePath, userId UserIdPath, params GetTeamMemberV2Params) {
+	var request GetTeamMemberV2RequestObject
+
+	request.Name = name
+	request.UserId = userId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTeamMemberV2(ctx, request.(GetTeamMemberV2RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTeamMemberV2")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetTeamMemberV2ResponseObject); ok {
+		if err := validResponse.VisitGetTeamMemberV2Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateTeamMemberV2 operation middleware
+func (sh *strictHandler) UpdateTeamMemberV2(w http.ResponseWriter, r *http.Request, name TeamNamePath, userId UserIdPath, params UpdateTeamMemberV2Params) {
+	var request UpdateTeamMemberV2RequestObject
+
+	request.Name = name
+	request.UserId = userId
+	request.Params = params
+
+	var body UpdateTeamMemberV2JSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateTeamMemberV2(ctx, request.(UpdateTeamMemberV2RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateTeamMemberV2")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateTeamMemberV2ResponseObject); ok {
+		if err := validResponse.VisitUpdateTeamMemberV2Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutTeamMemberV2 operation middleware
+func (sh *strictHandler) PutTeamMemberV2(w http.ResponseWriter, r *http.Request, name TeamNamePath, userId UserIdPath, params PutTeamMemberV2Params) {
+	var request PutTeamMemberV2RequestObject
+
+	request.Name = name
+	request.UserId = userId
+	request.Params = params
+
+	var body PutTeamMemberV2JSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutTeamMemberV2(ctx, request.(PutTeamMemberV2RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutTeamMemberV2")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutTeamMemberV2ResponseObject); ok {
+		if err := validResponse.VisitPutTeamMemberV2Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListUsersV2 operation middleware
+func (sh *strictHandler) ListUsersV2(w http.ResponseWriter, r *http.Request, params ListUsersV2Params) {
+	var request ListUsersV2RequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListUsersV2(ctx, request.(ListUsersV2RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListUsersV2")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListUsersV2ResponseObject); ok {
+		if err := validResponse.VisitListUsersV2Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUserV2 operation middleware
+func (sh *strictHandler) GetUserV2(w http.ResponseWriter, r *http.Request, userId UserIdPath, params GetUserV2Params) {
+	var request GetUserV2RequestObject
+
+	request.UserId = userId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUserV2(ctx, request.(GetUserV2RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUserV2")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetUserV2ResponseObject); ok {
+		if err := validResponse.VisitGetUserV2Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetUserReviewsV2 operation middleware
+func (sh *strictHandler) GetUserReviewsV2(w http.ResponseWriter, r *http.Request, userId UserIdPath, params GetUserReviewsV2Params) {
+	var request GetUserReviewsV2RequestObject
+
+	request.UserId = userId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUserReviewsV2(ctx, request.(GetUserReviewsV2RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUserReviewsV2")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetUserReviewsV2ResponseObject); ok {
+		if err := validResponse.VisitGetUserReviewsV2Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Base64 encoded, gzipped, json marshaled Swagger object
+var swaggerSpec = []string{
+
+	"H4sIAAAAAAAC/+x973LbyJXvq3ThblWkFERRsp1k6Nrakm15wl2PzFByZhPTEWGyZWFNAhwAlK14VWVZ",
+	"mTi5mmvt7J26m0pV/s3c+52jEce0LMmv0HiF+yRbfboBdAMNEKQoWUr5g8sUCTS6T59/fc7vHDzTGna7",
+	"Y1vY8lyt9Exbx0YTO/BxccV4RP9vYrfhmB3PtC2tpJH/JH3/ub9NBv4e8p+Tvr/t78AXvev0756/7b/w",
+	"v/RfIPLOf04GiBySHvmeHJATcoTIgLwmR6RPjuHfgAw0XXMb67ht0Efhp0a708JaSatpV2qapmveZof+",
+	"6XqOaT3StrZ07Y7dMNhcElP7D3LAZoT8bXJCXpMD0iPH5JickO/ISWy20oPjj9nStY7hGG3scWLcdLDh",
+	"4eZtx27fNlsedhSP/xMZkO/Jsb/n7yHyHaUFXaL/W9JDDXb7goemyD45JG/9V/5LMvBfkD55639Bpzit",
+	"6ZpJx/msi51NTdcso03nxG9dXXPstjTnNdtpG55W0pqGh2c8s42V9OIzX7FT58129PPhMz8mfTTu9D17",
+	"rMl3Hdd2fgZDqgjeByY7Ia+B816SY3+XvEHkkG/yif8cWA6Rd+SEvPX3kIWfeqsNGJUxaJ8c+LvkwN/x",
+	"f0/65A0C9g0J4O+mLQuGyOQhXSs3cbtje9hqbP4LVi3gj4ySbHr7/gs6YdIrIPKX6G9E2ZkS+giR7+kG",
+	"BORn8sR+e8v+2geu3wdi/J70SN9/AaLgf86W5H9JjgManfgvyD69omZNkdekR0UExqGDHKJ6OHlvpoo7",
+	"LWMTN0vIc7q4Po3It6QfUDWYdiBlZN/fZeQOhNzfu04XQYXT3yHfkQE5qlnCtP//86/Q1fl5VL61+Enl",
+	"7sri0s1frP7L4i9Wq4v3lhdv6ewxh6SHyMDf9j8nJ+QgWASdN3CAvy092N+jK/O3/T02evGjmiUOX15a",
+	"rVTvflxdXF4uoGgbDigLUA4g+/4OUI/sh2wC8+35nyNY7neMRegM/N9SRUb3Aqbw2t8l+6TnvwKO+o6c",
+	"1CyyD9vkv+Rary+tdWXlDpqiM0f+DjkCRn3JGfAVmr+6Pn1d2C1/F117+hSBLEpbu+e/Yisu1KyAaZk2",
+	"j7hWYMgZypEi+7aNp3ew9chb10rz166phLG89onhNdaTfEwtRSBnCZECnginD9z9h5gR6KfsnU6pdgIa",
+	"5pCO0ve3yVsgc2SDapas1hllBDszgF3b9r/Q6bfHoCb6jCvm5lGlunjz7tKt8kr57tLq7YXyncVbBVT/",
+	"YR3BfQOYOQz+grEFnW3NiskL5U06YCgWoJNgkuSQDLI2ZG2G0XRUK1heW7ItPIn9uB4YarCZ++Qd6ZED",
+	"TrmBUqWAWF0pXs1YFJ2cYmUKa262TS9Nv/+V9Ng2+s8TehlRpcV3kykZSn5gFv93pI/mi0VENVJ4z0sy",
+	"4GIJC0izVy06IWnSTbxmdFueVrpW1LW2aZntblsrzYW7YloefoQdWM4n2HkU9xJUT2nDdWPbdPaYFTvX",
+	"Q8azvJXqQtdbt53sRxhwzarZHLLNleqS0capHgg1dwd8h7mqP4a93+ebt4cqVTTF5cvf8V9SD5P0wKWj",
+	"FiXgjrRdhf+GTbGKN0z8BA9ZssOvyrPoZc/wum72eC5cIw31Dw5e00ra/5iNfPNZ9qs7GwzKn7CCjXSH",
+	"9I/UvAIFDyhJe5GLgSrVFEp52Giv5iFXt9Wq4s+62PXKzYrhKdQQ+QNTJP4LMvB/QwZ0Y7lPA4+P9FzH",
+	"mZkrFueCKXXocOGMgM4O/qxrOriplagLkpdYi5ZnepvlWzBjtr3xLYlN+f9Sve9/QbkJMZNM1X7Pf+Hv",
+	"UDOAKtXraOHOHbWrVamCv/DWfwWq6Ei6mfQ1fRIMIC4D1kVZgEpX6iYcUd14GPGCvysR/6HReIytppr4",
+	"nBMmQP5gmmnK/huwOPSwyE8UzIyPNvkMZp7AEu652FloeOYGzhZp01014DKVDD207RY2rHDAMaSHn2a+",
+	"IK/BYvf4OWxPokx3Xr2jXTfQXROiSLmZtqUTW0GMvhNfgqxFT6MW6Wj0soqD18yn6QYPnFagib+Nuvym",
+	"01i4YIzVDjx5WHTDwW7HtlwMwY0bRpOrcvpXw7boqY9+NDqdlsnCLbP/5rKYS7g/zzTsOLbDbmnSB9xY",
+	"uLVaXfzZvcXlFU3XmtgzzJarle4/09ZM3GqCS9J+iB23UCxEe9jGrms8iiaImjZ2rR94qE39R+StY+Tg",
+	"R92W4SD8tONg16VE1DWny2yH4XnYsbStB+JQprVhtMwmcti6UMfYbNlGE9kOigI7JZSYTwmNMomtLZHM",
+	"mSxHSVXlRGdboAhmHFLhgB0/9F8ozrgaDYnY1lrLbOTZqslNrUeP7tSvDs5rEM6DP4/9XXomfgeONj0f",
+	"vUVTK4sLn6wu/mt5eWVZR5Wq+PGTxerH9FC/dHdldWF5ufzxEvtr9ebC0q3yrYWVRR3dW16s0iP6veXF",
+	"abrk27bz0Gw2sXU69rx9t3qjfOvW4pLEdHYHOzAEMl1k2R7qYKdteh5uojXbgZ1vGK0WdkqIKoECC2hN",
+	"dOv/ypRhSviAn2jfMYeDxbF4bEKItAz8F3SEE/IOjp0QlPBfaYkoVBV3XdxkVBmXkOpAjURVM3ooeow3",
+	"0RPDRUbLwUZzk6q7JnpieuvIQE1zbQ072PICSZ0kYWPhDhpegQjawN+OGyByHA9RSYJHuR1oabndtTWz",
+	"YWLLW27YHXxKQi4t37t9u3yzvLi0srp8825lUSKiZz/GFmgiYMx1YwNzTcSsHnJhCpNkxW9YyOUQRJsF",
+	"UyinHQPpghAff6yuLdnebbtrnZKdqCq4fffeksxBDnbtrtPAsPQ1eMpkZS4MGnEBoyfON8xj4Wv7xG6a",
+	"ayaTltxZEDmOEsRgkRQU0cOobSjcfR5k7PlfsgCFpquSMapF88tm4RpYa8XBDdtqmnS2tw2zdVqBV0TJ",
+	"1Hu1gR1qGCOeZeYzDHNNcgcztiCMjir3AaYCATvxNiFmSMPkMV1wSKOB3JnluuCexaIf5q9PS957Swv3",
+	"Vn56t1r+ZYyu9AHY8vhQodxPlIxfB+KeZEVyrCdlQ2fRw5MgOhNGSpmL+iU5lDn3008/nVmI1oFlxyTh",
+	"mkZLg7sXKuUVqgXp545DbbVnMqc1SCwZXt6oFj1bdEwHuyPdw/zrZ8kfbOfRqqnSDX9OpgdoLJuGBg4Z",
+	"7SDM9YYbcB6XPRY0r2oeDt6wH4+4XlDUQC3Tw234gC0avbyvUVNMb8FG2y09cUyPDtBxos9Gs21a2gPF",
+	"qPwLw3GMTfibbhAnRXR68+zHq1c+m5/58ZM7P//ZL11lkDE6vt2PRtGjaB3MXhe3OpqP/fDfcMMDj3gd",
+	"Nx5XsQtB2jibhKeQZ5rRZBrRaFWES9jBMTFos8u8wtW2K9Pb7j5sCcS2uvQEQe8IpTq5DSxYI1Dffqzp",
+	"2pphtugVD4aRJowQiZNSUeKW6dJM3RJn2QRfUgb7Fhz176lGhGRTEGW5Hsg/RLpe8F+idApieTcyCLz9",
+	"EzisQgZE0xPpo+BYVtJqtWUVb4aH8EnEDfRYjChQSpVqCc4qaG7+J9QgDMgRc6Hp7ToKj9vh8eUt5NF+",
+	"T1dZs+I3AD2kHE9IAZgZTTxT13uPJjhlmszN/wSSB+HfIoV+df9XtZpbqz0tFmdqtadzt2u1pz++/WDq",
+	"n0r3f5X49ofqi6f/6R+UVJb0/wT9MFnKwqHkr9nAEdsL50KNRq+lz+xkqOmaeDSEP6OzIf918ZPKyi80",
+	"XRMOipouzV8ORcQMrNLtFk+HqQeclByypqe4SOWllcXq0sKd1cVq9W5VqU0FBZV97Pb3OJNxLAM5uh7L",
+	"jx7Qr5G89FDxZ3kJt2l4BlhFpd9DXng2RE/BdkfXJzVU7HrGNSpFJkwowVQ8lqRMIr2lfh9zrXvIf8ld",
+	"mddAJ/8lxYWgqXoi6FOf1sNQ8ztKdNKDrCME3EL/JpHzvV6z6g/t5mad+ZKBR+//Fj4MmEYCRZDY9HSS",
+	"BnGtNKwI6YM+AveWozUQoCL65IhmResBges6qtOx6f9U/uj/XOXQj6Fuqus1q87TmvQHlZWsFwqF6ZAQ",
+	"cnq7R3MhzN8OZlFC9a5lftbFMDYznXRod9PyjKcwMYfZvluGZ/DZ3LCbm8vmr3FdRbEY4zAW4KTKYjhd",
+	"+yk2Wt56FXdsR+EdNKjrkOEcZIuN6HhsKZ6dtPsQANF0zbK9VfY5v/Hnc01f5HL4OHmRSvcj73NVjyu7",
+	"bhc3U/xyo2OuesFPWdQLXfvAhVQw/X+JKX7SF1xkAC6ICX+44jtywD72U5EL+RxRTRcWoqLBEn5Cswbp",
+	"rLNmtFysD0nJ7stRpr4MXATkFHc4uNJCU1wK/W1kdVutaeGOIHIQ+XC9WPIMUI/ydvFxpTNC1q7RRX+C",
+	"A5+XTsGg/rDsRkdHgzBtMkpKRtqNcATVLoTZcIG571bAhnN3QmVyhdy1gn9d13xk4eZqkOhXmGZuNwKU",
+	"QsSjLBgORmff/8J/xbUkdRCnioXC/LRokYcerCJ0herqEJmZ/zzIECELXnJF5D9h0tQEwkXXE8gnDojz",
+	"d+AX6un6uxSO5n/Osvn5ZtDptlqrPNSbtjDpmtTjd6TVcsMlRL6KT0T1WF3GtwSKWMEhSt6MuKzCbb3M",
+	"aQIWdSgd8guo8NgkT2UQYegixFOEvJCOM9Kk4pNwhj15eV1pvLPF4/JzmooqVcy4Ly3cMeJW0Jl2WkYD",
+	"N1cfbmbqOYYLDmD0knbrDTWpHUeTn6ReGpWmXKISN6oC3Bvc4j6gHV+lQLrRVIpuYymPd+QkGAQQqm+m",
+	"hymysYSTMbVC63OiDz9uRSn04XIsoYZEUGNgLtXWU9cW7txR2tAALHfHVBnRPMIn2de8RnGobGXr5GDW",
+	"VcaLbWwNmfyFlCZxfjnkym7hBVAawXJHcFhD9wtB6HAPAtpR2UcvCDrAdS1sNBEZRD5tn0W2o8tozJwF",
+	"luN+qGO3pFCReGU4uDocPbqPKQnZWG5pJHsw8TS6q6WD3pJfZ8T2b5hIsMHTJpRuxIMNGGUqikdnPHlD",
+	"qNyKPZvlNlQoOX1iEwtTKMopBoe5SZ6OMk9DQw6h4aV6OIm0afMHDhNseWURRlFJ9JHlQw8RaMPuEZMU",
+	"6WIVjqYLU82mwL1Ok+cXJ0uHSSwq91LSBdTjHDqM/5S8pH4ejW6oVRQEPvLzvRTLydJOfNzU2YytJcQk",
+	"ZO5EY5ZCoEjO5ByGsEuWhGd5djKj5XX7BMYS9UU2k9F13RKSoq3W3TXAaGbtL71J29Lj1LA72OKn4NWG",
+	"3bW8lCKEt1B/0+clVCeqCIKOguCdOsX3RSLYogqzHGnKshyRfMk5J6n0QKZTukx2OZMMo11AcNVepu7S",
+	"ZTkK0UXkVxUSOYZoCzZyGn1Ovy1594MGAXCj65je5jK9nT3lITYc7FBsS3IjFiplIVhN0zIbT1YLhUI9",
+	"TKP886crDA/yHMj8HDh9ALkUdjJYXr4bBojFvFMf1Rc48AgUZQndgImgWrdYvNIA9QYfcb1QswBqzrM1",
+	"wysraQzaf05hvP42e7IAT9lGNIu6uri0cOPO4q1/pBHfAgIUpFtCdZrOYJkwsk+HQR8vrtBMTwQwgV9r",
+	"1iz9atZowlFhFrZ41sVe2WWlFTRN5Yh3oNlOdMiaZVHPf4cg5b87PBpC00xwWIgm4H+pi7XqNBI+a7Zp",
+	"FkhHs/gp/+Bg17MdDDOhEZhZoN7sDwtIBAj6u2h2Yx6B6PTJtyBPL1gJMwVDUEwpw6royH9JCYuCcsqo",
+	"bJcLFwMXUDV3BNnd30GGl1b1bswVahbF8rW8dR3NtswN/GuYodHc/DVMsI09x2y4EN4n33KleuzvsrSw",
+	"onZ4Cvarcu/GnfLN1crCyk+Xp4EjQn74nM87VvlMl4eAbShPTFVvLNycLiFyRGcalTsLeQUEUAsEmuU1",
+	"nPsgZ02nRsF3R1B4G5YwMCwHCyQrCqlr1lQ9PP1BapYmUw/8HemRkOsMD4b16SAHKzD1Pt0DSJaSN9K9",
+	"pH9drAW+WryCwuw/K9YFJQG2HUQr0nrrntdhMDrTWrMVCvgreASF7vc5PXjleD/aKtW6aT4fTXnYMizP",
+	"nS5J8/V39RSzSAYS6Vn+oWYpCfuGIRyZNX4ewJ5h7AHH8tNkN/0rBX5D57+PAgzQEZTX7vgvYkhLCkSn",
+	"bKaEwcn5rh5kt/znUeZqwJP1EQJZiYkP02KkB0wZny5jUdAFA/JaWbBd/9eZu86jmfKtenrxfZ2HyerT",
+	"BUT+K94jQVfhTLmAU1Obsg9gh4Ul6ihI3smcyIZX14SkbhFvd1As1KyaJeowfie1S/XZjfk6mqL/gS52",
+	"Z59Rz3GLJuLpd1TdzgTRxNlnZnOrDnxx4D8vTDNO+xY0W4+1j2BtILhC8V9w8wVqUbQnAI3mrSzIgP0e",
+	"qs3nAaaFDHQUx+2TAaLY5gKqVm7OxBVnzdqYQ4G1YmzMsJVoY55JzVHQecHfpTvacTDFoTbZogIN/ZKV",
+	"hitgHjDb+i1+n2lbgGpY7lou9upoag4FwS66ADRfnP8xtfGofse0Hpe4US4UCvABX0cObv1jTXO7jQZ2",
+	"XduZ4ZDpmlZnqsczvRbDXKMgWomi0AZaxs6G2cBoagW7Hlox3Mc6um20WvTB16h7xsfTStpcoVgoAlK1",
+	"gy2jY2ol7UqhWLiiAfZsHXwY0eox00q/7diuN2I2Pr1TQEIp/y/45kjsQkAV8j4iX5KvUNDQggz4/bwK",
+	"ZvmnCzPz135U0PSoZKfc1Eq8wc0Kz9pzvqVokhFB2RwbDMV1P54vCiWTDTNCo0I2I0LJOhCVlODYIwQf",
+	"xGdmZGTJ96A+jqEfA/J/A2rliNkA9LGNAlgotOhhzDhgbRN0RFcyfV3q2PKWd0Wh3/nb/jYo45dUbrLw",
+	"z7mjH+cDO4457jJkWOHBb8WLReNFkPPFuYmV1IkAmSGYewkCTpd5tVg819K+PvDWPj+dMysBZAzxXmlW",
+	"SOBemPdc2nRCOs9KNRNw05XhNyWLruBA1m23DWeTScpuCHlhdXDx4xdbEPAWHGGNRyDJcHZ7QEcT9WCL",
+	"R8QeYY8HOQRtQ8NlKyyIlWCgyW1cFJnLZB9w3uRDjlycwRzR97w9fyPvwP7TtlLyxjDkNFdNUjsX8mbE",
+	"TWOhPNF4yftWhd9PbSWiMGOiwGFcIyBGLgXE+I+uxgHjOcOa4ym/CfOuEMcdogAlfhUUYDbTCSXr583c",
+	"9M6r56ii4wVaUp2iLGl/jmg5phrEG7wTJPzxlKNm6R8sMpGqFxkE9Sx1ogRyVREKIl3+b5lTI3n21MeU",
+	"onha6f4DiXJf8xNLGMFhARg0xRPX/JB3xM0kda9O2CeRkJwIjJRmW6AejJZhVOivY9FO0E0BTonCe3MX",
+	"CJ4pVf8PBDSBUDSA/04cCgC8r1nPAF5owykaFiIJgcugbVcasZMhQvkcExz6AqCq6gghYjnk/p8p2ZHo",
+	"ktlYq8etB0zjjmVjBFyb1p3TFFA23jBpXoklK2kLzSZyseE01mU+GMEoSdi6vBlgBepnrFtPm3AdFXF3",
+	"dscFYVcZMk+FLr7POt10r2gPxFmdfvMj6CKDdeUvG1bBPhXKoVJlipED1k9Tsj6W1T9PM/wfQfe0WRlR",
+	"n7TK/i6b3UenLbqPyuOicrxKFZnNsJUGfmq6njvJenC6pTs8dseOpWJeEBY2P5/Db1I1HUmcC4LCBWod",
+	"aPO0Qdikjoc3eNqYdtGU87+83Qkt7ZxX4+1ZzWqsKlRogSdYEoHbXYU94Sb7Ec5lSz7GXqYhSe16F2vo",
+	"pII2nrbZ1XAzJrQVZTbsNA7JuCpPqGzQaFhzZq44M391ZW6+VCyWisVfZmjFuQugFXkl4nFQpsfcmhN/",
+	"j4dNoVxibEV5pXg17eJI/MTmJZdAuVaqSS0a1xZAVH8nlH16DyTT0tNVO/llPBbzGSbkNDQjjTiqvxjr",
+	"DZpDNGMNWXPdEetomuseoRderuuFxq45rk/20c9/04qd/5ZEI97c94zyFKF7cZ5lCM3sJ6Bc5QKE+5dA",
+	"0T4YR9MCCkqlZf8mQplYS9v3Ej5Phsb7iTLyqCOF+G4ClYqjZ+FDyOhvI1BnvBstz0OSAW2n5mJvhj4C",
+	"uhMdC3llgHPxAgPWl+8waEL73N8lR0xlhts+nV9BAuBmlDM1SNMkj9R5vBfRcxnz9J3O8OOepcc+Fg85",
+	"1p5doHcCPlxUahrTLFeulq79aHKahRcqncXRluwnvbcBCiuj/m6PumpvTNeuzuU4+Claz03yzPgXjud4",
+	"IXiBFKNxyPcF6uMPAGLyDmKVzDM85rkxqTvm3gjqL0AajqIBo1pNJjOXQQOyrhhdx+GXCqT/q3zIpuJh",
+	"t5qrERx9w2h1QWeIX/M2zhladUvXWviR0diMPe+bwHDBcymgMACHkz48We7ELz1d/CnHDPJr9nhLLbkm",
+	"DrIswupDo88gLP5ObEkMJwY2/ATUy5FiYTHIfXx1Kj6Me0qJJ/D5XY/1QmVBHgYQDjHB0nqSLQIVfa7O",
+	"pbOVxGTnEKO+8MaY4rq610aKIY/jxMeKXOkjt/K/u0CqXlfHThhvxTtrxN8SRPocsPR3bYaDjkvpJTEK",
+	"Mz1K4JlXOVJfLaZ+/8SeQV5Tw8naou7xNF5QFRL5QqHyVQSxw4uiIHbDsCzbQ4FRRbbFuo404RBHSWHZ",
+	"Nw2raQYFffK86BHngKcVd8i7oAX5IQ/JD1hMmTcmTJ1arK9cNDvLRqx+CjlRmThqBPNBpgXlzsFEvQUu",
+	"j7GJ/iVz0771d8nb3DVNGYuQeuWJbfs4dpR3NQ+UBvJs5K2bLqf0RBvYj9YlPjQjCVH39y6Ou5mcIk89",
+	"HIJBpQ0f++BgppXz81KqoJswu4wlJ3h79/jbTTJcUlYNkoojqLKfT2tmoq5oTcMzHhqsxErqiFos/ERP",
+	"BxyIPQ1Nx9sM3aeW4UFThY8EnPJH8W6rc4X52NhCExbeQm1EfAPv/6ZsIw3FSvF3tvEv/N9RBSxbmZtG",
+	"Yx3P3LQtz7Fbw7oZ69q14pVxqe+ud72m/cRKUj/sNgvXeOyFFU8s0VoH3WUl4kV95yZHwP8HJYR7oNKC",
+	"JrE9RZfACNQBVA0bK8o1Z+y43QOJ26e/AzCsr+qJPtJGZMFU/jcHoUHtnQhWGTCXmWcoxdIVKJ38knyl",
+	"h20PoZCDVma91QGiHQThSJ/deMCURypciJeicbwQdHGYVZ05E5km8QWcfaR0nGB1b3hJRlDXBRvi77Gm",
+	"twGFEezOgHwLWulNEm3PjF2VNVR4fxgZ1hRC6Awi1V4Lr4WSzqH5HdV4P4lzRVBKjToyXxgS3+zeBUCQ",
+	"Q6iFF/R8IYHImTJgF3D52ofjM+iHw1iN3tkCO6N3ypw7kuSP2fAR0puoC/OnhOsS7s2UVJoT1lyKKqrK",
+	"uslsRTppKDqe3ZJQDgpUa/DkUCX5O+DEv5Ze253xrrDsl4Olm4MHZyy8qcj9v7KF6tByHPJGe3CSCbtH",
+	"8hgXClYxngyk4+/TO1ZCXI28ydz7fCD7D8bh7I1DJro+NA9yLcgH43AJjMPXpMdcL1ErpupA/oKmiRqM",
+	"eBHBuOYiaPMwOhAbuhi9TwUStv26L3X8YTMWlMac2ISnpC20zAaGNE3WTfPyTTfshzBZUUN1jE2qWtz8",
+	"OiloR30WyOWg8dTFoUpuskgdtYb7YlL37d7YyLlTAn/lt2QIr4MLaHGG8N84Qd4LFFiKiO0AEmYHyhuZ",
+	"CRmEVflTcvPzWXgjC+uuGb4kUak5w15GXG9RPpH01siY37F0lvxO5HPH575vgQ4csZHEWcm1NA/7PxkC",
+	"KxlP/QC1HfH0mQ28zSudKeLFGj6NLF/QrmtU+RJfT33u0hU0IUsIiaJr3pVcJ5SkQOWWHFU3u1ToejJp",
+	"9UGK8tFpdBh7qmcf9Mo5ZKaXvGHDfgd5pNf8jEC/EnJOgsjdY63zZJFjSKTRBY/d5561/IlNyi8tCPss",
+	"sRcPxg5ZRL31lVBqIUJUqf4gfKGdijFPJ55DtcDt6E2/WbUfP/B3J9QxdChmejpTqsYoHGGDjCNLo9Vm",
+	"0DtYT8XR7qGMWHHwmvn0cgih1BFV+3Tzn+ca8z/fbGyW3bJ172m5VdTCHqX385njYg5zrHaKH4xkkXPX",
+	"NqSfYi57zYMUnE5dZXo1hDh20PpNXRkhhNWzRVpoRjpK7GqZ2crw1ktS7yDIAwdppJuY99hfPrUJdla3",
+	"6zOAYCq8er7+s3fgM8Xyz0Lo40ueTHqTahv/rkv1/zISXPICwMy+CUEvJ2Sf+zj+byhIhnxHi9YPo9bQ",
+	"QYuU9KSoSrHFu3pmJnBF3NnP5z+Utn4obT0Ph+6cCkPza62LW5upp6TfE22MxpDeD42MPjQymoR0nmG7",
+	"IF27I7wjJuv6OxJK4uyjFmHyL/uGm7a11jIb3kR9CGU3nfNsmaPsHJ7qaMhtcsZRVKcP1k+Iqc8/OH6K",
+	"UNrXQkg5vTtNuuEZzRWMbi83K4bHdqoDe5bgCPb6rslar3M6Qyeq0cc0Vsk3tae+xTrv29rPF5w23ABc",
+	"vKL6EZT7BapIChB18PYPsj+s/P1k+npUg0TFHGB5rDaL11yFxb20hOMAHvM9K79QNN/Mq/9npbe8pmOG",
+	"g6vGOW8mlYx+cayH9J5cNVhTMsTvA7FwCnPypyScmNWYxBaVaVZycM/sMx5I22Kh2Bb2cJKVqrhtb+CA",
+	"5OfFSyzLmZvzTmmizphPxTcj52BX4bUrNOp4Og1+5r72BdDffwvrqJOlotcFavLC0VC4ovepjFs7Grzt",
+	"Jx2+H6Fv33PgIC8w7UdyRP22Y1iPLyKY9pTotkyQqiRx53gwvhjn3PcLFY29QCvrsDumVAUI0Qvn11wK",
+	"KOYpHJu4zI0GcowxxixXaGleTK781Z7OWwkcsroeCNpAzwH6Dqdd8jZy1OF9uv4O6Ii3QeDnWGjoMXW1",
+	"+BG6t7xYXS0vrd5bXmSvTwzaFIXvjQ1abfSFHFT47kB4z5+mK12w6KXcKp6PLfcPqS+zy8hyhdZC614N",
+	"KgHpW81UhYDn0Th5kl7dVQVPfCNzX7i74HWdpq7p79zf+kYSgqQQp7tQgTzrmTo9ncsviqaW3z+9pQ/h",
+	"rEulpeNSIW/m9QxoYNSP5U3sLv4KzatKVjhb6y2eJIcFSk/HfBcDa3Qm4KJYZPQ88EH5BfHSQncuV5T0",
+	"D2Fwkzs/CQzN2Mag01U1Yvka+sSEL6o+hsI59sJn3vKqF3lvwoGFHebfwUuMKYJAZzcMou5Y+xCP7QfO",
+	"3zv4ko79yn+VGC3FX0q6aZWud8kVSBQMEPF0o1SSscUzbXphdEG2RTt32T97p/ICaIuvIBjQC6F36dX2",
+	"+wr5fStLa5CWYZItuBnpR0UOTs/IjwCWbwwx/VA0MKZsThaZPy7o7EKB4wNGlYMYaWcjeueYDHsBs3jD",
+	"zlAXpmzy1EVWyaPSBAsRkyzEM3zuMFbiFYhnzlGXpARxMlV/CZzsRSr6q1Tfa4Gf3C/ymfYQGw524E25",
+	"pfsPth6EtzwL4o3MqG/p4RdsLOELKSknfM/7QArflNm7Y4VvbhiNx92O+A3MRfib9d4Rvlhk7/LderD1",
+	"3wMAdMKfY+bJAAA=",
+}
+
+// GetSwagger returns the content of the embedded swagger specification file
+// or error if failed to decode
+func decodeSpec() ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(strings.Join(swaggerSpec, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error base64 decoding spec: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(zipped))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(zr)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing spec: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+var rawSpec = decodeSpecCached()
+
+// a naive cached of a decoded swagger spec
+func decodeSpecCached() func() ([]byte, error) {
+	data, err := decodeSpec()
+	return func() ([]byte, error) {
+		return data, err
+	}
+}
+
+// Constructs a synthetic filesystem for resolving external references when loading openapi specifications.
+func PathToRawSpec(pathToFile string) map[string]func() ([]byte, error) {
+	res := make(map[string]func() ([]byte, error))
+	if len(pathToFile) > 0 {
+		res[pathToFile] = rawSpec
+	}
+
+	return res
+}
+
+// GetSwagger returns the Swagger specification corresponding to the generated code
+// in this file. The external references of Swagger specification are resolved.
+// The logic of resolving external references is tightly connected to "import-mapping" feature.
+// Externally referenced files must be embedded in the corresponding golang packages.
+// Urls can be supported but this task was out of the scope.
+func GetSwagger() (swagger *openapi3.T, err error) {
+	resolvePath := PathToRawSpec("")
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, url *url.URL) ([]byte, error) {
+		pathToFile := url.String()
+		pathToFile = path.Clean(pathToFile)
+		getSpec, ok := resolvePath[pathToFile]
+		if !ok {
+			err1 := fmt.Errorf("path not found: %s", pathToFile)
+			return nil, err1
+		}
+		return getSpec()
+	}
+	var specData []byte
+	specData, err = rawSpec()
+	if err != nil {
+		return
+	}
+	swagger, err = loader.LoadFromData(specData)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,6 @@
+// Package api — типы запросов и ответов и строгий серверный интерфейс,
+// сгенерированные из openapi.yml. Файл api.gen.go не редактируется вручную:
+// после изменения спецификации выполните `make openapi` (или go generate).
+package api
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.5.1 -config oapi-codegen.yml ../../openapi.yml
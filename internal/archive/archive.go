@@ -0,0 +1,323 @@
+// Package archive реализует переносимый формат полной выгрузки данных сервиса.
+//
+// Архив — JSON Lines. Первая строка — заголовок с названием и версией формата и описанием
+// полей каждого вида записей, последняя — итоговая строка с количеством записей по видам
+// и SHA-256 всех предшествующих строк. Между ними идут записи team, user и pull_request
+// (именно в этом порядке, чтобы при восстановлении ссылки указывали на уже загруженные данные).
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/trainee/review-service/internal/model"
+)
+
+const (
+	// FormatName идентифицирует формат в заголовке архива.
+	FormatName = "review-service-archive"
+	// Version — текущая версия формата. Reader принимает архивы версий не выше текущей.
+	Version = 1
+)
+
+// Виды записей архива.
+const (
+	KindHeader      = "header"
+	KindTeam        = "team"
+	KindUser        = "user"
+	KindPullRequest = "pull_request"
+	KindFooter      = "footer"
+)
+
+// ErrInvalid оборачивает все ошибки разбора и проверки целостности архива.
+var ErrInvalid = errors.New("archive: invalid archive")
+
+// maxLineSize ограничивает длину одной строки архива.
+const maxLineSize = 1 << 20
+
+// Header — первая строка архива.
+type Header struct {
+	Kind      string              `json:"kind"`
+	Format    string              `json:"format"`
+	Version   int                 `json:"version"`
+	CreatedAt time.Time           `json:"created_at"`
+	Records   map[string][]string `json:"records"`
+}
+
+// Footer — последняя строка архива.
+type Footer struct {
+	Kind   string         `json:"kind"`
+	Counts map[string]int `json:"counts"`
+	SHA256 string         `json:"sha256"`
+}
+
+// Record — запись с данными. Значимые поля зависят от Kind (см. recordFields).
+type Record struct {
+	Kind string `json:"kind"`
+	Line int    `json:"-"`
+
+	TeamName string `json:"team_name,omitempty"`
+	UserID   string `json:"user_id,omitempty"`
+	Username string `json:"username,omitempty"`
+	IsActive *bool  `json:"is_active,omitempty"`
+
+	PullRequestID     string         `json:"pull_request_id,omitempty"`
+	PullRequestName   string         `json:"pull_request_name,omitempty"`
+	AuthorID          string         `json:"author_id,omitempty"`
+	Status            model.PRStatus `json:"status,omitempty"`
+	AssignedReviewers []string       `json:"assigned_reviewers,omitempty"`
+	CreatedAt         *time.Time     `json:"created_at,omitempty"`
+	MergedAt          *time.Time     `json:"merged_at,omitempty"`
+}
+
+// recordFields описывает поля записей; попадает в заголовок, чтобы архив был самоописываемым.
+var recordFields = map[string][]string{
+	KindTeam:        {"team_name"},
+	KindUser:        {"user_id", "username", "team_name", "is_active"},
+	KindPullRequest: {"pull_request_id", "pull_request_name", "author_id", "status", "assigned_reviewers", "created_at", "merged_at"},
+}
+
+// User возвращает пользователя из записи вида user.
+func (r Record) User() model.User {
+	u := model.User{UserID: r.UserID, Username: r.Username, TeamName: r.TeamName}
+	if r.IsActive != nil {
+		u.IsActive = *r.IsActive
+	}
+	return u
+}
+
+// PullRequest возвращает PR из записи вида pull_request.
+func (r Record) PullRequest() model.PullRequest {
+	reviewers := r.AssignedReviewers
+	if reviewers == nil {
+		reviewers = []string{}
+	}
+	return model.PullRequest{
+		ID:                r.PullRequestID,
+		Name:              r.PullRequestName,
+		AuthorID:          r.AuthorID,
+		Status:            r.Status,
+		AssignedReviewers: reviewers,
+		CreatedAt:         r.CreatedAt,
+		MergedAt:          r.MergedAt,
+	}
+}
+
+// --- Writer ---
+
+// Writer последовательно пишет архив. После записи данных нужно вызвать Close,
+// иначе архив будет считаться обрезанным.
+type Writer struct {
+	w      io.Writer
+	hash   hash.Hash
+	counts map[string]int
+}
+
+// NewWriter пишет заголовок архива в w.
+func NewWriter(w io.Writer, createdAt time.Time) (*Writer, error) {
+	aw := &Writer{w: w, hash: sha256.New(), counts: make(map[string]int)}
+	err := aw.writeLine(Header{
+		Kind:      KindHeader,
+		Format:    FormatName,
+		Version:   Version,
+		CreatedAt: createdAt.UTC(),
+		Records:   recordFields,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return aw, nil
+}
+
+func (aw *Writer) writeLine(v any) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	aw.hash.Write(line)
+	_, err = aw.w.Write(line)
+	return err
+}
+
+func (aw *Writer) writeRecord(rec Record) error {
+	aw.counts[rec.Kind]++
+	return aw.writeLine(rec)
+}
+
+func (aw *Writer) WriteTeam(teamName string) error {
+	return aw.writeRecord(Record{Kind: KindTeam, TeamName: teamName})
+}
+
+func (aw *Writer) WriteUser(u model.User) error {
+	active := u.IsActive
+	return aw.writeRecord(Record{Kind: KindUser, UserID: u.UserID, Username: u.Username, TeamName: u.TeamName, IsActive: &active})
+}
+
+func (aw *Writer) WritePullRequest(pr model.PullRequest) error {
+	reviewers := pr.AssignedReviewers
+	if reviewers == nil {
+		reviewers = []string{}
+	}
+	return aw.writeRecord(Record{
+		Kind:              KindPullRequest,
+		PullRequestID:     pr.ID,
+		PullRequestName:   pr.Name,
+		AuthorID:          pr.AuthorID,
+		Status:            pr.Status,
+		AssignedReviewers: reviewers,
+		CreatedAt:         pr.CreatedAt,
+		MergedAt:          pr.MergedAt,
+	})
+}
+
+// Close дописывает итоговую строку с количеством записей и контрольной суммой.
+func (aw *Writer) Close() error {
+	counts := map[string]int{KindTeam: 0, KindUser: 0, KindPullRequest: 0}
+	for kind, n := range aw.counts {
+		counts[kind] = n
+	}
+	line, err := json.Marshal(Footer{Kind: KindFooter, Counts: counts, SHA256: hex.EncodeToString(aw.hash.Sum(nil))})
+	if err != nil {
+		return err
+	}
+	_, err = aw.w.Write(append(line, '\n'))
+	return err
+}
+
+// --- Reader ---
+
+// Reader читает архив и проверяет его целостность: версию формата, порядок видов записей,
+// количество записей и контрольную сумму. Ошибки целостности оборачивают ErrInvalid.
+type Reader struct {
+	scanner *bufio.Scanner
+	header  Header
+	hash    hash.Hash
+	counts  map[string]int
+	line    int
+	lastKnd int
+	done    bool
+}
+
+// kindOrder — допустимый порядок записей в архиве.
+var kindOrder = map[string]int{KindTeam: 1, KindUser: 2, KindPullRequest: 3}
+
+// NewReader читает и проверяет заголовок архива.
+func NewReader(r io.Reader) (*Reader, error) {
+	ar := &Reader{scanner: bufio.NewScanner(r), hash: sha256.New(), counts: make(map[string]int)}
+	ar.scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	raw, err := ar.nextLine()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ar.invalid("empty archive")
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(raw, &ar.header); err != nil || ar.header.Kind != KindHeader {
+		return nil, ar.invalid("first line must be an archive header")
+	}
+	if ar.header.Format != FormatName {
+		return nil, ar.invalid(fmt.Sprintf("unknown format %q", ar.header.Format))
+	}
+	if ar.header.Version < 1 || ar.header.Version > Version {
+		return nil, ar.invalid(fmt.Sprintf("unsupported version %d (supported up to %d)", ar.header.Version, Version))
+	}
+	ar.hash.Write(append(raw, '\n'))
+	return ar, nil
+}
+
+// Header возвращает заголовок архива.
+func (ar *Reader) Header() Header {
+	return ar.header
+}
+
+// Next возвращает очередную запись. После итоговой строки, прошедшей проверку, возвращает io.EOF.
+func (ar *Reader) Next() (*Record, error) {
+	if ar.done {
+		return nil, io.EOF
+	}
+	raw, err := ar.nextLine()
+	if errors.Is(err, io.EOF) {
+		return nil, ar.invalid("archive is truncated: footer is missing")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var probe struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(raw, &probe); err != nil {
+		return nil, ar.invalid("malformed JSON")
+	}
+	if probe.Kind == KindFooter {
+		return nil, ar.verifyFooter(raw)
+	}
+
+	order, ok := kindOrder[probe.Kind]
+	if !ok {
+		return nil, ar.invalid(fmt.Sprintf("unknown record kind %q", probe.Kind))
+	}
+	if order < ar.lastKnd {
+		return nil, ar.invalid(fmt.Sprintf("%s record after records of a later kind", probe.Kind))
+	}
+	ar.lastKnd = order
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	var rec Record
+	if err := dec.Decode(&rec); err != nil {
+		return nil, ar.invalid(err.Error())
+	}
+	rec.Line = ar.line
+	ar.hash.Write(append(raw, '\n'))
+	ar.counts[rec.Kind]++
+	return &rec, nil
+}
+
+func (ar *Reader) verifyFooter(raw []byte) error {
+	var footer Footer
+	if err := json.Unmarshal(raw, &footer); err != nil {
+		return ar.invalid("malformed footer")
+	}
+	for _, kind := range []string{KindTeam, KindUser, KindPullRequest} {
+		if footer.Counts[kind] != ar.counts[kind] {
+			return ar.invalid(fmt.Sprintf("%s count mismatch: footer %d, read %d", kind, footer.Counts[kind], ar.counts[kind]))
+		}
+	}
+	if sum := hex.EncodeToString(ar.hash.Sum(nil)); footer.SHA256 != sum {
+		return ar.invalid("checksum mismatch")
+	}
+	if _, err := ar.nextLine(); !errors.Is(err, io.EOF) {
+		return ar.invalid("unexpected data after footer")
+	}
+	ar.done = true
+	return io.EOF
+}
+
+// nextLine возвращает следующую непустую строку.
+func (ar *Reader) nextLine() ([]byte, error) {
+	for ar.scanner.Scan() {
+		ar.line++
+		if raw := bytes.TrimSpace(ar.scanner.Bytes()); len(raw) > 0 {
+			return raw, nil
+		}
+	}
+	if err := ar.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func (ar *Reader) invalid(msg string) error {
+	return fmt.Errorf("%w: line %d: %s", ErrInvalid, ar.line, msg)
+}
@@ -0,0 +1,101 @@
+package archive
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trainee/review-service/internal/model"
+)
+
+func writeArchive(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	created := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	merged := created.Add(time.Hour)
+
+	w, err := NewWriter(&buf, created)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteTeam("payments"))
+	require.NoError(t, w.WriteUser(model.User{UserID: "u1", Username: "alice", TeamName: "payments", IsActive: false}))
+	require.NoError(t, w.WritePullRequest(model.PullRequest{
+		ID: "pr-1", Name: "feat", AuthorID: "u1", Status: model.PRMerged,
+		AssignedReviewers: []string{"u2"}, CreatedAt: &created, MergedAt: &merged,
+	}))
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func readAll(data string) ([]*Record, error) {
+	r, err := NewReader(strings.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	var records []*Record
+	for {
+		rec, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return records, err
+		}
+		records = append(records, rec)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	data := writeArchive(t)
+
+	r, err := NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, Version, r.Header().Version)
+	require.Contains(t, r.Header().Records, KindPullRequest)
+
+	records, err := readAll(string(data))
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	user := records[1].User()
+	require.Equal(t, "alice", user.Username)
+	require.False(t, user.IsActive)
+
+	pr := records[2].PullRequest()
+	require.Equal(t, 4, records[2].Line)
+	require.Equal(t, model.PRMerged, pr.Status)
+	require.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+	require.Equal(t, time.Hour, pr.MergedAt.Sub(*pr.CreatedAt))
+}
+
+func TestReaderRejectsInvalidArchives(t *testing.T) {
+	data := string(writeArchive(t))
+	lines := strings.Split(strings.TrimSpace(data), "\n")
+	withLines := func(ls ...string) string { return strings.Join(ls, "\n") + "\n" }
+
+	cases := map[string]struct {
+		data    string
+		message string
+	}{
+		"empty":          {"", "empty archive"},
+		"no header":      {withLines(lines[1:]...), "header"},
+		"future version": {strings.Replace(data, `"version":1`, `"version":99`, 1), "unsupported version 99"},
+		"unknown format": {strings.Replace(data, FormatName, "pg_dump", 1), `unknown format "pg_dump"`},
+		"unknown kind":   {withLines(lines[0], `{"kind":"comment"}`), `unknown record kind "comment"`},
+		"unknown field":  {withLines(lines[0], `{"kind":"team","team_name":"a","color":"red"}`), "unknown field"},
+		"wrong order":    {withLines(lines[0], lines[2], lines[1]), "team record after"},
+		"count mismatch": {withLines(lines[0], lines[1], lines[4]), "user count mismatch"},
+		"trailing data":  {data + lines[1] + "\n", "after footer"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := readAll(tc.data)
+			require.ErrorIs(t, err, ErrInvalid)
+			require.ErrorContains(t, err, tc.message)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Package auth содержит аутентификацию вызывающих сторон: идентичность (Principal),
+// её передачу через context и проверку учётных данных.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/trainee/review-service/internal/model"
+	"github.com/trainee/review-service/internal/tenant"
+)
+
+// Scopes, которыми ограничиваются токены.
+const (
+	ScopeRead       = "read"
+	ScopeTeamsWrite = "teams:write"
+	ScopePRsWrite   = "prs:write"
+	// ScopeAdmin разрешает всё, включая выпуск токенов, импорт и выгрузку данных.
+	ScopeAdmin = "admin"
+)
+
+// KnownScopes — все допустимые scopes.
+var KnownScopes = []string{ScopeRead, ScopeTeamsWrite, ScopePRsWrite, ScopeAdmin}
+
+var (
+	// ErrInvalidCredentials — учётные данные не распознаны, отозваны или истекли.
+	ErrInvalidCredentials = errors.New("auth: invalid credentials")
+	// ErrUnsupportedCredentials — токен другого вида; Chain передаёт его следующему Authenticator.
+	ErrUnsupportedCredentials = fmt.Errorf("%w: unsupported token type", ErrInvalidCredentials)
+)
+
+// Principal — аутентифицированная вызывающая сторона.
+type Principal struct {
+	// Subject — идентификатор вызывающего: token_id для API токена, user_id для JWT.
+	Subject string
+	Name    string
+	Scopes  []string
+	// Method — способ аутентификации: "api_token" или "jwt".
+	Method string
+	// Org — организация, к данным которой у вызывающего есть доступ.
+	Org string
+}
+
+// HasScope сообщает, разрешена ли операция со scope; admin разрешает всё.
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope) || slices.Contains(p.Scopes, ScopeAdmin)
+}
+
+// Authenticator проверяет bearer-токен и возвращает вызывающего.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*Principal, error)
+}
+
+// Chain пробует Authenticator по очереди, пока один из них не распознает токен.
+type Chain []Authenticator
+
+func (c Chain) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	for _, a := range c {
+		p, err := a.Authenticate(ctx, token)
+		if errors.Is(err, ErrUnsupportedCredentials) {
+			continue
+		}
+		return p, err
+	}
+	return nil, ErrUnsupportedCredentials
+}
+
+type principalKey struct{}
+
+// NewContext возвращает копию ctx с вызывающим.
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext возвращает вызывающего или nil, если запрос не аутентифицирован.
+func FromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
+
+// ResolveOrg определяет организацию запроса. Для аутентифицированного вызывающего
+// это организация из его учётных данных, а requested может только совпадать с ней
+// (иначе model.ErrForbidden). Без аутентификации организация задаётся requested,
+// по умолчанию — tenant.Default.
+func ResolveOrg(p *Principal, requested string) (string, error) {
+	if requested != "" {
+		if tenant.Validate(requested) != nil {
+			return "", model.Invalid(tenant.Header, "pattern", fmt.Sprintf("invalid organization id %q", requested))
+		}
+	}
+	if p == nil {
+		if requested == "" {
+			return tenant.Default, nil
+		}
+		return requested, nil
+	}
+	org := p.Org
+	if org == "" {
+		org = tenant.Default
+	}
+	if requested != "" && requested != org {
+		return "", fmt.Errorf("%w: organization %s is not accessible", model.ErrForbidden, requested)
+	}
+	return org, nil
+}
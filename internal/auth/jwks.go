@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// PublicKey — ключ проверки подписи из JWKS.
+type PublicKey struct {
+	ID  string
+	Alg string
+	Key crypto.PublicKey
+}
+
+// KeySource возвращает ключи для проверки подписи JWT.
+type KeySource interface {
+	// Keys возвращает ключи; refresh=true просит источник перечитать их (например, при неизвестном kid).
+	Keys(ctx context.Context, refresh bool) ([]PublicKey, error)
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// ParseJWKS разбирает JWK Set (RFC 7517). Ключи не для подписи и неподдерживаемых типов пропускаются.
+func ParseJWKS(data []byte) ([]PublicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("auth: parse JWKS: %w", err)
+	}
+
+	var keys []PublicKey
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("auth: JWKS key %q: %w", k.Kid, err)
+		}
+		if key != nil {
+			keys = append(keys, PublicKey{ID: k.Kid, Alg: k.Alg, Key: key})
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("auth: JWKS contains no signing keys")
+	}
+	return keys, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, errors.New("RSA exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("EC point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("invalid base64url integer")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// StaticKeys — фиксированный набор ключей.
+type StaticKeys []PublicKey
+
+func (s StaticKeys) Keys(context.Context, bool) ([]PublicKey, error) {
+	return s, nil
+}
+
+// LoadJWKSFile читает JWKS из файла один раз при старте.
+func LoadJWKSFile(path string) (StaticKeys, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJWKS(data)
+}
+
+// RemoteJWKS загружает JWKS по URL и кэширует его. Повторная загрузка — по истечении
+// TTL или по запросу refresh, но не чаще MinRefreshInterval.
+type RemoteJWKS struct {
+	URL                string
+	Client             *http.Client
+	TTL                time.Duration
+	MinRefreshInterval time.Duration
+
+	mu        sync.Mutex
+	keys      []PublicKey
+	fetchedAt time.Time
+}
+
+func NewRemoteJWKS(url string) *RemoteJWKS {
+	return &RemoteJWKS{
+		URL:                url,
+		Client:             &http.Client{Timeout: 5 * time.Second},
+		TTL:                10 * time.Minute,
+		MinRefreshInterval: 30 * time.Second,
+	}
+}
+
+func (r *RemoteJWKS) Keys(ctx context.Context, refresh bool) ([]PublicKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	age := time.Since(r.fetchedAt)
+	stale := r.keys == nil || age >= r.TTL || (refresh && age >= r.MinRefreshInterval)
+	if !stale {
+		return r.keys, nil
+	}
+
+	keys, err := r.fetch(ctx)
+	if err != nil {
+		if r.keys != nil {
+			// IdP временно недоступен — продолжаем работать на закэшированных ключах
+			// и повторяем загрузку не раньше чем через MinRefreshInterval.
+			r.fetchedAt = time.Now().Add(r.MinRefreshInterval - r.TTL)
+			return r.keys, nil
+		}
+		return nil, err
+	}
+	r.keys, r.fetchedAt = keys, time.Now()
+	return keys, nil
+}
+
+func (r *RemoteJWKS) fetch(ctx context.Context) ([]PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("auth: fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("auth: fetch JWKS: unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	return ParseJWKS(data)
+}
@@ -0,0 +1,229 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	_ "crypto/sha256" // SHA-256 для RS256/ES256
+	_ "crypto/sha512" // SHA-384/512 для RS384/RS512/ES384/ES512
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/trainee/review-service/internal/tenant"
+)
+
+// JWTConfig описывает проверку JWT, выпущенных корпоративным SSO.
+type JWTConfig struct {
+	Keys     KeySource
+	Issuer   string
+	Audience string
+	// UserClaim — claim со значением user_id сервиса (по умолчанию "sub").
+	UserClaim string
+	// OrgClaim — claim с организацией пользователя. Если не задан, все
+	// пользователи SSO относятся к tenant.Default.
+	OrgClaim string
+	// DefaultScopes выдаются, если в токене нет claim scope/scp.
+	DefaultScopes []string
+	// Leeway — допустимое расхождение часов при проверке exp/nbf.
+	Leeway time.Duration
+}
+
+// JWTAuthenticator проверяет подпись и claims JWT (RS256/384/512, ES256/384/512).
+type JWTAuthenticator struct {
+	cfg JWTConfig
+	now func() time.Time
+}
+
+func NewJWTAuthenticator(cfg JWTConfig) (*JWTAuthenticator, error) {
+	if cfg.Keys == nil {
+		return nil, errors.New("auth: JWT key source is required")
+	}
+	if cfg.Issuer == "" || cfg.Audience == "" {
+		return nil, errors.New("auth: JWT issuer and audience are required")
+	}
+	if cfg.UserClaim == "" {
+		cfg.UserClaim = "sub"
+	}
+	return &JWTAuthenticator{cfg: cfg, now: time.Now}, nil
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+var jwtHashes = map[string]crypto.Hash{
+	"RS256": crypto.SHA256, "RS384": crypto.SHA384, "RS512": crypto.SHA512,
+	"ES256": crypto.SHA256, "ES384": crypto.SHA384, "ES512": crypto.SHA512,
+}
+
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrUnsupportedCredentials
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, invalidJWT("malformed header")
+	}
+	hash, ok := jwtHashes[header.Alg]
+	if !ok {
+		// В том числе "none" и HS*: симметричные ключи SSO нам не выдаёт.
+		return nil, invalidJWT("unsupported alg " + header.Alg)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalidJWT("malformed signature")
+	}
+	if err := a.verify(ctx, header, hash, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return nil, err
+	}
+
+	var claims map[string]any
+	dec := json.NewDecoder(base64.NewDecoder(base64.RawURLEncoding, strings.NewReader(parts[1])))
+	dec.UseNumber()
+	if err := dec.Decode(&claims); err != nil {
+		return nil, invalidJWT("malformed claims")
+	}
+	return a.principal(claims)
+}
+
+func (a *JWTAuthenticator) verify(ctx context.Context, header jwtHeader, hash crypto.Hash, signed, sig []byte) error {
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	for _, refresh := range []bool{false, true} {
+		keys, err := a.cfg.Keys.Keys(ctx, refresh)
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, k := range keys {
+			if (header.Kid != "" && k.ID != header.Kid) || (k.Alg != "" && k.Alg != header.Alg) {
+				continue
+			}
+			found = true
+			if verifySignature(k.Key, header.Alg, hash, digest, sig) {
+				return nil
+			}
+		}
+		// Перечитываем ключи только если подходящего ключа нет (ротация у IdP).
+		if found {
+			break
+		}
+	}
+	return invalidJWT("signature verification failed")
+}
+
+func verifySignature(key crypto.PublicKey, alg string, hash crypto.Hash, digest, sig []byte) bool {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return alg[:2] == "RS" && rsa.VerifyPKCS1v15(k, hash, digest, sig) == nil
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if alg[:2] != "ES" || len(sig) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(sig[:size])
+		s := new(big.Int).SetBytes(sig[size:])
+		return ecdsa.Verify(k, digest, r, s)
+	}
+	return false
+}
+
+func (a *JWTAuthenticator) principal(claims map[string]any) (*Principal, error) {
+	now := a.now()
+	exp, ok := numericDate(claims["exp"])
+	if !ok {
+		return nil, invalidJWT("exp is required")
+	}
+	if !now.Before(exp.Add(a.cfg.Leeway)) {
+		return nil, invalidJWT("token is expired")
+	}
+	if nbf, ok := numericDate(claims["nbf"]); ok && now.Add(a.cfg.Leeway).Before(nbf) {
+		return nil, invalidJWT("token is not valid yet")
+	}
+	if iss, _ := claims["iss"].(string); iss != a.cfg.Issuer {
+		return nil, invalidJWT("unexpected issuer")
+	}
+	if !hasAudience(claims["aud"], a.cfg.Audience) {
+		return nil, invalidJWT("unexpected audience")
+	}
+
+	userID, _ := claims[a.cfg.UserClaim].(string)
+	if userID == "" {
+		return nil, invalidJWT("claim " + a.cfg.UserClaim + " is missing")
+	}
+	name, _ := claims["name"].(string)
+
+	org := tenant.Default
+	if a.cfg.OrgClaim != "" {
+		org, _ = claims[a.cfg.OrgClaim].(string)
+		if tenant.Validate(org) != nil {
+			return nil, invalidJWT("claim " + a.cfg.OrgClaim + " is missing or invalid")
+		}
+	}
+
+	// Scopes из токена (scope — строка через пробел, scp — массив) заменяют DefaultScopes.
+	scopes := a.cfg.DefaultScopes
+	if v, ok := claims["scope"].(string); ok {
+		scopes = strings.Fields(v)
+	}
+	if v, ok := claims["scp"].([]any); ok {
+		scopes = nil
+		for _, s := range v {
+			if str, ok := s.(string); ok {
+				scopes = append(scopes, str)
+			}
+		}
+	}
+	return &Principal{Subject: userID, Name: name, Scopes: scopes, Method: "jwt", Org: org}, nil
+}
+
+func hasAudience(aud any, want string) bool {
+	switch v := aud.(type) {
+	case string:
+		return v == want
+	case []any:
+		for _, item := range v {
+			if item == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func numericDate(v any) (time.Time, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	sec := int64(f)
+	return time.Unix(sec, int64((f-float64(sec))*1e9)), true
+}
+
+func decodeSegment(seg string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+func invalidJWT(reason string) error {
+	return fmt.Errorf("%w: jwt: %s", ErrInvalidCredentials, reason)
+}
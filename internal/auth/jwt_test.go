@@ -0,0 +1,262 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trainee/review-service/internal/tenant"
+)
+
+// testKey — локально сгенерированный ключ подписи; сеть и настоящий IdP не нужны.
+type testKey struct {
+	kid  string
+	alg  string
+	priv crypto.Signer
+}
+
+func newRSAKey(t *testing.T, kid string) testKey {
+	t.Helper()
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return testKey{kid: kid, alg: "RS256", priv: priv}
+}
+
+func newECKey(t *testing.T, kid string) testKey {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return testKey{kid: kid, alg: "ES256", priv: priv}
+}
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+func jwksJSON(t *testing.T, keys ...testKey) []byte {
+	t.Helper()
+	var set struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	for _, k := range keys {
+		switch pub := k.priv.Public().(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, map[string]string{
+				"kty": "RSA", "kid": k.kid, "alg": k.alg, "use": "sig",
+				"n": b64(pub.N.Bytes()), "e": b64(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case *ecdsa.PublicKey:
+			set.Keys = append(set.Keys, map[string]string{
+				"kty": "EC", "kid": k.kid, "crv": "P-256",
+				"x": b64(pub.X.FillBytes(make([]byte, 32))), "y": b64(pub.Y.FillBytes(make([]byte, 32))),
+			})
+		}
+	}
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	return data
+}
+
+func signJWT(t *testing.T, k testKey, claims map[string]any) string {
+	t.Helper()
+	header, err := json.Marshal(map[string]string{"alg": k.alg, "kid": k.kid, "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signed := b64(header) + "." + b64(payload)
+
+	h := crypto.SHA256.New()
+	h.Write([]byte(signed))
+	digest := h.Sum(nil)
+
+	var sig []byte
+	switch priv := k.priv.(type) {
+	case *rsa.PrivateKey:
+		sig, err = rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA256, digest)
+		require.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, priv, digest)
+		require.NoError(t, err)
+		sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + b64(sig)
+}
+
+func validClaims(now time.Time) map[string]any {
+	return map[string]any{
+		"iss":         "https://sso.example.com",
+		"aud":         []string{"other", "review-service"},
+		"exp":         now.Add(time.Hour).Unix(),
+		"sub":         "8f3a-uuid",
+		"employee_id": "u1",
+		"name":        "Alice",
+	}
+}
+
+func newTestJWTAuthenticator(t *testing.T, keys KeySource, now time.Time) *JWTAuthenticator {
+	t.Helper()
+	a, err := NewJWTAuthenticator(JWTConfig{
+		Keys:          keys,
+		Issuer:        "https://sso.example.com",
+		Audience:      "review-service",
+		UserClaim:     "employee_id",
+		DefaultScopes: []string{ScopeRead},
+	})
+	require.NoError(t, err)
+	a.now = func() time.Time { return now }
+	return a
+}
+
+func TestJWTFromJWKSFile(t *testing.T) {
+	rsaKey, ecKey := newRSAKey(t, "rsa-1"), newECKey(t, "ec-1")
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwksJSON(t, rsaKey, ecKey), 0o600))
+	keys, err := LoadJWKSFile(path)
+	require.NoError(t, err)
+
+	now := time.Now()
+	a := newTestJWTAuthenticator(t, keys, now)
+	ctx := context.Background()
+
+	for _, k := range []testKey{rsaKey, ecKey} {
+		p, err := a.Authenticate(ctx, signJWT(t, k, validClaims(now)))
+		require.NoError(t, err, k.alg)
+		require.Equal(t, &Principal{Subject: "u1", Name: "Alice", Scopes: []string{ScopeRead}, Method: "jwt", Org: tenant.Default}, p)
+	}
+
+	claims := validClaims(now)
+	claims["scope"] = "read prs:write"
+	p, err := a.Authenticate(ctx, signJWT(t, rsaKey, claims))
+	require.NoError(t, err)
+	require.Equal(t, []string{ScopeRead, ScopePRsWrite}, p.Scopes)
+}
+
+func TestJWTRejectsInvalidTokens(t *testing.T) {
+	key := newRSAKey(t, "rsa-1")
+	now := time.Now()
+	a := newTestJWTAuthenticator(t, mustParseJWKS(t, jwksJSON(t, key)), now)
+	ctx := context.Background()
+
+	modify := func(f func(map[string]any)) string {
+		claims := validClaims(now)
+		f(claims)
+		return signJWT(t, key, claims)
+	}
+	foreign := newRSAKey(t, "rsa-1")
+	valid := signJWT(t, key, validClaims(now))
+	parts := strings.Split(valid, ".")
+
+	cases := map[string]string{
+		"expired":       modify(func(c map[string]any) { c["exp"] = now.Add(-time.Second).Unix() }),
+		"no exp":        modify(func(c map[string]any) { delete(c, "exp") }),
+		"not yet valid": modify(func(c map[string]any) { c["nbf"] = now.Add(time.Hour).Unix() }),
+		"issuer":        modify(func(c map[string]any) { c["iss"] = "https://evil.example.com" }),
+		"audience":      modify(func(c map[string]any) { c["aud"] = "other" }),
+		"user claim":    modify(func(c map[string]any) { delete(c, "employee_id") }),
+		"foreign key":   signJWT(t, foreign, validClaims(now)),
+		"alg none":      b64([]byte(`{"alg":"none"}`)) + "." + parts[1] + ".",
+		"tampered":      parts[0] + "." + b64([]byte(`{"employee_id":"admin"}`)) + "." + parts[2],
+	}
+	for name, token := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := a.Authenticate(ctx, token)
+			require.ErrorIs(t, err, ErrInvalidCredentials)
+			require.NotErrorIs(t, err, ErrUnsupportedCredentials)
+		})
+	}
+
+	_, err := a.Authenticate(ctx, "rvw_api_token")
+	require.ErrorIs(t, err, ErrUnsupportedCredentials)
+}
+
+func TestJWTOrgClaim(t *testing.T) {
+	key := newRSAKey(t, "rsa-1")
+	now := time.Now()
+	a := newTestJWTAuthenticator(t, mustParseJWKS(t, jwksJSON(t, key)), now)
+	a.cfg.OrgClaim = "org"
+	ctx := context.Background()
+
+	claims := validClaims(now)
+	claims["org"] = "acme"
+	p, err := a.Authenticate(ctx, signJWT(t, key, claims))
+	require.NoError(t, err)
+	require.Equal(t, "acme", p.Org)
+
+	for _, org := range []any{nil, "", "ACME", 42} {
+		claims["org"] = org
+		_, err := a.Authenticate(ctx, signJWT(t, key, claims))
+		require.ErrorIs(t, err, ErrInvalidCredentials, org)
+	}
+}
+
+func TestRemoteJWKSRefreshesOnUnknownKid(t *testing.T) {
+	oldKey, newKey := newECKey(t, "2024"), newECKey(t, "2025")
+	var current atomic.Value
+	current.Store(jwksJSON(t, oldKey))
+	var fetches atomic.Int32
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches.Add(1)
+		_, _ = w.Write(current.Load().([]byte))
+	}))
+	defer idp.Close()
+
+	remote := NewRemoteJWKS(idp.URL)
+	remote.MinRefreshInterval = 0
+	now := time.Now()
+	a := newTestJWTAuthenticator(t, remote, now)
+	ctx := context.Background()
+
+	_, err := a.Authenticate(ctx, signJWT(t, oldKey, validClaims(now)))
+	require.NoError(t, err)
+	_, err = a.Authenticate(ctx, signJWT(t, oldKey, validClaims(now)))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, fetches.Load(), "keys are cached")
+
+	// IdP сменил ключ: неизвестный kid приводит к повторной загрузке JWKS.
+	current.Store(jwksJSON(t, newKey))
+	_, err = a.Authenticate(ctx, signJWT(t, newKey, validClaims(now)))
+	require.NoError(t, err)
+	require.EqualValues(t, 2, fetches.Load())
+}
+
+func TestChain(t *testing.T) {
+	store := newFakeTokenStore()
+	tokens := NewTokens(store)
+	value, _, err := tokens.Issue(context.Background(), "ci", []string{ScopeRead}, 0)
+	require.NoError(t, err)
+
+	key := newRSAKey(t, "k")
+	now := time.Now()
+	chain := Chain{tokens, newTestJWTAuthenticator(t, mustParseJWKS(t, jwksJSON(t, key)), now)}
+
+	p, err := chain.Authenticate(context.Background(), value)
+	require.NoError(t, err)
+	require.Equal(t, "api_token", p.Method)
+
+	p, err = chain.Authenticate(context.Background(), signJWT(t, key, validClaims(now)))
+	require.NoError(t, err)
+	require.Equal(t, "jwt", p.Method)
+
+	_, err = chain.Authenticate(context.Background(), "opaque")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func mustParseJWKS(t *testing.T, data []byte) StaticKeys {
+	t.Helper()
+	keys, err := ParseJWKS(data)
+	require.NoError(t, err)
+	return keys
+}
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/trainee/review-service/internal/model"
+	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/tenant"
+)
+
+// TokenPrefix отличает API токены от других bearer-токенов.
+const TokenPrefix = "rvw_"
+
+// Tokens выпускает, проверяет и отзывает API токены.
+type Tokens struct {
+	store repo.TokenRepository
+	now   func() time.Time
+}
+
+func NewTokens(store repo.TokenRepository) *Tokens {
+	return &Tokens{store: store, now: time.Now}
+}
+
+// HashToken возвращает хэш, под которым токен хранится в БД. Токен случайный
+// и длинный, поэтому соль и медленный KDF не нужны.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ValidateScopes проверяет, что scopes непустые и известные.
+func ValidateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return fmt.Errorf("%w: at least one scope is required", model.ErrBadRequest)
+	}
+	for _, s := range scopes {
+		if !slices.Contains(KnownScopes, s) {
+			return fmt.Errorf("%w: unknown scope %q", model.ErrBadRequest, s)
+		}
+	}
+	return nil
+}
+
+// Issue выпускает токен организации из ctx. Значение возвращается один раз,
+// в БД сохраняется только хэш. ttl=0 — бессрочный токен.
+func (t *Tokens) Issue(ctx context.Context, name string, scopes []string, ttl time.Duration) (string, *model.APIToken, error) {
+	if strings.TrimSpace(name) == "" || ttl < 0 {
+		return "", nil, model.ErrBadRequest
+	}
+	if err := ValidateScopes(scopes); err != nil {
+		return "", nil, err
+	}
+
+	id, err := randomString(8)
+	if err != nil {
+		return "", nil, err
+	}
+	secret, err := randomString(32)
+	if err != nil {
+		return "", nil, err
+	}
+	value := TokenPrefix + secret
+
+	token := model.APIToken{
+		ID:        "tok_" + id,
+		OrgID:     tenant.FromContext(ctx),
+		Name:      name,
+		Scopes:    slices.Compact(slices.Sorted(slices.Values(scopes))),
+		CreatedAt: t.now().UTC().Truncate(time.Microsecond),
+	}
+	if ttl > 0 {
+		expires := token.CreatedAt.Add(ttl)
+		token.ExpiresAt = &expires
+	}
+	if err := t.store.CreateAPIToken(ctx, token, HashToken(value)); err != nil {
+		return "", nil, err
+	}
+	return value, &token, nil
+}
+
+// Authenticate реализует Authenticator для API токенов.
+func (t *Tokens) Authenticate(ctx context.Context, value string) (*Principal, error) {
+	if !strings.HasPrefix(value, TokenPrefix) {
+		return nil, ErrUnsupportedCredentials
+	}
+	token, err := t.store.GetAPITokenByHash(ctx, HashToken(value))
+	if errors.Is(err, repo.ErrNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := t.now()
+	if token.RevokedAt != nil || (token.ExpiresAt != nil && !now.Before(*token.ExpiresAt)) {
+		return nil, ErrInvalidCredentials
+	}
+	return &Principal{Subject: token.ID, Name: token.Name, Scopes: token.Scopes, Method: "api_token", Org: token.OrgID}, nil
+}
+
+func (t *Tokens) List(ctx context.Context) ([]model.APIToken, error) {
+	tokens, err := t.store.ListAPITokens(ctx)
+	if tokens == nil {
+		tokens = []model.APIToken{}
+	}
+	return tokens, err
+}
+
+func (t *Tokens) Revoke(ctx context.Context, tokenID string) error {
+	err := t.store.RevokeAPIToken(ctx, tokenID, t.now().UTC())
+	if errors.Is(err, repo.ErrNotFound) {
+		return model.ErrNotFound
+	}
+	return err
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trainee/review-service/internal/model"
+	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/tenant"
+)
+
+type fakeTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]model.APIToken // по хэшу
+}
+
+func newFakeTokenStore() *fakeTokenStore {
+	return &fakeTokenStore{tokens: make(map[string]model.APIToken)}
+}
+
+func (f *fakeTokenStore) CreateAPIToken(_ context.Context, token model.APIToken, hash string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tokens[hash] = token
+	return nil
+}
+
+func (f *fakeTokenStore) GetAPITokenByHash(_ context.Context, hash string) (*model.APIToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.tokens[hash]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return &t, nil
+}
+
+func (f *fakeTokenStore) ListAPITokens(context.Context) ([]model.APIToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var result []model.APIToken
+	for _, t := range f.tokens {
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+func (f *fakeTokenStore) RevokeAPIToken(_ context.Context, tokenID string, at time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for hash, t := range f.tokens {
+		if t.ID == tokenID {
+			t.RevokedAt = &at
+			f.tokens[hash] = t
+			return nil
+		}
+	}
+	return repo.ErrNotFound
+}
+
+func TestIssueAndAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeTokenStore()
+	tokens := NewTokens(store)
+
+	value, token, err := tokens.Issue(ctx, "ci", []string{ScopePRsWrite, ScopeRead, ScopeRead}, 0)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(value, TokenPrefix))
+	require.Equal(t, []string{ScopePRsWrite, ScopeRead}, token.Scopes)
+	require.Nil(t, token.ExpiresAt)
+	// Значение токена не хранится.
+	require.Contains(t, store.tokens, HashToken(value))
+
+	p, err := tokens.Authenticate(ctx, value)
+	require.NoError(t, err)
+	require.Equal(t, token.ID, p.Subject)
+	require.Equal(t, tenant.Default, p.Org)
+	require.True(t, p.HasScope(ScopeRead))
+	require.False(t, p.HasScope(ScopeTeamsWrite))
+
+	_, err = tokens.Authenticate(ctx, value+"x")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = tokens.Authenticate(ctx, "not-a-token")
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+
+	require.NoError(t, tokens.Revoke(ctx, token.ID))
+	_, err = tokens.Authenticate(ctx, value)
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+	require.ErrorIs(t, tokens.Revoke(ctx, "tok_missing"), model.ErrNotFound)
+}
+
+func TestTokenBelongsToIssuingOrg(t *testing.T) {
+	tokens := NewTokens(newFakeTokenStore())
+	value, token, err := tokens.Issue(tenant.NewContext(context.Background(), "acme"), "ci", []string{ScopeRead}, 0)
+	require.NoError(t, err)
+	require.Equal(t, "acme", token.OrgID)
+
+	p, err := tokens.Authenticate(context.Background(), value)
+	require.NoError(t, err)
+	require.Equal(t, "acme", p.Org)
+}
+
+func TestTokenExpiry(t *testing.T) {
+	ctx := context.Background()
+	tokens := NewTokens(newFakeTokenStore())
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tokens.now = func() time.Time { return now }
+
+	value, token, err := tokens.Issue(ctx, "short", []string{ScopeAdmin}, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(time.Hour), *token.ExpiresAt)
+
+	p, err := tokens.Authenticate(ctx, value)
+	require.NoError(t, err)
+	require.True(t, p.HasScope(ScopeTeamsWrite), "admin implies every scope")
+
+	now = now.Add(time.Hour)
+	_, err = tokens.Authenticate(ctx, value)
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestIssueValidation(t *testing.T) {
+	tokens := NewTokens(newFakeTokenStore())
+	_, _, err := tokens.Issue(context.Background(), "x", []string{"root"}, 0)
+	require.ErrorIs(t, err, model.ErrBadRequest)
+	_, _, err = tokens.Issue(context.Background(), "x", nil, 0)
+	require.ErrorIs(t, err, model.ErrBadRequest)
+	_, _, err = tokens.Issue(context.Background(), " ", []string{ScopeRead}, 0)
+	require.ErrorIs(t, err, model.ErrBadRequest)
+}
+
+func TestPrincipalContext(t *testing.T) {
+	ctx := context.Background()
+	require.Nil(t, FromContext(ctx))
+	p := &Principal{Subject: "tok_1"}
+	require.Same(t, p, FromContext(NewContext(ctx, p)))
+}
@@ -0,0 +1,218 @@
+// Package events доставляет доменные события (назначения ревьюеров, merge,
+// смена активности) подписчикам транспортов: gRPC-стримам и SSE.
+package events
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Type — вид события.
+type Type string
+
+const (
+	// PRAssigned — ревьюеры назначены при создании PR.
+	PRAssigned Type = "pull_request.assigned"
+	// PRReassigned — ревьюер заменён другим участником команды.
+	PRReassigned Type = "pull_request.reassigned"
+	// PRMerged — PR переведён в MERGED (повторный merge события не создаёт).
+	PRMerged Type = "pull_request.merged"
+	// UserStatusChanged — пользователю установлена активность is_active.
+	UserStatusChanged Type = "user.status_changed"
+)
+
+// Event — изменение, о котором узнают подписчики своей организации.
+type Event struct {
+	// ID монотонно растёт в пределах организации.
+	ID            uint64   `json:"id"`
+	Type          Type     `json:"type"`
+	Org           string   `json:"-"`
+	PullRequestID string   `json:"pull_request_id,omitempty"`
+	AuthorID      string   `json:"author_id,omitempty"`
+	TeamName      string   `json:"team_name,omitempty"`
+	Reviewers     []string `json:"reviewers,omitempty"`
+	OldReviewerID string   `json:"old_reviewer_id,omitempty"`
+	NewReviewerID string   `json:"new_reviewer_id,omitempty"`
+	// UserID и IsActive заполняются для UserStatusChanged.
+	UserID     string    `json:"user_id,omitempty"`
+	IsActive   *bool     `json:"is_active,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// IsAssignment сообщает, что событие — назначение или переназначение ревьюеров.
+func (e Event) IsAssignment() bool {
+	return e.Type == PRAssigned || e.Type == PRReassigned
+}
+
+// Involves сообщает, касается ли событие пользователя как ревьюера.
+func (e Event) Involves(userID string) bool {
+	if e.OldReviewerID == userID || e.NewReviewerID == userID {
+		return true
+	}
+	for _, r := range e.Reviewers {
+		if r == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Concerns сообщает, касается ли событие пользователя: как ревьюера, автора PR
+// или того, чья активность изменилась.
+func (e Event) Concerns(userID string) bool {
+	return e.AuthorID == userID || e.UserID == userID || e.Involves(userID)
+}
+
+const (
+	// DefaultBuffer — размер очереди подписчика по умолчанию.
+	DefaultBuffer = 64
+	// ReplaySize — сколько последних событий хранится для продолжения потока после разрыва.
+	ReplaySize = 1024
+)
+
+// Broker рассылает события подписчикам их организации. Publish не блокируется:
+// подписчик, не успевающий читать, отключается (канал закрывается), и клиент
+// переподключается, продолжая с последнего полученного ID (см. SubscribeAfter).
+// Нумерация и буфер для продолжения у каждой организации свои: активность одной
+// не вытесняет события другой и не видна ей по ID.
+type Broker struct {
+	mu     sync.Mutex
+	orgs   map[string]*stream
+	closed bool
+}
+
+// stream — события и подписчики одной организации.
+type stream struct {
+	seq  uint64
+	subs map[chan Event]struct{}
+	// history — последние события по возрастанию ID, не больше ReplaySize.
+	history []Event
+}
+
+func NewBroker() *Broker {
+	return &Broker{orgs: make(map[string]*stream)}
+}
+
+// stream возвращает поток организации, создавая его; вызывается под b.mu.
+func (b *Broker) stream(org string) *stream {
+	s, ok := b.orgs[org]
+	if !ok {
+		s = &stream{subs: make(map[chan Event]struct{})}
+		b.orgs[org] = s
+	}
+	return s
+}
+
+// Publish присваивает событию ID в потоке организации e.Org и рассылает его
+// подписчикам этой организации.
+func (b *Broker) Publish(e Event) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := b.stream(e.Org)
+	s.seq++
+	e.ID = s.seq
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = time.Now().UTC()
+	}
+	if len(s.history) == ReplaySize {
+		s.history = append(s.history[:0], s.history[1:]...)
+	}
+	s.history = append(s.history, e)
+	for ch := range s.subs {
+		select {
+		case ch <- e:
+		default:
+			slog.Warn("Event subscriber is too slow, disconnecting", "event_id", e.ID, "org", e.Org)
+			delete(s.subs, ch)
+			close(ch)
+		}
+	}
+	return e
+}
+
+// Subscribe возвращает канал событий организации org. Канал закрывается, когда
+// ctx отменён, подписчик отстал больше чем на buffer событий или брокер закрыт.
+func (b *Broker) Subscribe(ctx context.Context, org string, buffer int) <-chan Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.subscribe(ctx, org, buffer)
+}
+
+// SubscribeAfter подписывается на события организации org и возвращает
+// сохранённые события с ID больше lastID, без пропусков между ними и каналом.
+// complete == false, если часть событий после lastID уже вытеснена из буфера или
+// lastID выдан до перезапуска сервера — тогда клиенту нужно перечитать состояние
+// через API.
+func (b *Broker) SubscribeAfter(ctx context.Context, org string, lastID uint64, buffer int) (replay []Event, ch <-chan Event, complete bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := b.stream(org)
+
+	// ID событий в history идут подряд: oldest … seq.
+	oldest := s.seq - uint64(len(s.history)) + 1
+	switch {
+	case lastID > s.seq:
+		replay = s.history
+	case lastID+1 >= oldest:
+		replay, complete = s.history[lastID+1-oldest:], true
+	default:
+		replay = s.history
+	}
+	return slices.Clone(replay), b.subscribe(ctx, org, buffer), complete
+}
+
+// subscribe регистрирует подписчика; вызывается под b.mu.
+func (b *Broker) subscribe(ctx context.Context, org string, buffer int) <-chan Event {
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+	ch := make(chan Event, buffer)
+	if b.closed {
+		close(ch)
+		return ch
+	}
+	s := b.stream(org)
+	s.subs[ch] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		b.unsubscribe(s, ch)
+	}()
+	return ch
+}
+
+func (b *Broker) unsubscribe(s *stream, ch chan Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := s.subs[ch]; ok {
+		delete(s.subs, ch)
+		close(ch)
+	}
+}
+
+// Subscribers возвращает число активных подписок всех организаций.
+func (b *Broker) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := 0
+	for _, s := range b.orgs {
+		n += len(s.subs)
+	}
+	return n
+}
+
+// Close закрывает все подписки; используется при остановке сервера.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for _, s := range b.orgs {
+		for ch := range s.subs {
+			delete(s.subs, ch)
+			close(ch)
+		}
+	}
+}
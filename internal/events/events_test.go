@@ -0,0 +1,111 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrokerPublish(t *testing.T) {
+	b := NewBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := b.Subscribe(ctx, "acme", 4)
+
+	first := b.Publish(Event{Type: PRAssigned, Org: "acme", PullRequestID: "pr-1", Reviewers: []string{"u2"}})
+	second := b.Publish(Event{Type: PRReassigned, Org: "acme", PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u3"})
+	require.Equal(t, uint64(1), first.ID)
+	require.Equal(t, uint64(2), second.ID)
+	require.False(t, first.OccurredAt.IsZero())
+
+	require.Equal(t, first, <-ch)
+	got := <-ch
+	require.True(t, got.Involves("u2"))
+	require.True(t, got.Involves("u3"))
+	require.False(t, got.Involves("u1"))
+
+	// Отмена контекста закрывает подписку.
+	cancel()
+	_, ok := <-ch
+	require.False(t, ok)
+	require.Zero(t, b.Subscribers())
+}
+
+func TestBrokerDisconnectsSlowSubscriber(t *testing.T) {
+	b := NewBroker()
+	slow := b.Subscribe(context.Background(), "acme", 1)
+	fast := b.Subscribe(context.Background(), "acme", 4)
+
+	b.Publish(Event{Org: "acme", PullRequestID: "pr-1"})
+	b.Publish(Event{Org: "acme", PullRequestID: "pr-2"})
+
+	require.Equal(t, "pr-1", (<-slow).PullRequestID)
+	_, ok := <-slow
+	require.False(t, ok, "slow subscriber must be disconnected")
+	require.Equal(t, "pr-1", (<-fast).PullRequestID)
+	require.Equal(t, "pr-2", (<-fast).PullRequestID)
+
+	b.Close()
+	_, ok = <-fast
+	require.False(t, ok)
+	_, ok = <-b.Subscribe(context.Background(), "acme", 1)
+	require.False(t, ok, "subscribe after Close returns a closed channel")
+}
+
+func TestBrokerSubscribeAfter(t *testing.T) {
+	b := NewBroker()
+	for i := 0; i < ReplaySize+2; i++ {
+		b.Publish(Event{Type: PRAssigned, Org: "acme"})
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	replay, ch, complete := b.SubscribeAfter(ctx, "acme", ReplaySize, 4)
+	require.True(t, complete)
+	require.Len(t, replay, 2)
+	require.Equal(t, uint64(ReplaySize+1), replay[0].ID)
+
+	// Между буфером и каналом нет пропусков.
+	b.Publish(Event{Type: PRMerged, Org: "acme"})
+	require.Equal(t, uint64(ReplaySize+3), (<-ch).ID)
+
+	// Первые события вытеснены из буфера.
+	replay, _, complete = b.SubscribeAfter(ctx, "acme", 1, 4)
+	require.False(t, complete)
+	require.Len(t, replay, ReplaySize)
+
+	// ID больше последнего выдан до перезапуска.
+	_, _, complete = b.SubscribeAfter(ctx, "acme", 1<<40, 4)
+	require.False(t, complete)
+
+	// Клиент, получивший всё, ничего не пропустил.
+	replay, _, complete = b.SubscribeAfter(ctx, "acme", ReplaySize+3, 4)
+	require.True(t, complete)
+	require.Empty(t, replay)
+}
+
+func TestBrokerSeparatesOrgs(t *testing.T) {
+	b := NewBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	quiet := b.Subscribe(ctx, "quiet", 2)
+
+	b.Publish(Event{Type: PRAssigned, Org: "quiet", PullRequestID: "q-1"})
+	// Поток другой организации не вытесняет буфер и не сдвигает нумерацию.
+	for i := 0; i < ReplaySize+1; i++ {
+		b.Publish(Event{Type: PRAssigned, Org: "busy"})
+	}
+	second := b.Publish(Event{Type: PRMerged, Org: "quiet", PullRequestID: "q-1"})
+	require.Equal(t, uint64(2), second.ID)
+
+	// Подписчик не получает чужие события и поэтому не отстаёт.
+	require.Equal(t, "q-1", (<-quiet).PullRequestID)
+	require.Equal(t, second, <-quiet)
+
+	replay, _, complete := b.SubscribeAfter(ctx, "quiet", 0, 4)
+	require.True(t, complete)
+	require.Len(t, replay, 2)
+	replay, _, complete = b.SubscribeAfter(ctx, "new", 0, 4)
+	require.True(t, complete)
+	require.Empty(t, replay)
+}
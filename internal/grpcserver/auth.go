@@ -0,0 +1,100 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/model"
+	"github.com/trainee/review-service/internal/tenant"
+	reviewv1 "github.com/trainee/review-service/pkg/api/review/v1"
+)
+
+// orgMetadata — ключ метаданных с организацией (аналог заголовка X-Org-ID).
+var orgMetadata = strings.ToLower(tenant.Header)
+
+// methodScopes — scope, нужный для метода; остальные методы требуют auth.ScopeRead.
+var methodScopes = map[string]string{
+	reviewv1.ReviewService_CreateTeam_FullMethodName:        auth.ScopeTeamsWrite,
+	reviewv1.ReviewService_SetUserActive_FullMethodName:     auth.ScopeTeamsWrite,
+	reviewv1.ReviewService_AddTeamMember_FullMethodName:     auth.ScopeTeamsWrite,
+	reviewv1.ReviewService_RemoveTeamMember_FullMethodName:  auth.ScopeTeamsWrite,
+	reviewv1.ReviewService_CreatePullRequest_FullMethodName: auth.ScopePRsWrite,
+	reviewv1.ReviewService_MergePullRequest_FullMethodName:  auth.ScopePRsWrite,
+	reviewv1.ReviewService_ReassignReviewer_FullMethodName:  auth.ScopePRsWrite,
+}
+
+func (s *Server) unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := s.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return toStatus(err)
+	}
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// authorize аутентифицирует вызывающего, проверяет scope метода и кладёт
+// в контекст вызывающего и организацию — как middleware HTTP API.
+func (s *Server) authorize(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var principal *auth.Principal
+	if s.authenticator != nil {
+		scheme, token, ok := strings.Cut(first(md, "authorization"), " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			return nil, model.ErrUnauthorized
+		}
+		p, err := s.authenticator.Authenticate(ctx, strings.TrimSpace(token))
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			return nil, model.ErrUnauthorized
+		}
+		if err != nil {
+			return nil, err
+		}
+		scope, ok := methodScopes[method]
+		if !ok {
+			scope = auth.ScopeRead
+		}
+		if !p.HasScope(scope) {
+			slog.Warn("Insufficient scope", "subject", p.Subject, "required", scope, "method", method)
+			return nil, model.ErrInsufficientScope
+		}
+		principal = p
+		ctx = auth.NewContext(ctx, p)
+	}
+
+	org, err := auth.ResolveOrg(principal, first(md, orgMetadata))
+	if err != nil {
+		return nil, err
+	}
+	return tenant.NewContext(ctx, org), nil
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// contextStream подменяет контекст серверного стрима.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,113 @@
+package grpcserver
+
+import (
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/trainee/review-service/internal/model"
+	reviewv1 "github.com/trainee/review-service/pkg/api/review/v1"
+)
+
+// teamFromProto проверяет команду так же, как decodeTeam в HTTP API.
+func teamFromProto(in *reviewv1.Team) (model.Team, error) {
+	if in == nil || strings.TrimSpace(in.GetTeamName()) == "" {
+		return model.Team{}, model.ErrBadRequest
+	}
+	team := model.Team{
+		TeamName: in.GetTeamName(),
+		Members:  make([]model.TeamMember, 0, len(in.GetMembers())),
+	}
+	seen := make(map[string]struct{}, len(in.GetMembers()))
+	for _, m := range in.GetMembers() {
+		if strings.TrimSpace(m.GetUserId()) == "" || strings.TrimSpace(m.GetUsername()) == "" {
+			return model.Team{}, model.ErrBadRequest
+		}
+		if _, ok := seen[m.GetUserId()]; ok {
+			return model.Team{}, model.ErrBadRequest
+		}
+		seen[m.GetUserId()] = struct{}{}
+		team.Members = append(team.Members, model.TeamMember{
+			UserID:   m.GetUserId(),
+			Username: m.GetUsername(),
+			IsActive: m.GetIsActive(),
+		})
+	}
+	return team, nil
+}
+
+func teamToProto(t *model.Team) *reviewv1.Team {
+	out := &reviewv1.Team{TeamName: t.TeamName, Version: t.Version}
+	for _, m := range t.Members {
+		out.Members = append(out.Members, &reviewv1.TeamMember{
+			UserId:   m.UserID,
+			Username: m.Username,
+			IsActive: m.IsActive,
+		})
+	}
+	return out
+}
+
+func userToProto(u model.User) *reviewv1.User {
+	return &reviewv1.User{
+		UserId:   u.UserID,
+		Username: u.Username,
+		TeamName: u.TeamName,
+		IsActive: u.IsActive,
+		Version:  u.Version,
+	}
+}
+
+func userDetailsToProto(u model.UserDetails) *reviewv1.User {
+	out := userToProto(u.User)
+	out.OpenReviewCount = int64(u.OpenReviewCount)
+	return out
+}
+
+func prToProto(pr *model.PullRequest) *reviewv1.PullRequest {
+	return &reviewv1.PullRequest{
+		PullRequestId:     pr.ID,
+		PullRequestName:   pr.Name,
+		AuthorId:          pr.AuthorID,
+		Status:            statusToProto(pr.Status),
+		AssignedReviewers: pr.AssignedReviewers,
+		CreatedAt:         timestamp(pr.CreatedAt),
+		MergedAt:          timestamp(pr.MergedAt),
+		Version:           pr.Version,
+	}
+}
+
+func statusToProto(s model.PRStatus) reviewv1.PullRequestStatus {
+	switch s {
+	case model.PROpen:
+		return reviewv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN
+	case model.PRMerged:
+		return reviewv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED
+	default:
+		return reviewv1.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED
+	}
+}
+
+// statusFromProto возвращает пустой статус для UNSPECIFIED (без фильтра).
+func statusFromProto(s reviewv1.PullRequestStatus) model.PRStatus {
+	switch s {
+	case reviewv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN:
+		return model.PROpen
+	case reviewv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED:
+		return model.PRMerged
+	default:
+		return ""
+	}
+}
+
+func pageFromProto(limit int32, cursor string) model.PageRequest {
+	return model.PageRequest{Limit: int(limit), Cursor: cursor}
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,73 @@
+package grpcserver
+
+import (
+	"errors"
+	"log/slog"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/trainee/review-service/internal/model"
+)
+
+// errorDomain — домен ErrorInfo в деталях статуса.
+const errorDomain = "review-service"
+
+// toStatus переводит доменные ошибки в коды gRPC так же, как respondError переводит
+// их в HTTP-статусы. Код ошибки HTTP API передаётся в ErrorInfo.Reason.
+func toStatus(err error) error {
+	var (
+		code   codes.Code
+		reason string
+	)
+	message := err.Error()
+
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		code, reason = codes.NotFound, "NOT_FOUND"
+	case errors.Is(err, model.ErrBadRequest):
+		code, reason = codes.InvalidArgument, "BAD_REQUEST"
+
+	case errors.Is(err, model.ErrTeamExists):
+		code, reason = codes.AlreadyExists, "TEAM_EXISTS"
+	case errors.Is(err, model.ErrPRExists):
+		code, reason = codes.AlreadyExists, "PR_EXISTS"
+	case errors.Is(err, model.ErrPRMerged):
+		code, reason = codes.FailedPrecondition, "PR_MERGED"
+	case errors.Is(err, model.ErrNotAssigned):
+		code, reason = codes.FailedPrecondition, "NOT_ASSIGNED"
+	case errors.Is(err, model.ErrNoCandidate):
+		code, reason = codes.FailedPrecondition, "NO_CANDIDATE"
+	case errors.Is(err, model.ErrNotEmpty):
+		code, reason = codes.FailedPrecondition, "NOT_EMPTY"
+	case errors.Is(err, model.ErrUserInUse):
+		code, reason = codes.FailedPrecondition, "USER_IN_USE"
+	case errors.Is(err, model.ErrIdempotencyInProgress):
+		code, reason = codes.Aborted, "IDEMPOTENCY_IN_PROGRESS"
+	case errors.Is(err, model.ErrIdempotencyKeyReused):
+		code, reason = codes.InvalidArgument, "IDEMPOTENCY_KEY_REUSED"
+
+	case errors.Is(err, model.ErrUnauthorized):
+		code, reason = codes.Unauthenticated, "UNAUTHORIZED"
+	case errors.Is(err, model.ErrInsufficientScope):
+		code, reason = codes.PermissionDenied, "INSUFFICIENT_SCOPE"
+	case errors.Is(err, model.ErrForbidden):
+		code, reason = codes.PermissionDenied, "FORBIDDEN"
+
+	// Версия ресурса изменилась: клиент перечитывает его и повторяет вызов.
+	case errors.Is(err, model.ErrPreconditionFailed):
+		code, reason = codes.Aborted, "PRECONDITION_FAILED"
+
+	default:
+		slog.Error("Internal Server Error", "error", err)
+		code, reason = codes.Internal, "INTERNAL_ERROR"
+		message = "An unexpected error occurred"
+	}
+
+	st := status.New(code, message)
+	if withDetails, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
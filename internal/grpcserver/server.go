@@ -0,0 +1,305 @@
+// Package grpcserver реализует gRPC API (proto/review/v1) поверх service.Service.
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/events"
+	"github.com/trainee/review-service/internal/model"
+	"github.com/trainee/review-service/internal/service"
+	"github.com/trainee/review-service/internal/tenant"
+	reviewv1 "github.com/trainee/review-service/pkg/api/review/v1"
+)
+
+type Server struct {
+	reviewv1.UnimplementedReviewServiceServer
+
+	service       *service.Service
+	authenticator auth.Authenticator
+	events        *events.Broker
+}
+
+// Option настраивает Server.
+type Option func(*Server)
+
+// WithAuthenticator включает проверку bearer-токенов из метаданных authorization
+// и scopes, как в HTTP API. Без неё все методы открыты.
+func WithAuthenticator(a auth.Authenticator) Option {
+	return func(s *Server) {
+		s.authenticator = a
+	}
+}
+
+// WithEvents включает WatchAssignments; без брокера метод возвращает UNIMPLEMENTED.
+func WithEvents(b *events.Broker) Option {
+	return func(s *Server) {
+		s.events = b
+	}
+}
+
+func NewServer(svc *service.Service, opts ...Option) *Server {
+	s := &Server{service: svc}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GRPCServer создаёт grpc.Server с аутентификацией и регистрирует в нём сервис.
+func (s *Server) GRPCServer(opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(s.unaryInterceptor),
+		grpc.ChainStreamInterceptor(s.streamInterceptor),
+	)
+	srv := grpc.NewServer(opts...)
+	reviewv1.RegisterReviewServiceServer(srv, s)
+	return srv
+}
+
+// --- Teams & Users ---
+
+func (s *Server) CreateTeam(ctx context.Context, req *reviewv1.CreateTeamRequest) (*reviewv1.Team, error) {
+	team, err := teamFromProto(req.GetTeam())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if err := s.service.CreateTeam(ctx, team); err != nil {
+		return nil, toStatus(err)
+	}
+	return teamToProto(&team), nil
+}
+
+func (s *Server) GetTeam(ctx context.Context, req *reviewv1.GetTeamRequest) (*reviewv1.Team, error) {
+	if req.GetTeamName() == "" {
+		return nil, toStatus(model.ErrBadRequest)
+	}
+	team, err := s.service.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return teamToProto(team), nil
+}
+
+func (s *Server) GetUser(ctx context.Context, req *reviewv1.GetUserRequest) (*reviewv1.User, error) {
+	if req.GetUserId() == "" {
+		return nil, toStatus(model.ErrBadRequest)
+	}
+	user, err := s.service.GetUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return userDetailsToProto(*user), nil
+}
+
+func (s *Server) ListUsers(ctx context.Context, req *reviewv1.ListUsersRequest) (*reviewv1.ListUsersResponse, error) {
+	filter := model.UserFilter{
+		TeamName:       req.GetTeamName(),
+		IsActive:       req.IsActive,
+		UsernamePrefix: req.GetUsernamePrefix(),
+	}
+	page, err := s.service.ListUsers(ctx, filter, pageFromProto(req.GetLimit(), req.GetCursor()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &reviewv1.ListUsersResponse{NextCursor: page.NextCursor}
+	for _, u := range page.Users {
+		resp.Users = append(resp.Users, userDetailsToProto(u))
+	}
+	return resp, nil
+}
+
+func (s *Server) SetUserActive(ctx context.Context, req *reviewv1.SetUserActiveRequest) (*reviewv1.User, error) {
+	if req.GetUserId() == "" {
+		return nil, toStatus(model.ErrBadRequest)
+	}
+	user, err := s.service.SetUserActiveStatus(withExpectedVersion(ctx, req.GetExpectedVersion()), req.GetUserId(), req.GetIsActive())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return userToProto(*user), nil
+}
+
+func (s *Server) AddTeamMember(ctx context.Context, req *reviewv1.AddTeamMemberRequest) (*reviewv1.User, error) {
+	m := req.GetMember()
+	if strings.TrimSpace(req.GetTeamName()) == "" || strings.TrimSpace(m.GetUserId()) == "" || strings.TrimSpace(m.GetUsername()) == "" {
+		return nil, toStatus(model.ErrBadRequest)
+	}
+	member := model.TeamMember{UserID: m.GetUserId(), Username: m.GetUsername(), IsActive: m.GetIsActive()}
+	user, err := s.service.AddTeamMember(withExpectedVersion(ctx, req.GetExpectedVersion()), req.GetTeamName(), member)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return userToProto(*user), nil
+}
+
+func (s *Server) RemoveTeamMember(ctx context.Context, req *reviewv1.RemoveTeamMemberRequest) (*emptypb.Empty, error) {
+	if req.GetTeamName() == "" || req.GetUserId() == "" {
+		return nil, toStatus(model.ErrBadRequest)
+	}
+	if err := s.service.RemoveTeamMember(withExpectedVersion(ctx, req.GetExpectedVersion()), req.GetTeamName(), req.GetUserId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) GetUserReviews(ctx context.Context, req *reviewv1.GetUserReviewsRequest) (*reviewv1.ListPullRequestsResponse, error) {
+	if req.GetUserId() == "" {
+		return nil, toStatus(model.ErrBadRequest)
+	}
+	// Как в HTTP API: по умолчанию только открытые PR.
+	filter := model.ReviewFilter{UserID: req.GetUserId(), Status: model.PROpen}
+	switch {
+	case req.GetAllStatuses():
+		filter.Status = ""
+	case req.GetStatus() != reviewv1.PullRequestStatus_PULL_REQUEST_STATUS_UNSPECIFIED:
+		filter.Status = statusFromProto(req.GetStatus())
+	}
+	page, err := s.service.GetUserReviewPRs(ctx, filter, pageFromProto(req.GetLimit(), req.GetCursor()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &reviewv1.ListPullRequestsResponse{NextCursor: page.NextCursor}
+	for _, pr := range page.PullRequests {
+		resp.PullRequests = append(resp.PullRequests, &reviewv1.PullRequest{
+			PullRequestId:   pr.ID,
+			PullRequestName: pr.Name,
+			AuthorId:        pr.AuthorID,
+			Status:          statusToProto(pr.Status),
+		})
+	}
+	return resp, nil
+}
+
+// --- Pull Requests ---
+
+func (s *Server) CreatePullRequest(ctx context.Context, req *reviewv1.CreatePullRequestRequest) (*reviewv1.PullRequest, error) {
+	if req.GetPullRequestId() == "" || req.GetPullRequestName() == "" || req.GetAuthorId() == "" {
+		return nil, toStatus(model.ErrBadRequest)
+	}
+	pr, err := s.service.CreatePullRequest(ctx, req.GetPullRequestId(), req.GetPullRequestName(), req.GetAuthorId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return prToProto(pr), nil
+}
+
+func (s *Server) GetPullRequest(ctx context.Context, req *reviewv1.GetPullRequestRequest) (*reviewv1.PullRequest, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, toStatus(model.ErrBadRequest)
+	}
+	pr, err := s.service.GetPullRequest(ctx, req.GetPullRequestId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return prToProto(pr), nil
+}
+
+func (s *Server) ListPullRequests(ctx context.Context, req *reviewv1.ListPullRequestsRequest) (*reviewv1.ListPullRequestsResponse, error) {
+	filter := model.PRFilter{
+		Status:       statusFromProto(req.GetStatus()),
+		AuthorID:     req.GetAuthorId(),
+		ReviewerID:   req.GetReviewerId(),
+		TeamName:     req.GetTeamName(),
+		NameContains: req.GetName(),
+	}
+	page, err := s.service.ListPullRequests(ctx, filter, pageFromProto(req.GetLimit(), req.GetCursor()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &reviewv1.ListPullRequestsResponse{NextCursor: page.NextCursor}
+	for i := range page.PullRequests {
+		resp.PullRequests = append(resp.PullRequests, prToProto(&page.PullRequests[i]))
+	}
+	return resp, nil
+}
+
+func (s *Server) MergePullRequest(ctx context.Context, req *reviewv1.MergePullRequestRequest) (*reviewv1.PullRequest, error) {
+	if req.GetPullRequestId() == "" {
+		return nil, toStatus(model.ErrBadRequest)
+	}
+	pr, err := s.service.MergePullRequest(withExpectedVersion(ctx, req.GetExpectedVersion()), req.GetPullRequestId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return prToProto(pr), nil
+}
+
+func (s *Server) ReassignReviewer(ctx context.Context, req *reviewv1.ReassignReviewerRequest) (*reviewv1.ReassignReviewerResponse, error) {
+	if req.GetPullRequestId() == "" || req.GetOldUserId() == "" {
+		return nil, toStatus(model.ErrBadRequest)
+	}
+	pr, replacedBy, err := s.service.ReassignReviewer(withExpectedVersion(ctx, req.GetExpectedVersion()), req.GetPullRequestId(), req.GetOldUserId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &reviewv1.ReassignReviewerResponse{PullRequest: prToProto(pr), ReplacedBy: replacedBy}, nil
+}
+
+// --- Events ---
+
+// WatchAssignments передаёт события назначения организации вызывающего. Поток
+// завершается, когда клиент отключается, отстаёт или сервер останавливается.
+func (s *Server) WatchAssignments(req *reviewv1.WatchAssignmentsRequest, stream grpc.ServerStreamingServer[reviewv1.AssignmentEvent]) error {
+	if s.events == nil {
+		return s.UnimplementedReviewServiceServer.WatchAssignments(req, stream)
+	}
+	ctx := stream.Context()
+	org := tenant.FromContext(ctx)
+	for e := range s.events.Subscribe(ctx, org, events.DefaultBuffer) {
+		if !matches(e, req) {
+			continue
+		}
+		if err := stream.Send(eventToProto(e)); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() == nil {
+		slog.InfoContext(ctx, "Assignment stream closed by server", "org", org)
+	}
+	return nil
+}
+
+func matches(e events.Event, req *reviewv1.WatchAssignmentsRequest) bool {
+	if !e.IsAssignment() {
+		return false
+	}
+	if req.GetReviewerId() != "" && !e.Involves(req.GetReviewerId()) {
+		return false
+	}
+	return req.GetTeamName() == "" || e.TeamName == req.GetTeamName()
+}
+
+func eventToProto(e events.Event) *reviewv1.AssignmentEvent {
+	out := &reviewv1.AssignmentEvent{
+		Id:            e.ID,
+		PullRequestId: e.PullRequestID,
+		AuthorId:      e.AuthorID,
+		TeamName:      e.TeamName,
+		Reviewers:     e.Reviewers,
+		OldReviewerId: e.OldReviewerID,
+		NewReviewerId: e.NewReviewerID,
+		OccurredAt:    timestamppb.New(e.OccurredAt),
+	}
+	switch e.Type {
+	case events.PRAssigned:
+		out.Type = reviewv1.AssignmentEvent_TYPE_ASSIGNED
+	case events.PRReassigned:
+		out.Type = reviewv1.AssignmentEvent_TYPE_REASSIGNED
+	}
+	return out
+}
+
+// withExpectedVersion переносит expected_version в контекст сервиса (аналог If-Match).
+func withExpectedVersion(ctx context.Context, version int64) context.Context {
+	if version <= 0 {
+		return ctx
+	}
+	return service.WithIfMatch(ctx, version)
+}
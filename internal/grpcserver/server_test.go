@@ -0,0 +1,407 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/events"
+	"github.com/trainee/review-service/internal/model"
+	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/service"
+	"github.com/trainee/review-service/internal/tenant"
+	reviewv1 "github.com/trainee/review-service/pkg/api/review/v1"
+)
+
+// fakeRepo хранит команды, пользователей и PR в памяти. Методы, которые gRPC тесты
+// не вызывают, достаются от nil repo.Repository и паникуют.
+type fakeRepo struct {
+	repo.Repository
+
+	mu    sync.Mutex
+	teams map[string]model.Team
+	users map[string]model.User
+	prs   map[string]*model.PullRequest
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{
+		teams: map[string]model.Team{},
+		users: map[string]model.User{},
+		prs:   map[string]*model.PullRequest{},
+	}
+}
+
+func (f *fakeRepo) CreateTeamTx(_ context.Context, team model.Team) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.teams[team.TeamName]; ok {
+		return repo.ErrAlreadyExists
+	}
+	team.Version = 1
+	f.teams[team.TeamName] = team
+	for _, m := range team.Members {
+		f.users[m.UserID] = model.User{UserID: m.UserID, Username: m.Username, TeamName: team.TeamName, IsActive: m.IsActive, Version: 1}
+	}
+	return nil
+}
+
+func (f *fakeRepo) GetTeam(_ context.Context, teamName string) (*model.Team, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	team, ok := f.teams[teamName]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return &team, nil
+}
+
+func (f *fakeRepo) GetUserByID(_ context.Context, userID string) (*model.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return &u, nil
+}
+
+func (f *fakeRepo) ListTeamMembers(_ context.Context, teamName string) ([]model.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var members []model.User
+	for _, u := range f.users {
+		if u.TeamName == teamName {
+			members = append(members, u)
+		}
+	}
+	return members, nil
+}
+
+func (f *fakeRepo) CreatePR(_ context.Context, pr *model.PullRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.prs[pr.ID]; ok {
+		return repo.ErrAlreadyExists
+	}
+	now := time.Now().UTC()
+	pr.CreatedAt = &now
+	pr.Version = 1
+	cp := *pr
+	f.prs[pr.ID] = &cp
+	return nil
+}
+
+func (f *fakeRepo) GetPRByID(_ context.Context, prID string) (*model.PullRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pr, ok := f.prs[prID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	cp := *pr
+	return &cp, nil
+}
+
+func (f *fakeRepo) GetPRByIDForUpdate(ctx context.Context, prID string) (*model.PullRequest, error) {
+	return f.GetPRByID(ctx, prID)
+}
+
+func (f *fakeRepo) UpdatePR(_ context.Context, pr *model.PullRequest) (*model.PullRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	current, ok := f.prs[pr.ID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	if current.Version != pr.Version {
+		return nil, repo.ErrVersionConflict
+	}
+	cp := *pr
+	cp.Version++
+	f.prs[pr.ID] = &cp
+	out := cp
+	return &out, nil
+}
+
+func (f *fakeRepo) WithTransaction(_ context.Context, fn func(tx repo.TxRepository) error) error {
+	return fn(f)
+}
+
+func (f *fakeRepo) UpsertUser(_ context.Context, u model.User) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.teams[u.TeamName]; !ok {
+		return repo.ErrNotFound
+	}
+	u.Version = f.users[u.UserID].Version + 1
+	f.users[u.UserID] = u
+	return nil
+}
+
+func (f *fakeRepo) DeleteUser(_ context.Context, userID string, expectedVersion int64) (*model.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	if expectedVersion != 0 && u.Version != expectedVersion {
+		return nil, repo.ErrVersionConflict
+	}
+	for _, pr := range f.prs {
+		if pr.AuthorID == userID {
+			return nil, repo.ErrInUse
+		}
+		for _, r := range pr.AssignedReviewers {
+			if r == userID {
+				return nil, repo.ErrInUse
+			}
+		}
+	}
+	delete(f.users, userID)
+	return &u, nil
+}
+
+func (f *fakeRepo) EnsureTeam(context.Context, string) error { return nil }
+func (f *fakeRepo) IsEmpty(context.Context) (bool, error)    { return len(f.teams) == 0, nil }
+func (f *fakeRepo) WithSavepoint(ctx context.Context, fn func(tx repo.TxRepository) error) error {
+	return fn(f)
+}
+
+// staticAuth принимает токены из карты token → Principal.
+type staticAuth map[string]*auth.Principal
+
+func (a staticAuth) Authenticate(_ context.Context, token string) (*auth.Principal, error) {
+	if p, ok := a[token]; ok {
+		return p, nil
+	}
+	return nil, auth.ErrInvalidCredentials
+}
+
+func startServer(t *testing.T, opts ...Option) (reviewv1.ReviewServiceClient, *events.Broker) {
+	t.Helper()
+	broker := events.NewBroker()
+	svc := service.NewService(newFakeRepo(), service.WithEvents(broker))
+	srv := NewServer(svc, append(opts, WithEvents(broker))...).GRPCServer()
+
+	ln := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(func() {
+		broker.Close()
+		srv.GracefulStop()
+	})
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return ln.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return reviewv1.NewReviewServiceClient(conn), broker
+}
+
+func withToken(ctx context.Context, token string, kv ...string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, append([]string{"authorization", "Bearer " + token}, kv...)...)
+}
+
+func reason(t *testing.T, err error) string {
+	t.Helper()
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+func TestToStatus(t *testing.T) {
+	cases := []struct {
+		err    error
+		code   codes.Code
+		reason string
+	}{
+		{model.ErrNotFound, codes.NotFound, "NOT_FOUND"},
+		{fmt.Errorf("%w: bad limit", model.ErrBadRequest), codes.InvalidArgument, "BAD_REQUEST"},
+		{model.ErrTeamExists, codes.AlreadyExists, "TEAM_EXISTS"},
+		{model.ErrPRExists, codes.AlreadyExists, "PR_EXISTS"},
+		{model.ErrPRMerged, codes.FailedPrecondition, "PR_MERGED"},
+		{model.ErrNotAssigned, codes.FailedPrecondition, "NOT_ASSIGNED"},
+		{model.ErrNoCandidate, codes.FailedPrecondition, "NO_CANDIDATE"},
+		{model.ErrUserInUse, codes.FailedPrecondition, "USER_IN_USE"},
+		{model.ErrUnauthorized, codes.Unauthenticated, "UNAUTHORIZED"},
+		{model.ErrInsufficientScope, codes.PermissionDenied, "INSUFFICIENT_SCOPE"},
+		{model.ErrForbidden, codes.PermissionDenied, "FORBIDDEN"},
+		{model.ErrPreconditionFailed, codes.Aborted, "PRECONDITION_FAILED"},
+		{errors.New("db is down"), codes.Internal, "INTERNAL_ERROR"},
+	}
+	for _, tc := range cases {
+		err := toStatus(tc.err)
+		require.Equal(t, tc.code, status.Code(err), tc.err.Error())
+		require.Equal(t, tc.reason, reason(t, err))
+	}
+	// Внутренние подробности не уходят клиенту.
+	require.Equal(t, "An unexpected error occurred", status.Convert(toStatus(errors.New("db is down"))).Message())
+}
+
+func TestPullRequestLifecycle(t *testing.T) {
+	client, _ := startServer(t)
+	ctx := context.Background()
+
+	team, err := client.CreateTeam(ctx, &reviewv1.CreateTeamRequest{Team: &reviewv1.Team{
+		TeamName: "backend",
+		Members: []*reviewv1.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+			{UserId: "u2", Username: "Bob", IsActive: true},
+		},
+	}})
+	require.NoError(t, err)
+	require.Len(t, team.Members, 2)
+
+	_, err = client.CreateTeam(ctx, &reviewv1.CreateTeamRequest{Team: &reviewv1.Team{TeamName: "backend"}})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = client.CreateTeam(ctx, &reviewv1.CreateTeamRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	pr, err := client.CreatePullRequest(ctx, &reviewv1.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1"})
+	require.NoError(t, err)
+	require.Equal(t, reviewv1.PullRequestStatus_PULL_REQUEST_STATUS_OPEN, pr.Status)
+	require.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+	require.NotNil(t, pr.CreatedAt)
+
+	// expected_version работает как If-Match.
+	_, err = client.MergePullRequest(ctx, &reviewv1.MergePullRequestRequest{PullRequestId: "pr-1", ExpectedVersion: pr.Version + 1})
+	require.Equal(t, codes.Aborted, status.Code(err))
+	merged, err := client.MergePullRequest(ctx, &reviewv1.MergePullRequestRequest{PullRequestId: "pr-1", ExpectedVersion: pr.Version})
+	require.NoError(t, err)
+	require.Equal(t, reviewv1.PullRequestStatus_PULL_REQUEST_STATUS_MERGED, merged.Status)
+
+	_, err = client.ReassignReviewer(ctx, &reviewv1.ReassignReviewerRequest{PullRequestId: "pr-1", OldUserId: "u2"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Equal(t, "PR_MERGED", reason(t, err))
+
+	_, err = client.GetPullRequest(ctx, &reviewv1.GetPullRequestRequest{PullRequestId: "missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestTeamMembership(t *testing.T) {
+	client, _ := startServer(t)
+	ctx := context.Background()
+
+	_, err := client.CreateTeam(ctx, &reviewv1.CreateTeamRequest{Team: &reviewv1.Team{
+		TeamName: "backend",
+		Members:  []*reviewv1.TeamMember{{UserId: "u1", Username: "Alice", IsActive: true}},
+	}})
+	require.NoError(t, err)
+
+	user, err := client.AddTeamMember(ctx, &reviewv1.AddTeamMemberRequest{
+		TeamName: "backend",
+		Member:   &reviewv1.TeamMember{UserId: "u2", Username: "Bob", IsActive: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "backend", user.TeamName)
+	_, err = client.AddTeamMember(ctx, &reviewv1.AddTeamMemberRequest{TeamName: "backend", Member: &reviewv1.TeamMember{UserId: "u3"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.AddTeamMember(ctx, &reviewv1.AddTeamMemberRequest{TeamName: "missing", Member: &reviewv1.TeamMember{UserId: "u3", Username: "Carol"}})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// Автора PR удалить нельзя, только деактивировать.
+	_, err = client.CreatePullRequest(ctx, &reviewv1.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1"})
+	require.NoError(t, err)
+	_, err = client.RemoveTeamMember(ctx, &reviewv1.RemoveTeamMemberRequest{TeamName: "backend", UserId: "u1"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Equal(t, "USER_IN_USE", reason(t, err))
+
+	_, err = client.AddTeamMember(ctx, &reviewv1.AddTeamMemberRequest{
+		TeamName: "backend",
+		Member:   &reviewv1.TeamMember{UserId: "u3", Username: "Carol", IsActive: true},
+	})
+	require.NoError(t, err)
+	_, err = client.RemoveTeamMember(ctx, &reviewv1.RemoveTeamMemberRequest{TeamName: "backend", UserId: "u3", ExpectedVersion: 7})
+	require.Equal(t, codes.Aborted, status.Code(err))
+	_, err = client.RemoveTeamMember(ctx, &reviewv1.RemoveTeamMemberRequest{TeamName: "backend", UserId: "u3"})
+	require.NoError(t, err)
+	_, err = client.RemoveTeamMember(ctx, &reviewv1.RemoveTeamMemberRequest{TeamName: "backend", UserId: "u3"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestAuthentication(t *testing.T) {
+	client, _ := startServer(t, WithAuthenticator(staticAuth{
+		"reader": {Subject: "t1", Method: "api_token", Scopes: []string{auth.ScopeRead}, Org: "acme"},
+		"writer": {Subject: "t2", Method: "api_token", Scopes: []string{auth.ScopeTeamsWrite}},
+	}))
+	ctx := context.Background()
+
+	_, err := client.GetTeam(ctx, &reviewv1.GetTeamRequest{TeamName: "backend"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.GetTeam(withToken(ctx, "unknown"), &reviewv1.GetTeamRequest{TeamName: "backend"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Scope проверяется по методу.
+	_, err = client.GetTeam(withToken(ctx, "reader"), &reviewv1.GetTeamRequest{TeamName: "backend"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.CreateTeam(withToken(ctx, "reader"), &reviewv1.CreateTeamRequest{Team: &reviewv1.Team{TeamName: "backend"}})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Equal(t, "INSUFFICIENT_SCOPE", reason(t, err))
+	_, err = client.RemoveTeamMember(withToken(ctx, "reader"), &reviewv1.RemoveTeamMemberRequest{TeamName: "backend", UserId: "u1"})
+	require.Equal(t, "INSUFFICIENT_SCOPE", reason(t, err))
+	_, err = client.GetTeam(withToken(ctx, "writer"), &reviewv1.GetTeamRequest{TeamName: "backend"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Чужая организация недоступна.
+	_, err = client.GetTeam(withToken(ctx, "reader", "x-org-id", "globex"), &reviewv1.GetTeamRequest{TeamName: "backend"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Equal(t, "FORBIDDEN", reason(t, err))
+}
+
+func TestWatchAssignments(t *testing.T) {
+	client, broker := startServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.CreateTeam(ctx, &reviewv1.CreateTeamRequest{Team: &reviewv1.Team{
+		TeamName: "backend",
+		Members: []*reviewv1.TeamMember{
+			{UserId: "u1", Username: "Alice", IsActive: true},
+			{UserId: "u2", Username: "Bob", IsActive: true},
+		},
+	}})
+	require.NoError(t, err)
+
+	stream, err := client.WatchAssignments(ctx, &reviewv1.WatchAssignmentsRequest{ReviewerId: "u2"})
+	require.NoError(t, err)
+	// Дожидаемся подписки, иначе событие может уйти раньше.
+	require.Eventually(t, func() bool { return broker.Subscribers() == 1 }, time.Second, 10*time.Millisecond)
+
+	// События другой организации и других ревьюеров отфильтровываются.
+	broker.Publish(events.Event{Type: events.PRAssigned, Org: "globex", PullRequestID: "other-org", Reviewers: []string{"u2"}})
+	broker.Publish(events.Event{Type: events.PRAssigned, Org: tenant.Default, PullRequestID: "other-reviewer", Reviewers: []string{"u3"}})
+
+	_, err = client.CreatePullRequest(ctx, &reviewv1.CreatePullRequestRequest{PullRequestId: "pr-1", PullRequestName: "Add search", AuthorId: "u1"})
+	require.NoError(t, err)
+
+	e, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, reviewv1.AssignmentEvent_TYPE_ASSIGNED, e.Type)
+	require.Equal(t, "pr-1", e.PullRequestId)
+	require.Equal(t, "backend", e.TeamName)
+	require.Equal(t, []string{"u2"}, e.Reviewers)
+
+	// Остановка брокера завершает поток.
+	broker.Close()
+	_, err = stream.Recv()
+	require.Error(t, err)
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/trainee/review-service/internal/api"
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/model"
+)
+
+// DefaultPublicPaths доступны без аутентификации, если не задано иное.
+var DefaultPublicPaths = []string{"/health", "/livez", "/readyz", "/metrics"}
+
+// AuthConfig включает аутентификацию запросов.
+type AuthConfig struct {
+	// Authenticator проверяет bearer-токены из заголовка Authorization.
+	Authenticator auth.Authenticator
+	// Tokens включает эндпоинты управления API токенами (scope admin).
+	Tokens *auth.Tokens
+	// PublicPaths доступны без токена; nil — DefaultPublicPaths.
+	PublicPaths []string
+}
+
+// Option настраивает Handler.
+type Option func(*Handler)
+
+// WithAuth включает проверку токенов и scopes. Без неё все эндпоинты открыты.
+func WithAuth(cfg AuthConfig) Option {
+	return func(h *Handler) {
+		if cfg.PublicPaths == nil {
+			cfg.PublicPaths = DefaultPublicPaths
+		}
+		h.auth = &cfg
+	}
+}
+
+func (h *Handler) isPublic(path string) bool {
+	return slices.Contains(h.auth.PublicPaths, path)
+}
+
+// authenticate определяет вызывающего по заголовку Authorization: Bearer <token>.
+// На публичных путях токен не обязателен, но если передан — проверяется.
+func (h *Handler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.auth == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header := r.Header.Get("Authorization")
+		if header == "" && h.isPublic(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		scheme, token, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			respondUnauthorized(w, r)
+			return
+		}
+
+		principal, err := h.auth.Authenticator.Authenticate(r.Context(), strings.TrimSpace(token))
+		if errors.Is(err, auth.ErrInvalidCredentials) {
+			respondUnauthorized(w, r)
+			return
+		}
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+		if entry := accessEntryFrom(r.Context()); entry != nil {
+			entry.principal = principal
+		}
+		next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), principal)))
+	})
+}
+
+// requireScope пропускает запрос, только если у вызывающего есть scope.
+func (h *Handler) requireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if h.auth == nil || h.isPublic(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			principal := auth.FromContext(r.Context())
+			if principal == nil {
+				respondUnauthorized(w, r)
+				return
+			}
+			if !principal.HasScope(scope) {
+				slog.WarnContext(r.Context(), "Insufficient scope", "subject", principal.Subject, "required", scope, "path", r.URL.Path)
+				respondError(w, r, model.ErrInsufficientScope)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func respondUnauthorized(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="review-service"`)
+	respondError(w, r, model.ErrUnauthorized)
+}
+
+// --- API токены ---
+
+// POST /auth/token/create
+func (h *Handler) CreateToken(ctx context.Context, req api.CreateTokenRequestObject) (api.CreateTokenResponseObject, error) {
+	var ttl time.Duration
+	if expiresIn := deref(req.Body.ExpiresIn); expiresIn != "" {
+		var err error
+		if ttl, err = time.ParseDuration(expiresIn); err != nil || ttl <= 0 {
+			return nil, model.Invalid("expires_in", "format", "must be a positive duration such as 720h")
+		}
+	}
+	scopes := make([]string, 0, len(req.Body.Scopes))
+	for _, s := range req.Body.Scopes {
+		scopes = append(scopes, string(s))
+	}
+
+	value, token, err := h.auth.Tokens.Issue(ctx, req.Body.Name, scopes, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return api.CreateToken201JSONResponse{Token: value, ApiToken: apiToken(*token)}, nil
+}
+
+// GET /auth/token/list
+func (h *Handler) ListTokens(ctx context.Context, _ api.ListTokensRequestObject) (api.ListTokensResponseObject, error) {
+	tokens, err := h.auth.Tokens.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list := api.ListTokens200JSONResponse{Tokens: make([]api.APIToken, 0, len(tokens))}
+	for _, t := range tokens {
+		list.Tokens = append(list.Tokens, apiToken(t))
+	}
+	return list, nil
+}
+
+// POST /auth/token/revoke
+func (h *Handler) RevokeToken(ctx context.Context, req api.RevokeTokenRequestObject) (api.RevokeTokenResponseObject, error) {
+	if err := notBlank("token_id", req.Body.TokenId); err != nil {
+		return nil, err
+	}
+	if err := h.auth.Tokens.Revoke(ctx, req.Body.TokenId); err != nil {
+		return nil, err
+	}
+	return api.RevokeToken200JSONResponse{TokenId: req.Body.TokenId, Revoked: true}, nil
+}
@@ -0,0 +1,281 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/stretchr/testify/require"
+
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/model"
+	"github.com/trainee/review-service/internal/repository/memory"
+	"github.com/trainee/review-service/internal/service"
+)
+
+// --- Контрактные тесты: каждый пример из openapi.yml проходит через SetupRouter,
+// а ответ проверяется по схеме. Расхождение кода и спецификации роняет сборку.
+
+// contractExample — один запрос, собранный из примеров спецификации.
+type contractExample struct {
+	name        string
+	method      string
+	path        string // шаблон из спецификации
+	url         string // путь с подставленными параметрами
+	contentType string
+	body        []byte
+}
+
+// contractServer поднимает сервер с аутентификацией и данными, на которые
+// ссылаются примеры: команда backend (u1–u5), PR pr-1001 с ревьюверами u2 и u3,
+// роль team_lead у u2 и токен tok_3q2-7wLVQZs.
+func contractServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+	ctx := context.Background()
+
+	store := &fakeTokenStore{tokens: map[string]model.APIToken{
+		"contract": {ID: "tok_3q2-7wLVQZs", Name: "ci", Scopes: []string{auth.ScopeRead}, CreatedAt: time.Now()},
+	}}
+	tokens := auth.NewTokens(store)
+	adminToken, _, err := tokens.Issue(ctx, "bootstrap", []string{auth.ScopeAdmin}, 0)
+	require.NoError(t, err)
+
+	fake := memory.New()
+	team := model.Team{TeamName: "backend"}
+	for i, name := range []string{"Alice", "Bob", "Carol", "Dave", "Eve"} {
+		team.Members = append(team.Members, model.TeamMember{UserID: fmt.Sprintf("u%d", i+1), Username: name, IsActive: true})
+	}
+	require.NoError(t, fake.CreateTeamTx(ctx, team))
+	require.NoError(t, fake.CreatePR(ctx, &model.PullRequest{
+		ID:                "pr-1001",
+		Name:              "Add caching",
+		AuthorID:          "u1",
+		AssignedReviewers: []string{"u2", "u3"},
+	}))
+	require.NoError(t, fake.AssignRole(ctx, model.RoleAssignment{UserID: "u2", Role: model.RoleTeamLead, TeamName: "backend"}))
+
+	h := NewHandler(service.NewService(fake), WithAuth(AuthConfig{Authenticator: tokens, Tokens: tokens}))
+	return httptest.NewServer(h.SetupRouter()), adminToken
+}
+
+// contractExamples собирает запросы для каждой операции спецификации:
+// параметры пути и обязательные параметры берутся из их примеров, тело — из
+// каждого примера requestBody. Потоковые ответы (text/event-stream) и операции
+// с телом без примеров пропускаются.
+func contractExamples(t *testing.T, spec *openapi3.T) []contractExample {
+	t.Helper()
+	var result []contractExample
+	for _, path := range spec.Paths.InMatchingOrder() {
+		item := spec.Paths.Value(path)
+		for method, op := range item.Operations() {
+			if streaming(op) {
+				continue
+			}
+			target := path
+			query := url.Values{}
+			for _, p := range operationParameters(item, op) {
+				if !p.Required && p.In != openapi3.ParameterInPath {
+					continue
+				}
+				value := p.Example
+				if value == nil && p.Schema != nil {
+					value = p.Schema.Value.Example
+				}
+				require.NotNil(t, value, "%s %s: parameter %s has no example", method, path, p.Name)
+				switch p.In {
+				case openapi3.ParameterInPath:
+					target = strings.ReplaceAll(target, "{"+p.Name+"}", url.PathEscape(fmt.Sprint(value)))
+				case openapi3.ParameterInQuery:
+					query.Set(p.Name, fmt.Sprint(value))
+				default:
+					t.Fatalf("%s %s: required %s parameter %s is not supported", method, path, p.In, p.Name)
+				}
+			}
+			if len(query) > 0 {
+				target += "?" + query.Encode()
+			}
+			base := contractExample{name: method + " " + path, method: method, path: path, url: target}
+
+			if op.RequestBody == nil {
+				result = append(result, base)
+				continue
+			}
+			for contentType, media := range op.RequestBody.Value.Content {
+				for name, value := range mediaExamples(media) {
+					ex := base
+					ex.name += " " + contentType + " " + name
+					ex.contentType = contentType
+					ex.body = encodeExample(t, contentType, value)
+					result = append(result, ex)
+				}
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].name < result[j].name })
+	return result
+}
+
+// operationParameters объединяет параметры пути и операции; параметр операции
+// заменяет одноимённый параметр пути.
+func operationParameters(item *openapi3.PathItem, op *openapi3.Operation) []*openapi3.Parameter {
+	var params []*openapi3.Parameter
+	for _, ref := range item.Parameters {
+		if op.Parameters.GetByInAndName(ref.Value.In, ref.Value.Name) == nil {
+			params = append(params, ref.Value)
+		}
+	}
+	for _, ref := range op.Parameters {
+		params = append(params, ref.Value)
+	}
+	return params
+}
+
+func streaming(op *openapi3.Operation) bool {
+	for _, resp := range op.Responses.Map() {
+		if resp.Value != nil && resp.Value.Content.Get("text/event-stream") != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func mediaExamples(media *openapi3.MediaType) map[string]any {
+	examples := make(map[string]any)
+	if media.Example != nil {
+		examples["example"] = media.Example
+	}
+	for name, ex := range media.Examples {
+		examples[name] = ex.Value.Value
+	}
+	return examples
+}
+
+func encodeExample(t *testing.T, contentType string, value any) []byte {
+	if s, ok := value.(string); ok && contentType != "application/json" {
+		return []byte(s)
+	}
+	b, err := json.Marshal(value)
+	require.NoError(t, err)
+	return b
+}
+
+// loadSpec читает openapi.yml из корня репозитория, а не встроенную копию:
+// в internal/api попадают не все операции.
+func loadSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+	spec, err := openapi3.NewLoader().LoadFromFile("../../openapi.yml")
+	require.NoError(t, err)
+	return spec
+}
+
+func TestOpenAPISpecIsValid(t *testing.T) {
+	spec := loadSpec(t)
+	require.NoError(t, spec.Validate(context.Background(), openapi3.EnableExamplesValidation()))
+}
+
+// Встроенная в internal/api копия должна совпадать с openapi.yml: иначе код
+// сгенерирован из устаревшей спецификации и его нужно обновить (make openapi).
+func TestGeneratedSpecUpToDate(t *testing.T) {
+	spec := loadSpec(t)
+	asJSON := func(v any) string {
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+		return string(b)
+	}
+	// Генератор отбрасывает компоненты исключённых операций, поэтому
+	// сравниваются только попавшие во встроенную копию.
+	for name, schema := range openAPISpec.Components.Schemas {
+		require.JSONEq(t, asJSON(spec.Components.Schemas[name]), asJSON(schema), "schema %s", name)
+	}
+	for name, param := range openAPISpec.Components.Parameters {
+		require.JSONEq(t, asJSON(spec.Components.Parameters[name]), asJSON(param), "parameter %s", name)
+	}
+	for name, resp := range openAPISpec.Components.Responses {
+		require.JSONEq(t, asJSON(spec.Components.Responses[name]), asJSON(resp), "response %s", name)
+	}
+	for _, path := range openAPISpec.Paths.InMatchingOrder() {
+		for method, op := range openAPISpec.Paths.Value(path).Operations() {
+			require.NotNil(t, spec.Paths.Value(path), path)
+			source := spec.Paths.Value(path).GetOperation(method)
+			require.NotNil(t, source, "%s %s", method, path)
+			// Генератор пишет operationId с заглавной буквы.
+			want, got := *source, *op
+			require.True(t, strings.EqualFold(want.OperationID, got.OperationID), "%s %s", method, path)
+			want.OperationID, got.OperationID = "", ""
+			require.JSONEq(t, asJSON(want), asJSON(got), "%s %s", method, path)
+		}
+	}
+}
+
+func TestContractExamples(t *testing.T) {
+	spec := loadSpec(t)
+	examples := contractExamples(t, spec)
+	require.NotEmpty(t, examples)
+
+	for _, ex := range examples {
+		t.Run(ex.name, func(t *testing.T) {
+			srv, adminToken := contractServer(t)
+			defer srv.Close()
+
+			req, err := http.NewRequest(ex.method, srv.URL+ex.url, bytes.NewReader(ex.body))
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+			if ex.contentType != "" {
+				req.Header.Set("Content-Type", ex.contentType)
+			}
+			resp, err := srv.Client().Do(req)
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+
+			require.Less(t, resp.StatusCode, 300, "documented example must succeed: %s", body)
+			validateContractResponse(t, spec, ex, req, resp, body)
+		})
+	}
+}
+
+// validateContractResponse проверяет, что статус описан в спецификации, а
+// заголовки и JSON-тело соответствуют схеме ответа.
+func validateContractResponse(t *testing.T, spec *openapi3.T, ex contractExample, req *http.Request, resp *http.Response, body []byte) {
+	t.Helper()
+	item := spec.Paths.Value(ex.path)
+	op := item.GetOperation(ex.method)
+
+	// Тела не в JSON (экспорт NDJSON) проверяются только по статусу и заголовкам.
+	jsonBody := strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json")
+	req.Body = io.NopCloser(bytes.NewReader(ex.body))
+	input := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request: req,
+			Route: &routers.Route{
+				Spec:      spec,
+				Path:      ex.path,
+				PathItem:  item,
+				Method:    ex.method,
+				Operation: op,
+			},
+			Options: &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+		},
+		Status: resp.StatusCode,
+		Header: resp.Header,
+		Body:   io.NopCloser(bytes.NewReader(body)),
+		Options: &openapi3filter.Options{
+			IncludeResponseStatus: true,
+			ExcludeResponseBody:   !jsonBody,
+		},
+	}
+	require.NoError(t, openapi3filter.ValidateResponse(context.Background(), input), "response: %s", body)
+}
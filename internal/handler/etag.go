@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/trainee/review-service/internal/model"
+	"github.com/trainee/review-service/internal/service"
+)
+
+// etag форматирует версию ресурса как сильный ETag.
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// notModified сообщает, что клиент передал в If-None-Match ETag текущей
+// версии ресурса и вместо тела нужно ответить 304.
+func notModified(ifNoneMatch *string, version int64) bool {
+	if ifNoneMatch == nil {
+		return false
+	}
+	tag := etag(version)
+	for _, candidate := range strings.Split(*ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == tag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// withIfMatch переносит версию из If-Match в контекст сервиса.
+// "*" и отсутствие заголовка означают мутацию без проверки версии;
+// слабые и нераспознанные ETag ни с чем не совпадают.
+func withIfMatch(ctx context.Context, ifMatch *string) (context.Context, error) {
+	header := strings.TrimSpace(deref(ifMatch))
+	if header == "" || header == "*" {
+		return ctx, nil
+	}
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return nil, model.ErrPreconditionFailed
+	}
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return nil, model.ErrPreconditionFailed
+	}
+	return service.WithIfMatch(ctx, version), nil
+}
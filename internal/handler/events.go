@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trainee/review-service/internal/events"
+	"github.com/trainee/review-service/internal/model"
+	"github.com/trainee/review-service/internal/tenant"
+)
+
+// sseHeartbeat — период комментариев-пингов: не даёт прокси закрыть простаивающее
+// соединение и обнаруживает отключившихся клиентов.
+var sseHeartbeat = 15 * time.Second
+
+// WithEvents включает поток событий GET /events (Server-Sent Events).
+func WithEvents(b *events.Broker) Option {
+	return func(h *Handler) {
+		h.events = b
+	}
+}
+
+// GET /events
+// Поток событий организации: назначения, переназначения, merge и смена активности.
+// Фильтры user_id (ревьюер, автор или пользователь, чья активность изменилась)
+// и team_name. После разрыва клиент передаёт Last-Event-ID (EventSource делает это
+// сам) и получает пропущенные события из буфера; если они уже вытеснены, первым
+// приходит событие stream.reset.
+func (h *Handler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	userID := strings.TrimSpace(q.Get("user_id"))
+	teamName := strings.TrimSpace(q.Get("team_name"))
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		// Заголовок нельзя задать при первом подключении EventSource.
+		lastEventID = q.Get("last_event_id")
+	}
+	var (
+		lastID  uint64
+		resumed = lastEventID != ""
+	)
+	if resumed {
+		id, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			respondError(w, r, model.Invalid("Last-Event-ID", "type", "must be a non-negative integer"))
+			return
+		}
+		lastID = id
+	}
+
+	ctx := r.Context()
+	org := tenant.FromContext(ctx)
+	var (
+		replay   []events.Event
+		ch       <-chan events.Event
+		complete = true
+	)
+	if resumed {
+		replay, ch, complete = h.events.SubscribeAfter(ctx, org, lastID, events.DefaultBuffer)
+	} else {
+		ch = h.events.Subscribe(ctx, org, events.DefaultBuffer)
+	}
+
+	rc := http.NewResponseController(w)
+	// Поток живёт дольше WriteTimeout сервера; ошибку (например, в httptest) игнорируем.
+	_ = rc.SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	matches := func(e events.Event) bool {
+		return (userID == "" || e.Concerns(userID)) &&
+			(teamName == "" || e.TeamName == teamName)
+	}
+
+	if !complete {
+		slog.InfoContext(ctx, "Event stream resumed with a gap", "last_event_id", lastID, "org", org)
+		if _, err := io.WriteString(w, "event: stream.reset\ndata: {\"reason\":\"events after Last-Event-ID are no longer available\"}\n\n"); err != nil {
+			return
+		}
+	}
+	for _, e := range replay {
+		if matches(e) {
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		slog.ErrorContext(ctx, "Event stream is not supported by the response writer", "error", err)
+		return
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-ch:
+			// Канал закрыт: сервер останавливается или клиент не успевал читать.
+			if !ok {
+				return
+			}
+			if !matches(e) {
+				continue
+			}
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w io.Writer, e events.Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err
+}
@@ -1,76 +1,153 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/trainee/review-service/internal/api"
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/events"
+	"github.com/trainee/review-service/internal/health"
+	"github.com/trainee/review-service/internal/importer"
 	"github.com/trainee/review-service/internal/model"
 	"github.com/trainee/review-service/internal/service"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Handler struct {
-	service *service.Service
+	service     *service.Service
+	auth        *AuthConfig
+	idempotency *IdempotencyConfig
+	events      *events.Broker
+	noMetrics   bool
+	health      *health.Checker
 }
 
-func NewHandler(s *service.Service) *Handler {
-	return &Handler{service: s}
+func NewHandler(s *service.Service, opts ...Option) *Handler {
+	h := &Handler{service: s}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 func (h *Handler) SetupRouter() chi.Router {
 	r := chi.NewRouter()
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
+	// Спан запроса, request ID и access log — первыми: по ним связываются
+	// все спаны и строки журнала запроса.
+	r.Use(traceRequests)
+	r.Use(requestID)
+	r.Use(accessLog)
+	r.Use(recoverer)
 	r.Use(middleware.SetHeader("Content-Type", "application/json"))
-	r.Use(prometheusMiddleware)
+	if !h.noMetrics {
+		r.Use(prometheusMiddleware)
+	}
+	// Аутентификация (если включена); scopes проверяются на уровне маршрутов.
+	r.Use(h.authenticate)
+	// Организация запроса: из учётных данных или заголовка X-Org-ID.
+	r.Use(h.resolveOrg)
+	// Маршруты v1, заменённые ресурсами v2, отдают Deprecation и Sunset.
+	r.Use(deprecateV1)
+
+	// Обработчики из openapi.yml: параметры и тело разбирает internal/api.
+	srv := h.apiServer()
 
 	// Health
-	r.Get("/health", h.Health)
+	r.Get("/health", srv.Health)
+	r.Get("/livez", srv.Livez)
+	r.Get("/readyz", srv.Readyz)
 	// Metrics (Prometheus)
-	r.Handle("/metrics", promhttp.Handler())
+	if !h.noMetrics {
+		r.Handle("/metrics", metricsHandler())
+	}
+
+	// Запросы JSON API проверяются по схеме операции; потоковые маршруты
+	// (импорт, архивы) разбирают тело сами.
+	read := r.With(h.requireScope(auth.ScopeRead), validateRequest)
+	// Мутации команд и PR можно безопасно повторять с Idempotency-Key.
+	teamsWrite := r.With(h.requireScope(auth.ScopeTeamsWrite), h.idempotent, validateRequest)
+	prsWrite := r.With(h.requireScope(auth.ScopePRsWrite), h.idempotent, validateRequest)
+	admin := r.With(h.requireScope(auth.ScopeAdmin))
 
 	// Teams
-	r.Post("/team/add", h.CreateTeam)
-	r.Get("/team/get", h.GetTeam)
+	teamsWrite.Post("/team/add", srv.CreateTeam)
+	read.Get("/team/get", srv.GetTeam)
 
 	// Users
-	r.Post("/users/setIsActive", h.SetUserActivity)
-	r.Get("/users/getReview", h.GetUserReviews)
+	teamsWrite.Post("/users/setIsActive", srv.SetUserIsActive)
+	read.Get("/users/getReview", srv.GetUserReviews)
+	read.Get("/users/get", srv.GetUser)
+	read.Get("/users/list", srv.ListUsers)
 
 	// PullRequests
-	r.Post("/pullRequest/create", h.CreatePR)
-	r.Post("/pullRequest/merge", h.MergePR)
-	r.Post("/pullRequest/reassign", h.ReassignReviewer)
+	prsWrite.Post("/pullRequest/create", srv.CreatePullRequest)
+	prsWrite.Post("/pullRequest/merge", srv.MergePullRequest)
+	prsWrite.Post("/pullRequest/reassign", srv.ReassignReviewer)
+	read.Get("/pullRequest/get", srv.GetPullRequest)
+	read.Get("/pullRequest/list", srv.ListPullRequests)
+
+	// Roles (назначать может только администратор организации — проверяется в сервисе)
+	teamsWrite.Post("/roles/assign", srv.AssignRole)
+	teamsWrite.Post("/roles/revoke", srv.RevokeRole)
+	read.Get("/roles/list", srv.ListRoles)
+
+	// Events (SSE)
+	if h.events != nil {
+		read.Get("/events", h.StreamEvents)
+	}
+
+	// Bulk import
+	admin.Post("/import", h.Import)
+
+	// Export & restore
+	admin.Get("/export", h.Export)
+	admin.Post("/restore", h.Restore)
+
+	// API tokens
+	if h.auth != nil && h.auth.Tokens != nil {
+		tokens := admin.With(validateRequest)
+		tokens.Post("/auth/token/create", srv.CreateToken)
+		tokens.Get("/auth/token/list", srv.ListTokens)
+		tokens.Post("/auth/token/revoke", srv.RevokeToken)
+	}
+
+	// Ресурсный API v2 поверх того же сервиса.
+	r.Route("/v2", func(r chi.Router) { h.routesV2(r, srv) })
 
 	return r
 }
 
 // --- Хелперы для ответов ---
 
-func respondJSON(w http.ResponseWriter, status int, payload interface{}) {
+func respondJSON(w http.ResponseWriter, r *http.Request, status int, payload interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	if payload != nil {
 		if err := json.NewEncoder(w).Encode(payload); err != nil {
-			slog.Error("Failed to encode response", "error", err)
+			slog.ErrorContext(r.Context(), "Failed to encode response", "error", err)
 		}
 	}
 }
 
 type APIErrorResponse struct {
 	Error struct {
-		Code    string `json:"code"`
-		Message string `json:"message"`
+		Code    string             `json:"code"`
+		Message string             `json:"message"`
+		Details []model.FieldError `json:"details,omitempty"`
 	} `json:"error"`
 }
 
 // respondError маппит доменные ошибки на HTTP статусы и коды ошибок OpenAPI.
-func respondError(w http.ResponseWriter, err error) {
+func respondError(w http.ResponseWriter, r *http.Request, err error) {
 	var code string
 	message := err.Error()
 	status := http.StatusInternalServerError
@@ -102,10 +179,40 @@ func respondError(w http.ResponseWriter, err error) {
 	case errors.Is(err, model.ErrNoCandidate):
 		status = http.StatusConflict
 		code = "NO_CANDIDATE"
+	case errors.Is(err, model.ErrNotEmpty):
+		status = http.StatusConflict
+		code = "NOT_EMPTY"
+	case errors.Is(err, model.ErrUserInUse):
+		status = http.StatusConflict
+		code = "USER_IN_USE"
+	case errors.Is(err, model.ErrIdempotencyInProgress):
+		status = http.StatusConflict
+		code = "IDEMPOTENCY_IN_PROGRESS"
+
+	// 422 Unprocessable Entity
+	case errors.Is(err, model.ErrIdempotencyKeyReused):
+		status = http.StatusUnprocessableEntity
+		code = "IDEMPOTENCY_KEY_REUSED"
+
+	// 401 Unauthorized / 403 Forbidden
+	case errors.Is(err, model.ErrUnauthorized):
+		status = http.StatusUnauthorized
+		code = "UNAUTHORIZED"
+	case errors.Is(err, model.ErrInsufficientScope):
+		status = http.StatusForbidden
+		code = "INSUFFICIENT_SCOPE"
+	case errors.Is(err, model.ErrForbidden):
+		status = http.StatusForbidden
+		code = "FORBIDDEN"
+
+	case errors.Is(err, model.ErrPreconditionFailed):
+		status = http.StatusPreconditionFailed
+		code = "PRECONDITION_FAILED"
 
 	// 500 Internal Server Error
 	default:
-		slog.Error("Internal Server Error", "error", err)
+		slog.ErrorContext(r.Context(), "Internal Server Error", "error", err)
+		trace.SpanFromContext(r.Context()).RecordError(err)
 		code = "INTERNAL_ERROR"
 		message = "An unexpected error occurred"
 	}
@@ -113,220 +220,413 @@ func respondError(w http.ResponseWriter, err error) {
 	payload := APIErrorResponse{}
 	payload.Error.Code = code
 	payload.Error.Message = message
-	respondJSON(w, status, payload)
+	var invalid *model.ValidationError
+	if errors.As(err, &invalid) {
+		payload.Error.Details = invalid.Details
+	}
+	respondJSON(w, r, status, payload)
+}
+
+// parseOptionalBool читает необязательный булев query-параметр.
+func parseOptionalBool(r *http.Request, name string) (*bool, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, model.Invalid(name, "type", "must be a boolean")
+	}
+	return &v, nil
 }
 
-func decode(r *http.Request, v interface{}) error {
-	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
-		return model.ErrBadRequest
+// page собирает параметры курсорной пагинации.
+func page(limit *int, cursor *string) (model.PageRequest, error) {
+	p := model.PageRequest{Cursor: deref(cursor)}
+	if limit != nil {
+		if *limit <= 0 {
+			return p, model.Invalid("limit", "minimum", "must be at least 1")
+		}
+		p.Limit = *limit
 	}
-	return nil
+	return p, nil
 }
 
-// GET /health
-func (h *Handler) Health(w http.ResponseWriter, _ *http.Request) {
-	respondJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+// GET /livez
+// Процесс жив, пока отвечает; зависимости проверяет /readyz.
+func (h *Handler) Livez(context.Context, api.LivezRequestObject) (api.LivezResponseObject, error) {
+	return api.Livez200JSONResponse{Status: api.HealthStatusStatusOk}, nil
+}
+
+// GET /health — то же, что /livez, для совместимости.
+func (h *Handler) Health(context.Context, api.HealthRequestObject) (api.HealthResponseObject, error) {
+	return api.Health200JSONResponse{Status: api.HealthStatusStatusOk}, nil
 }
 
 // --- Реализация обработчиков ---
 
 // POST /team/add
-func (h *Handler) CreateTeam(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		TeamName string `json:"team_name"`
-		Members  []struct {
-			UserID   string `json:"user_id"`
-			Username string `json:"username"`
-			IsActive *bool  `json:"is_active"`
-		} `json:"members"`
-	}
-	if err := decode(r, &req); err != nil {
-		respondError(w, err)
-		return
+func (h *Handler) CreateTeam(ctx context.Context, req api.CreateTeamRequestObject) (api.CreateTeamResponseObject, error) {
+	team, err := newTeam(*req.Body)
+	if err != nil {
+		return nil, err
 	}
+	if err := h.service.CreateTeam(ctx, team); err != nil {
+		return nil, err
+	}
+	return api.CreateTeam201JSONResponse{Team: apiTeam(team)}, nil
+}
 
-	if strings.TrimSpace(req.TeamName) == "" {
-		respondError(w, model.ErrBadRequest)
-		return
+// newTeam проверяет тело создания команды (общее для v1 и v2).
+func newTeam(body api.NewTeam) (model.Team, error) {
+	if err := notBlank("team_name", body.TeamName); err != nil {
+		return model.Team{}, err
 	}
 
-	seen := make(map[string]struct{}, len(req.Members))
+	members := deref(body.Members)
+	seen := make(map[string]struct{}, len(members))
 	team := model.Team{
-		TeamName: req.TeamName,
-		Members:  make([]model.TeamMember, 0, len(req.Members)),
+		TeamName: body.TeamName,
+		Members:  make([]model.TeamMember, 0, len(members)),
 	}
-
-	for _, m := range req.Members {
-		if strings.TrimSpace(m.UserID) == "" || strings.TrimSpace(m.Username) == "" || m.IsActive == nil {
-			respondError(w, model.ErrBadRequest)
-			return
+	for i, m := range members {
+		field := fmt.Sprintf("members.%d.", i)
+		if err := notBlank(field+"user_id", m.UserId, field+"username", m.Username); err != nil {
+			return model.Team{}, err
 		}
-		if _, ok := seen[m.UserID]; ok {
-			respondError(w, model.ErrBadRequest)
-			return
+		if _, ok := seen[m.UserId]; ok {
+			return model.Team{}, model.Invalid(field+"user_id", "unique", "duplicate user_id "+m.UserId)
 		}
-		seen[m.UserID] = struct{}{}
+		seen[m.UserId] = struct{}{}
 		team.Members = append(team.Members, model.TeamMember{
-			UserID:   m.UserID,
+			UserID:   m.UserId,
 			Username: m.Username,
-			IsActive: *m.IsActive,
+			IsActive: m.IsActive,
 		})
 	}
-
-	if err := h.service.CreateTeam(r.Context(), team); err != nil {
-		respondError(w, err)
-		return
-	}
-
-	respondJSON(w, http.StatusCreated, map[string]interface{}{"team": team})
+	return team, nil
 }
 
 // GET /team/get
-func (h *Handler) GetTeam(w http.ResponseWriter, r *http.Request) {
-	teamName := r.URL.Query().Get("team_name")
-	if teamName == "" {
-		respondError(w, model.ErrBadRequest)
-		return
+func (h *Handler) GetTeam(ctx context.Context, req api.GetTeamRequestObject) (api.GetTeamResponseObject, error) {
+	team, err := h.service.GetTeam(ctx, req.Params.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	if notModified(req.Params.IfNoneMatch, team.Version) {
+		return api.GetTeam304Response{Headers: api.NotModifiedResponseHeaders{ETag: etag(team.Version)}}, nil
 	}
+	return api.GetTeam200JSONResponse{
+		Body:    apiTeam(*team),
+		Headers: api.GetTeam200ResponseHeaders{ETag: etag(team.Version)},
+	}, nil
+}
 
-	team, err := h.service.GetTeam(r.Context(), teamName)
+// POST /users/setIsActive
+func (h *Handler) SetUserIsActive(ctx context.Context, req api.SetUserIsActiveRequestObject) (api.SetUserIsActiveResponseObject, error) {
+	if err := notBlank("user_id", req.Body.UserId); err != nil {
+		return nil, err
+	}
+	ctx, err := withIfMatch(ctx, req.Params.IfMatch)
 	if err != nil {
-		respondError(w, err)
-		return
+		return nil, err
 	}
-	respondJSON(w, http.StatusOK, team)
+	user, err := h.service.SetUserActiveStatus(ctx, req.Body.UserId, req.Body.IsActive)
+	if err != nil {
+		return nil, err
+	}
+	return api.SetUserIsActive200JSONResponse{
+		Body:    api.UserResponse{User: apiUser(*user)},
+		Headers: api.SetUserIsActive200ResponseHeaders{ETag: etag(user.Version)},
+	}, nil
 }
 
-// POST /users/setIsActive
-func (h *Handler) SetUserActivity(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		UserID   string `json:"user_id"`
-		IsActive *bool  `json:"is_active"`
+// GET /users/get
+func (h *Handler) GetUser(ctx context.Context, req api.GetUserRequestObject) (api.GetUserResponseObject, error) {
+	user, err := h.service.GetUser(ctx, req.Params.UserId)
+	if err != nil {
+		return nil, err
 	}
-	if err := decode(r, &req); err != nil {
-		respondError(w, err)
-		return
+	if notModified(req.Params.IfNoneMatch, user.Version) {
+		return api.GetUser304Response{Headers: api.NotModifiedResponseHeaders{ETag: etag(user.Version)}}, nil
 	}
+	return api.GetUser200JSONResponse{
+		Body:    api.UserDetailsResponse{User: apiUserDetails(*user)},
+		Headers: api.GetUser200ResponseHeaders{ETag: etag(user.Version)},
+	}, nil
+}
 
-	if strings.TrimSpace(req.UserID) == "" || req.IsActive == nil {
-		respondError(w, model.ErrBadRequest)
-		return
+// GET /users/list
+func (h *Handler) ListUsers(ctx context.Context, req api.ListUsersRequestObject) (api.ListUsersResponseObject, error) {
+	users, err := h.listUsers(ctx, req.Params)
+	if err != nil {
+		return nil, err
 	}
+	return api.ListUsers200JSONResponse(users), nil
+}
 
-	user, err := h.service.SetUserActiveStatus(r.Context(), req.UserID, *req.IsActive)
+// listUsers отдаёт страницу справочника пользователей (общее для v1 и v2).
+func (h *Handler) listUsers(ctx context.Context, params api.ListUsersParams) (api.UserPage, error) {
+	p, err := page(params.Limit, params.Cursor)
 	if err != nil {
-		respondError(w, err)
-		return
+		return api.UserPage{}, err
 	}
-	respondJSON(w, http.StatusOK, map[string]interface{}{"user": user})
+	filter := model.UserFilter{
+		TeamName:       deref(params.TeamName),
+		IsActive:       params.IsActive,
+		UsernamePrefix: deref(params.UsernamePrefix),
+	}
+	users, err := h.service.ListUsers(ctx, filter, p)
+	if err != nil {
+		return api.UserPage{}, err
+	}
+	return apiUserPage(*users), nil
 }
 
 // GET /users/getReview
-func (h *Handler) GetUserReviews(w http.ResponseWriter, r *http.Request) {
-	userID := r.URL.Query().Get("user_id")
-	if userID == "" {
-		respondError(w, model.ErrBadRequest)
-		return
+func (h *Handler) GetUserReviews(ctx context.Context, req api.GetUserReviewsRequestObject) (api.GetUserReviewsResponseObject, error) {
+	prs, err := h.userReviews(ctx, req.Params.UserId, req.Params.Status, req.Params.Limit, req.Params.Cursor)
+	if err != nil {
+		return nil, err
 	}
+	return api.GetUserReviews200JSONResponse(prs), nil
+}
 
-	prs, err := h.service.GetUserReviewPRs(r.Context(), userID)
+// userReviews отдаёт страницу PR, где пользователь назначен ревьюером (общее для v1 и v2).
+func (h *Handler) userReviews(ctx context.Context, userID string, status *api.ReviewStatusFilter, limit *int, cursor *string) (api.ReviewPage, error) {
+	p, err := page(limit, cursor)
 	if err != nil {
-		respondError(w, err)
-		return
+		return api.ReviewPage{}, err
 	}
 
-	// Гарантируем, что возвращается пустой массив, а не null
-	if prs == nil {
-		prs = []model.PullRequestShort{}
+	// По умолчанию показываем только открытые PR; ALL снимает фильтр по статусу.
+	filter := model.ReviewFilter{UserID: userID, Status: model.PROpen}
+	switch s := deref(status); s {
+	case "":
+	case api.ReviewStatusALL:
+		filter.Status = ""
+	default:
+		filter.Status = model.PRStatus(s)
 	}
 
-	response := map[string]interface{}{
-		"user_id":       userID,
-		"pull_requests": prs,
+	prs, err := h.service.GetUserReviewPRs(ctx, filter, p)
+	if err != nil {
+		return api.ReviewPage{}, err
 	}
-	respondJSON(w, http.StatusOK, response)
+	return apiReviewPage(userID, *prs), nil
 }
 
-// POST /pullRequest/create
-func (h *Handler) CreatePR(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		ID       string `json:"pull_request_id"`
-		Name     string `json:"pull_request_name"`
-		AuthorID string `json:"author_id"`
-	}
-	if err := decode(r, &req); err != nil {
-		respondError(w, err)
-		return
+// GET /roles/list
+func (h *Handler) ListRoles(ctx context.Context, req api.ListRolesRequestObject) (api.ListRolesResponseObject, error) {
+	roles, err := h.service.ListRoles(ctx, deref(req.Params.UserId))
+	if err != nil {
+		return nil, err
+	}
+	list := api.ListRoles200JSONResponse{Roles: make([]api.RoleAssignment, 0, len(roles))}
+	for _, role := range roles {
+		list.Roles = append(list.Roles, apiRole(role))
 	}
+	return list, nil
+}
 
-	if strings.TrimSpace(req.ID) == "" || strings.TrimSpace(req.AuthorID) == "" || strings.TrimSpace(req.Name) == "" {
-		respondError(w, model.ErrBadRequest)
-		return
+// POST /roles/assign
+func (h *Handler) AssignRole(ctx context.Context, req api.AssignRoleRequestObject) (api.AssignRoleResponseObject, error) {
+	if err := h.service.AssignRole(ctx, modelRole(*req.Body)); err != nil {
+		return nil, err
 	}
+	return api.AssignRole200JSONResponse{Role: *req.Body}, nil
+}
 
-	pr, err := h.service.CreatePullRequest(r.Context(), req.ID, req.Name, req.AuthorID)
-	if err != nil {
-		respondError(w, err)
-		return
+// POST /roles/revoke
+func (h *Handler) RevokeRole(ctx context.Context, req api.RevokeRoleRequestObject) (api.RevokeRoleResponseObject, error) {
+	if err := h.service.RevokeRole(ctx, modelRole(*req.Body)); err != nil {
+		return nil, err
 	}
-	respondJSON(w, http.StatusCreated, map[string]interface{}{"pr": pr})
+	return api.RevokeRole200JSONResponse{Role: *req.Body, Revoked: true}, nil
 }
 
-// POST /pullRequest/merge
-func (h *Handler) MergePR(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		ID string `json:"pull_request_id"`
+// maxImportBodySize ограничивает размер тела запроса импорта.
+const maxImportBodySize = 32 << 20
+
+// POST /import
+func (h *Handler) Import(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	formatName := q.Get("format")
+	if formatName == "" {
+		formatName = "jsonl"
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "text/csv") {
+			formatName = "csv"
+		}
 	}
-	if err := decode(r, &req); err != nil {
-		respondError(w, err)
+	format, err := importer.ParseFormat(formatName)
+	if err != nil {
+		respondError(w, r, model.Invalid("format", "enum", err.Error()))
+		return
+	}
+	dryRun, err := parseOptionalBool(r, "dry_run")
+	if err != nil {
+		respondError(w, r, err)
 		return
 	}
 
-	if strings.TrimSpace(req.ID) == "" {
-		respondError(w, model.ErrBadRequest)
+	batch, err := importer.Decode(http.MaxBytesReader(w, r.Body, maxImportBodySize), format)
+	if err != nil {
+		if limitErr := bodyTooLarge(err); limitErr != nil {
+			err = limitErr
+		} else {
+			err = fmt.Errorf("%w: %v", model.ErrBadRequest, err)
+		}
+		respondError(w, r, err)
 		return
 	}
 
-	pr, err := h.service.MergePullRequest(r.Context(), req.ID)
+	opts := model.ImportOptions{Mode: model.ImportMode(q.Get("mode"))}
+	if dryRun != nil {
+		opts.DryRun = *dryRun
+	}
+	report, err := h.service.Import(r.Context(), *batch, opts)
 	if err != nil {
-		respondError(w, err)
+		respondError(w, r, err)
 		return
 	}
-	respondJSON(w, http.StatusOK, map[string]interface{}{"pr": pr})
+	respondJSON(w, r, http.StatusOK, report)
 }
 
-// POST /pullRequest/reassign
-func (h *Handler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		PRID string `json:"pull_request_id"`
-		// Следуем схеме OpenAPI (old_user_id), но также поддерживаем пример old_reviewer_id для совместимости.
-		OldUserID string `json:"old_user_id"`
-		LegacyOld string `json:"old_reviewer_id"`
+// GET /export
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", `attachment; filename="review-service-export.jsonl"`)
+	w.WriteHeader(http.StatusOK)
+
+	// Статус уже отправлен: при ошибке архив останется без итоговой строки,
+	// и restore отвергнет его как обрезанный.
+	if err := h.service.Export(r.Context(), w); err != nil {
+		slog.ErrorContext(r.Context(), "Export failed", "error", err)
 	}
+}
 
-	if err := decode(r, &req); err != nil {
-		respondError(w, err)
+// maxRestoreBodySize ограничивает размер архива, принимаемого через HTTP.
+const maxRestoreBodySize = 1 << 30
+
+// POST /restore
+func (h *Handler) Restore(w http.ResponseWriter, r *http.Request) {
+	report, err := h.service.Restore(r.Context(), http.MaxBytesReader(w, r.Body, maxRestoreBodySize))
+	if limitErr := bodyTooLarge(err); limitErr != nil {
+		err = limitErr
+	}
+	if err != nil {
+		respondError(w, r, err)
 		return
 	}
+	respondJSON(w, r, http.StatusOK, report)
+}
 
-	if req.OldUserID == "" {
-		req.OldUserID = req.LegacyOld
+// POST /pullRequest/create
+func (h *Handler) CreatePullRequest(ctx context.Context, req api.CreatePullRequestRequestObject) (api.CreatePullRequestResponseObject, error) {
+	body := req.Body
+	if err := notBlank("pull_request_id", body.PullRequestId, "pull_request_name", body.PullRequestName, "author_id", body.AuthorId); err != nil {
+		return nil, err
 	}
+	pr, err := h.service.CreatePullRequest(ctx, body.PullRequestId, body.PullRequestName, body.AuthorId)
+	if err != nil {
+		return nil, err
+	}
+	return api.CreatePullRequest201JSONResponse{
+		Body:    api.PullRequestResponse{Pr: apiPullRequest(*pr)},
+		Headers: api.CreatePullRequest201ResponseHeaders{ETag: etag(pr.Version)},
+	}, nil
+}
 
-	if strings.TrimSpace(req.PRID) == "" || strings.TrimSpace(req.OldUserID) == "" {
-		respondError(w, model.ErrBadRequest)
-		return
+// GET /pullRequest/get
+func (h *Handler) GetPullRequest(ctx context.Context, req api.GetPullRequestRequestObject) (api.GetPullRequestResponseObject, error) {
+	pr, err := h.service.GetPullRequest(ctx, req.Params.PullRequestId)
+	if err != nil {
+		return nil, err
+	}
+	if notModified(req.Params.IfNoneMatch, pr.Version) {
+		return api.GetPullRequest304Response{Headers: api.NotModifiedResponseHeaders{ETag: etag(pr.Version)}}, nil
 	}
+	return api.GetPullRequest200JSONResponse{
+		Body:    api.PullRequestResponse{Pr: apiPullRequest(*pr)},
+		Headers: api.GetPullRequest200ResponseHeaders{ETag: etag(pr.Version)},
+	}, nil
+}
 
-	pr, replacedBy, err := h.service.ReassignReviewer(r.Context(), req.PRID, req.OldUserID)
+// GET /pullRequest/list
+func (h *Handler) ListPullRequests(ctx context.Context, req api.ListPullRequestsRequestObject) (api.ListPullRequestsResponseObject, error) {
+	prs, err := h.listPullRequests(ctx, req.Params)
 	if err != nil {
-		respondError(w, err)
-		return
+		return nil, err
 	}
+	return api.ListPullRequests200JSONResponse(prs), nil
+}
 
-	response := map[string]interface{}{
-		"pr":          pr,
-		"replaced_by": replacedBy,
+// listPullRequests отдаёт страницу поиска PR (общее для v1 и v2).
+func (h *Handler) listPullRequests(ctx context.Context, params api.ListPullRequestsParams) (api.PullRequestPage, error) {
+	p, err := page(params.Limit, params.Cursor)
+	if err != nil {
+		return api.PullRequestPage{}, err
+	}
+	filter := model.PRFilter{
+		Status:       model.PRStatus(deref(params.Status)),
+		AuthorID:     deref(params.AuthorId),
+		ReviewerID:   deref(params.ReviewerId),
+		TeamName:     deref(params.TeamName),
+		NameContains: deref(params.Name),
+		CreatedFrom:  params.CreatedFrom,
+		CreatedTo:    params.CreatedTo,
+		MergedFrom:   params.MergedFrom,
+		MergedTo:     params.MergedTo,
+	}
+	prs, err := h.service.ListPullRequests(ctx, filter, p)
+	if err != nil {
+		return api.PullRequestPage{}, err
+	}
+	return apiPullRequestPage(*prs), nil
+}
+
+// POST /pullRequest/merge
+func (h *Handler) MergePullRequest(ctx context.Context, req api.MergePullRequestRequestObject) (api.MergePullRequestResponseObject, error) {
+	if err := notBlank("pull_request_id", req.Body.PullRequestId); err != nil {
+		return nil, err
+	}
+	ctx, err := withIfMatch(ctx, req.Params.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := h.service.MergePullRequest(ctx, req.Body.PullRequestId)
+	if err != nil {
+		return nil, err
+	}
+	return api.MergePullRequest200JSONResponse{
+		Body:    api.PullRequestResponse{Pr: apiPullRequest(*pr)},
+		Headers: api.MergePullRequest200ResponseHeaders{ETag: etag(pr.Version)},
+	}, nil
+}
+
+// POST /pullRequest/reassign
+func (h *Handler) ReassignReviewer(ctx context.Context, req api.ReassignReviewerRequestObject) (api.ReassignReviewerResponseObject, error) {
+	body := req.Body
+	// old_reviewer_id — устаревший синоним old_user_id (см. openapi.yml).
+	oldUserID := deref(body.OldUserId)
+	if oldUserID == "" {
+		oldUserID = deref(body.OldReviewerId)
+	}
+	if err := notBlank("pull_request_id", body.PullRequestId, "old_user_id", oldUserID); err != nil {
+		return nil, err
+	}
+
+	ctx, err := withIfMatch(ctx, req.Params.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+	pr, replacedBy, err := h.service.ReassignReviewer(ctx, body.PullRequestId, oldUserID)
+	if err != nil {
+		return nil, err
 	}
-	respondJSON(w, http.StatusOK, response)
+	return api.ReassignReviewer200JSONResponse{
+		Body:    api.ReassignResult{Pr: apiPullRequest(*pr), ReplacedBy: replacedBy},
+		Headers: api.ReassignReviewer200ResponseHeaders{ETag: etag(pr.Version)},
+	}, nil
 }
@@ -1,199 +1,44 @@
 package handler
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
-	"sort"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
-
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/events"
+	"github.com/trainee/review-service/internal/health"
+	"github.com/trainee/review-service/internal/logging"
 	"github.com/trainee/review-service/internal/model"
 	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/repository/memory"
 	"github.com/trainee/review-service/internal/service"
+	"github.com/trainee/review-service/internal/tenant"
 )
 
-// --- Тестовый in-memory репозиторий, реализующий интерфейс service.Repository.
-
-type fakeRepo struct {
-	mu    sync.Mutex
-	teams map[string]model.Team
-	users map[string]model.User
-	prs   map[string]*model.PullRequest
-}
-
-func newFakeRepo() *fakeRepo {
-	return &fakeRepo{
-		teams: make(map[string]model.Team),
-		users: make(map[string]model.User),
-		prs:   make(map[string]*model.PullRequest),
-	}
-}
-
-func (f *fakeRepo) WithTransaction(_ context.Context, fn func(repo.TxRepository) error) error {
-	return fn(f)
-}
-
-func (f *fakeRepo) CreateTeamTx(_ context.Context, team model.Team) error {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-
-	if _, exists := f.teams[team.TeamName]; exists {
-		return repo.ErrAlreadyExists
-	}
-	f.teams[team.TeamName] = team
-	for _, m := range team.Members {
-		f.users[m.UserID] = model.User{
-			UserID:   m.UserID,
-			Username: m.Username,
-			TeamName: team.TeamName,
-			IsActive: m.IsActive,
-		}
-	}
-	return nil
-}
-
-func (f *fakeRepo) GetTeam(_ context.Context, teamName string) (*model.Team, error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-
-	team, ok := f.teams[teamName]
-	if !ok {
-		return nil, repo.ErrNotFound
-	}
-	return &team, nil
-}
-
-func (f *fakeRepo) SetUserActiveStatus(_ context.Context, userID string, isActive bool) (*model.User, error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-
-	user, ok := f.users[userID]
-	if !ok {
-		return nil, repo.ErrNotFound
-	}
-	user.IsActive = isActive
-	f.users[userID] = user
-	return &user, nil
-}
-
-func (f *fakeRepo) GetUserByID(_ context.Context, userID string) (*model.User, error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-
-	user, ok := f.users[userID]
-	if !ok {
-		return nil, repo.ErrNotFound
-	}
-	cp := user
-	return &cp, nil
-}
-
-func (f *fakeRepo) ListTeamMembers(_ context.Context, teamName string) ([]model.User, error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-
-	var users []model.User
-	for _, u := range f.users {
-		if u.TeamName == teamName {
-			users = append(users, u)
-		}
-	}
-	return users, nil
-}
-
-func (f *fakeRepo) CreatePR(_ context.Context, pr *model.PullRequest) error {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-
-	if _, exists := f.prs[pr.ID]; exists {
-		return repo.ErrAlreadyExists
-	}
-
-	_, ok := f.users[pr.AuthorID]
-	if !ok {
-		return repo.ErrNotFound
-	}
-
-	now := time.Now()
-	pr.Status = model.PROpen
-	pr.CreatedAt = &now
-
-	f.prs[pr.ID] = &model.PullRequest{
-		ID:                pr.ID,
-		Name:              pr.Name,
-		AuthorID:          pr.AuthorID,
-		Status:            pr.Status,
-		AssignedReviewers: pr.AssignedReviewers,
-		CreatedAt:         pr.CreatedAt,
-	}
-	return nil
-}
-
-func (f *fakeRepo) GetPRByID(_ context.Context, prID string) (*model.PullRequest, error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-
-	pr, ok := f.prs[prID]
-	if !ok {
-		return nil, repo.ErrNotFound
-	}
-	clone := *pr
-	return &clone, nil
-}
-
-func (f *fakeRepo) GetPRByIDForUpdate(ctx context.Context, prID string) (*model.PullRequest, error) {
-	return f.GetPRByID(ctx, prID)
-}
-
-func (f *fakeRepo) UpdatePR(_ context.Context, pr *model.PullRequest) (*model.PullRequest, error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	current, ok := f.prs[pr.ID]
-	if !ok {
-		return nil, repo.ErrNotFound
-	}
-	current.Status = pr.Status
-	current.MergedAt = pr.MergedAt
-	current.AssignedReviewers = append([]string(nil), pr.AssignedReviewers...)
-	cp := *current
-	return &cp, nil
-}
-
-func (f *fakeRepo) GetPRsByReviewer(_ context.Context, userID string) ([]model.PullRequestShort, error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-
-	var prs []model.PullRequestShort
-	for _, pr := range f.prs {
-		for _, r := range pr.AssignedReviewers {
-			if r == userID {
-				prs = append(prs, model.PullRequestShort{
-					ID:       pr.ID,
-					Name:     pr.Name,
-					AuthorID: pr.AuthorID,
-					Status:   pr.Status,
-				})
-				break
-			}
-		}
-	}
-	sort.Slice(prs, func(i, j int) bool { return prs[i].ID < prs[j].ID })
-	return prs, nil
-}
-
 // --- Хелперы для HTTP тестов.
 
 func newTestServer() *httptest.Server {
-	fake := newFakeRepo()
-	svc := service.NewService(fake)
+	svc := service.NewService(memory.New())
 	h := NewHandler(svc)
 	return httptest.NewServer(h.SetupRouter())
 }
@@ -374,6 +219,46 @@ func TestHealth(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
+func TestProbes(t *testing.T) {
+	checker := health.NewChecker(time.Second)
+	var dbErr error
+	checker.Add("database", func(context.Context) (map[string]any, error) { return nil, dbErr })
+	srv := httptest.NewServer(NewHandler(service.NewService(memory.New()), WithHealth(checker)).SetupRouter())
+	defer srv.Close()
+	client := srv.Client()
+
+	resp, data := doJSON(t, client, http.MethodGet, srv.URL+"/readyz", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "ready", data["status"])
+	require.Equal(t, "ok", data["checks"].(map[string]any)["database"].(map[string]any)["status"])
+
+	// Отказ БД: не готов, но жив.
+	dbErr = errors.New("connection refused")
+	resp, data = doJSON(t, client, http.MethodGet, srv.URL+"/readyz", nil)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, "not_ready", data["status"])
+	require.Equal(t, "connection refused", data["checks"].(map[string]any)["database"].(map[string]any)["error"])
+	resp, _ = doJSON(t, client, http.MethodGet, srv.URL+"/livez", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Остановка: не готов, даже если зависимости в порядке.
+	dbErr = nil
+	checker.ShutDown()
+	resp, data = doJSON(t, client, http.MethodGet, srv.URL+"/readyz", nil)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Contains(t, data["checks"], "shutdown")
+}
+
+func TestWithoutMetrics(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(service.NewService(memory.New()), WithoutMetrics()).SetupRouter())
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL + "/metrics")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
 func TestSetUserActivityNotFound(t *testing.T) {
 	srv := newTestServer()
 	defer srv.Close()
@@ -415,31 +300,62 @@ func TestGetUserReviews(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 	prs = data["pull_requests"].([]any)
 	require.Empty(t, prs)
+
+	// После merge PR пропадает из выборки по умолчанию (OPEN), но доступен через status.
+	_, _ = doJSON(t, client, http.MethodPost, srv.URL+"/pullRequest/create", map[string]any{
+		"pull_request_id":   "pr-10",
+		"pull_request_name": "feat",
+		"author_id":         "u1",
+	})
+	_, _ = doJSON(t, client, http.MethodPost, srv.URL+"/pullRequest/merge", map[string]any{
+		"pull_request_id": "pr-9",
+	})
+	resp, data = doJSON(t, client, http.MethodGet, srv.URL+"/users/getReview?user_id=u2", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, data["pull_requests"].([]any), 1)
+
+	resp, data = doJSON(t, client, http.MethodGet, srv.URL+"/users/getReview?user_id=u2&status=MERGED", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "pr-9", data["pull_requests"].([]any)[0].(map[string]any)["pull_request_id"])
+
+	resp, data = doJSON(t, client, http.MethodGet, srv.URL+"/users/getReview?user_id=u2&status=ALL&limit=1", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, data["pull_requests"].([]any), 1)
+	cursor := data["next_cursor"].(string)
+
+	resp, data = doJSON(t, client, http.MethodGet, srv.URL+"/users/getReview?user_id=u2&status=ALL&limit=1&cursor="+cursor, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, data["pull_requests"].([]any), 1)
+	require.Nil(t, data["next_cursor"])
+
+	resp, _ = doJSON(t, client, http.MethodGet, srv.URL+"/users/getReview?user_id=u2&status=CLOSED", nil)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
 }
 
 func TestRespondErrorMapping(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	rec := httptest.NewRecorder()
-	respondError(rec, model.ErrPRExists)
+	respondError(rec, req, model.ErrPRExists)
 	require.Equal(t, http.StatusConflict, rec.Code)
 
 	rec = httptest.NewRecorder()
-	respondError(rec, model.ErrBadRequest)
+	respondError(rec, req, model.ErrBadRequest)
 	require.Equal(t, http.StatusBadRequest, rec.Code)
 
 	rec = httptest.NewRecorder()
-	respondError(rec, model.ErrTeamExists)
+	respondError(rec, req, model.ErrTeamExists)
 	require.Equal(t, http.StatusConflict, rec.Code)
 
 	rec = httptest.NewRecorder()
-	respondError(rec, model.ErrPRMerged)
+	respondError(rec, req, model.ErrPRMerged)
 	require.Equal(t, http.StatusConflict, rec.Code)
 
 	rec = httptest.NewRecorder()
-	respondError(rec, model.ErrNoCandidate)
+	respondError(rec, req, model.ErrNoCandidate)
 	require.Equal(t, http.StatusConflict, rec.Code)
 
 	rec = httptest.NewRecorder()
-	respondError(rec, errors.New("boom"))
+	respondError(rec, req, errors.New("boom"))
 	require.Equal(t, http.StatusInternalServerError, rec.Code)
 }
 
@@ -489,9 +405,92 @@ func TestValidationErrors(t *testing.T) {
 	})
 	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
 
-	// respondJSON encode error path
-	rec := httptest.NewRecorder()
-	respondJSON(rec, http.StatusOK, map[string]any{"ch": make(chan int)})
+	// Ошибка кодирования ответа попадает в журнал с request_id запроса.
+	logs := captureLogs(t)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(logging.NewContext(req.Context(), "req-encode"))
+	respondJSON(httptest.NewRecorder(), req, http.StatusOK, map[string]any{"ch": make(chan int)})
+	lines := logLines(t, logs)
+	require.Len(t, lines, 1)
+	require.Equal(t, "Failed to encode response", lines[0]["msg"])
+	require.Equal(t, "req-encode", lines[0]["request_id"])
+}
+
+// doRaw отправляет тело как есть и возвращает details ошибки.
+func doRaw(t *testing.T, srv *httptest.Server, method, path, body string) (*http.Response, []model.FieldError) {
+	t.Helper()
+	req, err := http.NewRequest(method, srv.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var payload APIErrorResponse
+	_ = json.NewDecoder(resp.Body).Decode(&payload)
+	if resp.StatusCode == http.StatusBadRequest {
+		require.Equal(t, "BAD_REQUEST", payload.Error.Code)
+	}
+	return resp, payload.Error.Details
+}
+
+func TestFieldValidationDetails(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	rules := func(details []model.FieldError) []string {
+		result := make([]string, 0, len(details))
+		for _, d := range details {
+			require.NotEmpty(t, d.Message)
+			result = append(result, d.Field+":"+d.Rule)
+		}
+		return result
+	}
+
+	cases := []struct {
+		name         string
+		method, path string
+		body         string
+		want         []string
+	}{
+		{"missing fields", http.MethodPost, "/users/setIsActive", `{}`,
+			[]string{"is_active:required", "user_id:required"}},
+		{"unknown field", http.MethodPost, "/pullRequest/merge", `{"pull_request_id":"pr-1","force":true}`,
+			[]string{"force:additionalProperties"}},
+		{"nested field", http.MethodPost, "/team/add", `{"team_name":"devs","members":[{"user_id":" u1","username":"a","is_active":"yes"}]}`,
+			[]string{"members.0.is_active:type", "members.0.user_id:pattern"}},
+		{"id too long", http.MethodPost, "/pullRequest/merge", `{"pull_request_id":"` + strings.Repeat("x", 129) + `"}`,
+			[]string{"pull_request_id:maxLength"}},
+		{"blank name", http.MethodPost, "/pullRequest/create", `{"pull_request_id":"pr-1","pull_request_name":"  ","author_id":"u1"}`,
+			[]string{"pull_request_name:pattern"}},
+		{"duplicate member", http.MethodPost, "/team/add", `{"team_name":"devs","members":[{"user_id":"u1","username":"a","is_active":true},{"user_id":"u1","username":"b","is_active":true}]}`,
+			[]string{"members.1.user_id:unique"}},
+		{"trailing data", http.MethodPost, "/pullRequest/merge", `{"pull_request_id":"pr-1"} {}`,
+			[]string{"body:trailingData"}},
+		{"malformed json", http.MethodPost, "/pullRequest/merge", `{"pull_request_id":`,
+			[]string{"body:syntax"}},
+		{"body too large", http.MethodPost, "/team/add", `{"team_name":"` + strings.Repeat("x", maxBodySize) + `"}`,
+			[]string{"body:maxBodySize"}},
+		{"query parameter", http.MethodGet, "/users/list?limit=0&is_active=maybe", ``,
+			[]string{"is_active:type", "limit:minimum"}},
+		{"enum", http.MethodPatch, "/v2/pull-requests/pr-1", `{"status":"OPEN"}`,
+			[]string{"status:enum"}},
+		{"path parameter", http.MethodGet, "/v2/users/%20u1", ``,
+			[]string{"user_id:pattern"}},
+		{"unknown field v2", http.MethodPost, "/v2/teams", `{"team_name":"devs","members":null,"extra":1}`,
+			[]string{"extra:additionalProperties"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, details := doRaw(t, srv, tc.method, tc.path, tc.body)
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			require.Equal(t, tc.want, rules(details))
+		})
+	}
+
+	// Корректный запрос после отклонённых проходит.
+	resp, details := doRaw(t, srv, http.MethodPost, "/team/add", `{"team_name":"devs"}`+"\n")
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.Empty(t, details)
 }
 
 func TestCreateTeamDuplicate(t *testing.T) {
@@ -597,3 +596,1072 @@ func TestReassignNotFoundPR(t *testing.T) {
 	require.Equal(t, http.StatusNotFound, resp.StatusCode)
 	require.Equal(t, "NOT_FOUND", data["error"].(map[string]any)["code"])
 }
+
+func TestUserDirectory(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	_, _ = doJSON(t, client, http.MethodPost, srv.URL+"/team/add", map[string]any{
+		"team_name": "dir",
+		"members": []map[string]any{
+			{"user_id": "u1", "username": "alice", "is_active": true},
+			{"user_id": "u2", "username": "albert", "is_active": true},
+			{"user_id": "u3", "username": "bob", "is_active": false},
+		},
+	})
+	_, _ = doJSON(t, client, http.MethodPost, srv.URL+"/pullRequest/create", map[string]any{
+		"pull_request_id":   "pr-dir",
+		"pull_request_name": "feat",
+		"author_id":         "u1",
+	})
+
+	resp, data := doJSON(t, client, http.MethodGet, srv.URL+"/users/get?user_id=u2", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	user := data["user"].(map[string]any)
+	require.Equal(t, "dir", user["team_name"])
+	require.Equal(t, float64(1), user["open_review_count"])
+
+	resp, data = doJSON(t, client, http.MethodGet, srv.URL+"/users/list?team_name=dir&is_active=true&username_prefix=al&limit=1", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, data["users"].([]any), 1)
+	cursor := data["next_cursor"].(string)
+
+	resp, data = doJSON(t, client, http.MethodGet, srv.URL+"/users/list?team_name=dir&is_active=true&username_prefix=al&limit=1&cursor="+cursor, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "u2", data["users"].([]any)[0].(map[string]any)["user_id"])
+	require.Nil(t, data["next_cursor"])
+
+	resp, _ = doJSON(t, client, http.MethodGet, srv.URL+"/users/get", nil)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = doJSON(t, client, http.MethodGet, srv.URL+"/users/get?user_id=ghost", nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp, _ = doJSON(t, client, http.MethodGet, srv.URL+"/users/list?is_active=maybe", nil)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = doJSON(t, client, http.MethodGet, srv.URL+"/users/list?limit=-1", nil)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetAndListPRs(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	_, _ = doJSON(t, client, http.MethodPost, srv.URL+"/team/add", map[string]any{
+		"team_name": "search",
+		"members": []map[string]any{
+			{"user_id": "u1", "username": "alice", "is_active": true},
+			{"user_id": "u2", "username": "bob", "is_active": true},
+		},
+	})
+	for _, id := range []string{"pr-a", "pr-b"} {
+		_, _ = doJSON(t, client, http.MethodPost, srv.URL+"/pullRequest/create", map[string]any{
+			"pull_request_id":   id,
+			"pull_request_name": "search " + id,
+			"author_id":         "u1",
+		})
+	}
+	_, _ = doJSON(t, client, http.MethodPost, srv.URL+"/pullRequest/merge", map[string]any{
+		"pull_request_id": "pr-a",
+	})
+
+	resp, data := doJSON(t, client, http.MethodGet, srv.URL+"/pullRequest/get?pull_request_id=pr-a", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "MERGED", data["pr"].(map[string]any)["status"])
+
+	resp, data = doJSON(t, client, http.MethodGet, srv.URL+"/pullRequest/list?status=OPEN&reviewer_id=u2&team_name=search&name=search", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	prs := data["pull_requests"].([]any)
+	require.Len(t, prs, 1)
+	require.Equal(t, "pr-b", prs[0].(map[string]any)["pull_request_id"])
+
+	resp, data = doJSON(t, client, http.MethodGet, srv.URL+"/pullRequest/list?limit=1", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, data["pull_requests"].([]any), 1)
+	require.NotEmpty(t, data["next_cursor"])
+
+	resp, _ = doJSON(t, client, http.MethodGet, srv.URL+"/pullRequest/get?pull_request_id=missing", nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp, _ = doJSON(t, client, http.MethodGet, srv.URL+"/pullRequest/get", nil)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = doJSON(t, client, http.MethodGet, srv.URL+"/pullRequest/list?status=CLOSED", nil)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = doJSON(t, client, http.MethodGet, srv.URL+"/pullRequest/list?created_from=yesterday", nil)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = doJSON(t, client, http.MethodGet, srv.URL+"/pullRequest/list?cursor=bm9wZQ", nil)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestImport(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	csvBody := "kind,team_name,user_id,username,is_active\n" +
+		"team,imported,,,\n" +
+		"user,imported,u1,alice,true\n" +
+		"user,imported,u2,bob,\n"
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/import?mode=best_effort", bytes.NewBufferString(csvBody))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "text/csv")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var report model.ImportReport
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	require.True(t, report.Committed)
+	require.Equal(t, 2, report.Applied)
+	require.Len(t, report.Errors, 1)
+	require.Equal(t, 4, report.Errors[0].Row)
+
+	resp, data := doJSON(t, client, http.MethodGet, srv.URL+"/users/get?user_id=u1", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "imported", data["user"].(map[string]any)["team_name"])
+
+	jsonl := `{"kind":"pull_request","pull_request_id":"pr-imp","pull_request_name":"feat","author_id":"u1"}`
+	req, err = http.NewRequest(http.MethodPost, srv.URL+"/import?format=jsonl&dry_run=true", bytes.NewBufferString(jsonl))
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var dry model.ImportReport
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&dry))
+	resp.Body.Close()
+	require.True(t, dry.DryRun)
+	require.False(t, dry.Committed)
+	require.Equal(t, 1, dry.Applied)
+
+	resp, _ = doJSON(t, client, http.MethodPost, srv.URL+"/import?format=xml", nil)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	req, err = http.NewRequest(http.MethodPost, srv.URL+"/import?format=csv", bytes.NewBufferString("color\nred\n"))
+	require.NoError(t, err)
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestExportRestore(t *testing.T) {
+	src := newTestServer()
+	defer src.Close()
+	client := src.Client()
+
+	resp, _ := doJSON(t, client, http.MethodPost, src.URL+"/team/add", map[string]any{
+		"team_name": "backend",
+		"members": []map[string]any{
+			{"user_id": "u1", "username": "alice", "is_active": true},
+			{"user_id": "u2", "username": "bob", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp, _ = doJSON(t, client, http.MethodPost, src.URL+"/pullRequest/create", map[string]any{
+		"pull_request_id": "pr1", "pull_request_name": "feat", "author_id": "u1",
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, err := client.Get(src.URL + "/export")
+	require.NoError(t, err)
+	archive, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	dst := newTestServer()
+	defer dst.Close()
+	resp, err = client.Post(dst.URL+"/restore", "application/x-ndjson", bytes.NewReader(archive))
+	require.NoError(t, err)
+	var report model.RestoreReport
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, model.RestoreReport{Version: 1, Teams: 1, Users: 2, PullRequests: 1}, report)
+
+	resp, data := doJSON(t, client, http.MethodGet, dst.URL+"/pullRequest/get?pull_request_id=pr1", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "u1", data["pr"].(map[string]any)["author_id"])
+
+	// БД уже не пустая.
+	resp, err = client.Post(dst.URL+"/restore", "application/x-ndjson", bytes.NewReader(archive))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// Повреждённый архив.
+	resp, err = client.Post(src.URL+"/restore", "application/x-ndjson", strings.NewReader("{}"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// --- Аутентификация.
+
+type fakeTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]model.APIToken // по хэшу
+}
+
+func (f *fakeTokenStore) CreateAPIToken(_ context.Context, token model.APIToken, hash string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tokens[hash] = token
+	return nil
+}
+
+func (f *fakeTokenStore) GetAPITokenByHash(_ context.Context, hash string) (*model.APIToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t, ok := f.tokens[hash]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return &t, nil
+}
+
+func (f *fakeTokenStore) ListAPITokens(context.Context) ([]model.APIToken, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	result := make([]model.APIToken, 0, len(f.tokens))
+	for _, t := range f.tokens {
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+func (f *fakeTokenStore) RevokeAPIToken(_ context.Context, tokenID string, at time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for hash, t := range f.tokens {
+		if t.ID == tokenID {
+			t.RevokedAt = &at
+			f.tokens[hash] = t
+			return nil
+		}
+	}
+	return repo.ErrNotFound
+}
+
+// newAuthTestServer поднимает сервер с включённой аутентификацией и возвращает admin-токен.
+func newAuthTestServer(t *testing.T, publicPaths []string) (*httptest.Server, string) {
+	t.Helper()
+	tokens := auth.NewTokens(&fakeTokenStore{tokens: make(map[string]model.APIToken)})
+	adminToken, _, err := tokens.Issue(context.Background(), "bootstrap", []string{auth.ScopeAdmin}, 0)
+	require.NoError(t, err)
+
+	h := NewHandler(service.NewService(memory.New()), WithAuth(AuthConfig{
+		Authenticator: tokens,
+		Tokens:        tokens,
+		PublicPaths:   publicPaths,
+	}))
+	return httptest.NewServer(h.SetupRouter()), adminToken
+}
+
+func doAuthJSON(t *testing.T, client *http.Client, method, url, token string, body interface{}) (*http.Response, map[string]any) {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+	req, err := http.NewRequest(method, url, &buf)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var m map[string]any
+	_ = json.NewDecoder(resp.Body).Decode(&m)
+	return resp, m
+}
+
+func errorCode(data map[string]any) string {
+	return data["error"].(map[string]any)["code"].(string)
+}
+
+func TestAuthTokensAndScopes(t *testing.T) {
+	srv, adminToken := newAuthTestServer(t, nil)
+	defer srv.Close()
+	client := srv.Client()
+
+	// Публичные пути по умолчанию.
+	resp, _ := doAuthJSON(t, client, http.MethodGet, srv.URL+"/health", "", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, data := doAuthJSON(t, client, http.MethodGet, srv.URL+"/team/get?team_name=x", "", nil)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	require.Equal(t, "UNAUTHORIZED", errorCode(data))
+	require.Contains(t, resp.Header.Get("WWW-Authenticate"), "Bearer")
+
+	resp, _ = doAuthJSON(t, client, http.MethodGet, srv.URL+"/team/get?team_name=x", "rvw_forged", nil)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// Выпуск токена только на чтение.
+	resp, data = doAuthJSON(t, client, http.MethodPost, srv.URL+"/auth/token/create", adminToken, map[string]any{
+		"name": "dashboard", "scopes": []string{"read"}, "expires_in": "24h",
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	readToken := data["token"].(string)
+	readTokenID := data["api_token"].(map[string]any)["token_id"].(string)
+	require.NotEmpty(t, data["api_token"].(map[string]any)["expires_at"])
+
+	resp, _ = doAuthJSON(t, client, http.MethodGet, srv.URL+"/team/get?team_name=x", readToken, nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, data = doAuthJSON(t, client, http.MethodPost, srv.URL+"/team/add", readToken, map[string]any{"team_name": "x", "members": []any{}})
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	require.Equal(t, "INSUFFICIENT_SCOPE", errorCode(data))
+
+	resp, _ = doAuthJSON(t, client, http.MethodGet, srv.URL+"/auth/token/list", readToken, nil)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// admin разрешает всё.
+	resp, _ = doAuthJSON(t, client, http.MethodPost, srv.URL+"/team/add", adminToken, map[string]any{"team_name": "x", "members": []any{}})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, data = doAuthJSON(t, client, http.MethodPost, srv.URL+"/auth/token/create", adminToken, map[string]any{
+		"name": "bad", "scopes": []string{"root"},
+	})
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	require.Equal(t, "BAD_REQUEST", errorCode(data))
+
+	resp, data = doAuthJSON(t, client, http.MethodGet, srv.URL+"/auth/token/list", adminToken, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, data["tokens"], 2)
+
+	// Отозванный токен больше не принимается.
+	resp, _ = doAuthJSON(t, client, http.MethodPost, srv.URL+"/auth/token/revoke", adminToken, map[string]any{"token_id": readTokenID})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, _ = doAuthJSON(t, client, http.MethodGet, srv.URL+"/team/get?team_name=x", readToken, nil)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestAuthPublicPathsConfigurable(t *testing.T) {
+	srv, adminToken := newAuthTestServer(t, []string{"/metrics"})
+	defer srv.Close()
+	client := srv.Client()
+
+	resp, _ := doAuthJSON(t, client, http.MethodGet, srv.URL+"/health", "", nil)
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp, _ = doAuthJSON(t, client, http.MethodGet, srv.URL+"/health", adminToken, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err := client.Get(srv.URL + "/metrics")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestTokenEndpointsDisabledWithoutAuth(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	resp, _ := doJSON(t, srv.Client(), http.MethodGet, srv.URL+"/auth/token/list", nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+// stubAuthenticator сопоставляет bearer-токен пользователю (как будто это проверенный JWT).
+type stubAuthenticator map[string]string
+
+func (s stubAuthenticator) Authenticate(_ context.Context, token string) (*auth.Principal, error) {
+	userID, ok := s[token]
+	if !ok {
+		return nil, auth.ErrInvalidCredentials
+	}
+	return &auth.Principal{Subject: userID, Method: "jwt", Scopes: []string{auth.ScopeRead, auth.ScopeTeamsWrite, auth.ScopePRsWrite}}, nil
+}
+
+func TestRoleBasedAccess(t *testing.T) {
+	tokens := auth.NewTokens(&fakeTokenStore{tokens: make(map[string]model.APIToken)})
+	adminToken, _, err := tokens.Issue(context.Background(), "ops", []string{auth.ScopeAdmin}, 0)
+	require.NoError(t, err)
+	h := NewHandler(service.NewService(memory.New()), WithAuth(AuthConfig{
+		Authenticator: auth.Chain{tokens, stubAuthenticator{"lead-jwt": "lead", "alice-jwt": "u1"}},
+		Tokens:        tokens,
+	}))
+	srv := httptest.NewServer(h.SetupRouter())
+	defer srv.Close()
+	client := srv.Client()
+
+	resp, _ := doAuthJSON(t, client, http.MethodPost, srv.URL+"/team/add", adminToken, map[string]any{
+		"team_name": "backend",
+		"members": []map[string]any{
+			{"user_id": "lead", "username": "lead", "is_active": true},
+			{"user_id": "u1", "username": "alice", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp, _ = doAuthJSON(t, client, http.MethodPost, srv.URL+"/roles/assign", adminToken, map[string]any{
+		"user_id": "lead", "role": "team_lead", "team_name": "backend",
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, data := doAuthJSON(t, client, http.MethodGet, srv.URL+"/roles/list?user_id=lead", "alice-jwt", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, data["roles"], 1)
+
+	// Обычный пользователь не управляет активностью и не выдаёт роли.
+	resp, data = doAuthJSON(t, client, http.MethodPost, srv.URL+"/users/setIsActive", "alice-jwt", map[string]any{"user_id": "lead", "is_active": false})
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	require.Equal(t, "FORBIDDEN", errorCode(data))
+	resp, data = doAuthJSON(t, client, http.MethodPost, srv.URL+"/roles/assign", "lead-jwt", map[string]any{"user_id": "u1", "role": "org_admin"})
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	require.Equal(t, "FORBIDDEN", errorCode(data))
+
+	// Тимлид управляет активностью своей команды.
+	resp, _ = doAuthJSON(t, client, http.MethodPost, srv.URL+"/users/setIsActive", "lead-jwt", map[string]any{"user_id": "u1", "is_active": false})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// И составом своей команды, но не чужой.
+	resp, _ = doAuthJSON(t, client, http.MethodPost, srv.URL+"/team/add", adminToken, map[string]any{
+		"team_name": "frontend",
+		"members":   []map[string]any{{"user_id": "f1", "username": "frank", "is_active": true}},
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	member := map[string]any{"username": "new", "is_active": true}
+	resp, data = doAuthJSON(t, client, http.MethodPut, srv.URL+"/v2/teams/backend/members/u7", "lead-jwt", member)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "backend", data["team_name"])
+	resp, data = doAuthJSON(t, client, http.MethodPut, srv.URL+"/v2/teams/frontend/members/u8", "lead-jwt", member)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	require.Equal(t, "FORBIDDEN", errorCode(data))
+	resp, data = doAuthJSON(t, client, http.MethodDelete, srv.URL+"/v2/teams/frontend/members/f1", "lead-jwt", nil)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	require.Equal(t, "FORBIDDEN", errorCode(data))
+	resp, _ = doAuthJSON(t, client, http.MethodDelete, srv.URL+"/v2/teams/backend/members/u7", "lead-jwt", nil)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp, _ = doAuthJSON(t, client, http.MethodGet, srv.URL+"/v2/users/u7", adminToken, nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestOrganizationResolution(t *testing.T) {
+	// Команда есть только в acme: по статусу видно, в какой организации шёл запрос.
+	store := memory.New()
+	require.NoError(t, store.CreateTeamTx(tenant.NewContext(context.Background(), "acme"), model.Team{TeamName: "backend"}))
+	tokens := auth.NewTokens(&fakeTokenStore{tokens: make(map[string]model.APIToken)})
+	acmeToken, _, err := tokens.Issue(tenant.NewContext(context.Background(), "acme"), "acme-ci", []string{auth.ScopeRead}, 0)
+	require.NoError(t, err)
+
+	get := func(srv *httptest.Server, token, org string) int {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/team/get?team_name=backend", nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if org != "" {
+			req.Header.Set(tenant.Header, org)
+		}
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	// Без аутентификации организацию выбирает заголовок.
+	open := httptest.NewServer(NewHandler(service.NewService(store)).SetupRouter())
+	defer open.Close()
+	require.Equal(t, http.StatusNotFound, get(open, "", ""))
+	require.Equal(t, http.StatusOK, get(open, "", "acme"))
+	require.Equal(t, http.StatusBadRequest, get(open, "", "Not Valid"))
+
+	// С аутентификацией организация берётся из токена, чужую выбрать нельзя.
+	secured := httptest.NewServer(NewHandler(service.NewService(store), WithAuth(AuthConfig{Authenticator: tokens})).SetupRouter())
+	defer secured.Close()
+	require.Equal(t, http.StatusOK, get(secured, acmeToken, ""))
+	require.Equal(t, http.StatusOK, get(secured, acmeToken, "acme"))
+	require.Equal(t, http.StatusForbidden, get(secured, acmeToken, "globex"))
+}
+
+// --- Idempotency-Key.
+
+type fakeIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]model.IdempotencyRecord // по организации, scope и ключу
+}
+
+func newFakeIdempotencyStore() *fakeIdempotencyStore {
+	return &fakeIdempotencyStore{records: make(map[string]model.IdempotencyRecord)}
+}
+
+func idempotencyID(ctx context.Context, scope, key string) string {
+	return tenant.FromContext(ctx) + "/" + scope + "/" + key
+}
+
+func (f *fakeIdempotencyStore) ClaimIdempotencyKey(ctx context.Context, rec model.IdempotencyRecord) (*model.IdempotencyRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := idempotencyID(ctx, rec.Scope, rec.Key)
+	if existing, ok := f.records[id]; ok && existing.ExpiresAt.After(rec.CreatedAt) {
+		return &existing, repo.ErrAlreadyExists
+	}
+	f.records[id] = rec
+	return nil, nil
+}
+
+func (f *fakeIdempotencyStore) CompleteIdempotencyKey(ctx context.Context, scope, key string, status int, headers map[string]string, response []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := idempotencyID(ctx, scope, key)
+	rec := f.records[id]
+	rec.StatusCode, rec.Headers, rec.Response = status, headers, append([]byte(nil), response...)
+	f.records[id] = rec
+	return nil
+}
+
+func (f *fakeIdempotencyStore) ReleaseIdempotencyKey(ctx context.Context, scope, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.records, idempotencyID(ctx, scope, key))
+	return nil
+}
+
+func (f *fakeIdempotencyStore) DeleteExpiredIdempotencyKeys(_ context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func doIdempotent(t *testing.T, srv *httptest.Server, path, key string, body any) (*http.Response, []byte) {
+	t.Helper()
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, srv.URL+path, bytes.NewReader(payload))
+	require.NoError(t, err)
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, data
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	store := newFakeIdempotencyStore()
+	h := NewHandler(service.NewService(memory.New()), WithIdempotency(IdempotencyConfig{Store: store}))
+	srv := httptest.NewServer(h.SetupRouter())
+	defer srv.Close()
+
+	members := []map[string]any{}
+	for _, id := range []string{"u1", "u2", "u3", "u4", "u5"} {
+		members = append(members, map[string]any{"user_id": id, "username": id, "is_active": true})
+	}
+	resp, _ := doIdempotent(t, srv, "/team/add", "team-1", map[string]any{"team_name": "core", "members": members})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	create := map[string]any{"pull_request_id": "pr-1", "pull_request_name": "search", "author_id": "u1"}
+	resp, first := doIdempotent(t, srv, "/pullRequest/create", "create-1", create)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.Empty(t, resp.Header.Get("Idempotent-Replayed"))
+
+	// Повтор получает исходный ответ вместо PR_EXISTS.
+	resp, again := doIdempotent(t, srv, "/pullRequest/create", "create-1", create)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.Equal(t, "true", resp.Header.Get("Idempotent-Replayed"))
+	require.JSONEq(t, string(first), string(again))
+
+	// Без ключа повтор — обычная ошибка.
+	resp, _ = doIdempotent(t, srv, "/pullRequest/create", "", create)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// Тот же ключ с другим телом отклоняется.
+	resp, data := doIdempotent(t, srv, "/pullRequest/create", "create-1", map[string]any{"pull_request_id": "pr-2", "pull_request_name": "x", "author_id": "u1"})
+	require.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	require.Contains(t, string(data), "IDEMPOTENCY_KEY_REUSED")
+
+	// Повтор переназначения не выбирает нового случайного ревьюера.
+	var created struct {
+		PR model.PullRequest `json:"pr"`
+	}
+	require.NoError(t, json.Unmarshal(first, &created))
+	reassign := map[string]any{"pull_request_id": "pr-1", "old_user_id": created.PR.AssignedReviewers[0]}
+	resp, first = doIdempotent(t, srv, "/pullRequest/reassign", "reassign-1", reassign)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	for i := 0; i < 3; i++ {
+		resp, again = doIdempotent(t, srv, "/pullRequest/reassign", "reassign-1", reassign)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.JSONEq(t, string(first), string(again))
+	}
+
+	// Ключ, чей запрос ещё выполняется, не запускает его повторно.
+	_, err := store.ClaimIdempotencyKey(tenant.NewContext(context.Background(), tenant.Default), model.IdempotencyRecord{
+		Key: "busy", Fingerprint: fingerprint(http.MethodPost, "/pullRequest/merge", []byte(`{"pull_request_id":"pr-1"}`)),
+		CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	resp, data = doIdempotent(t, srv, "/pullRequest/merge", "busy", map[string]any{"pull_request_id": "pr-1"})
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	require.Contains(t, string(data), "IDEMPOTENCY_IN_PROGRESS")
+}
+
+func TestIdempotencyKeyReplaysHeaders(t *testing.T) {
+	h := NewHandler(service.NewService(memory.New()), WithIdempotency(IdempotencyConfig{Store: newFakeIdempotencyStore()}))
+	srv := httptest.NewServer(h.SetupRouter())
+	defer srv.Close()
+
+	members := []map[string]any{}
+	for _, id := range []string{"u1", "u2", "u3"} {
+		members = append(members, map[string]any{"user_id": id, "username": id, "is_active": true})
+	}
+	resp, _ := doIdempotent(t, srv, "/team/add", "", map[string]any{"team_name": "core", "members": members})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	create := map[string]any{"pull_request_id": "pr-1", "pull_request_name": "search", "author_id": "u1"}
+	first, _ := doIdempotent(t, srv, "/v2/pull-requests", "create-1", create)
+	require.Equal(t, http.StatusCreated, first.StatusCode)
+	require.Equal(t, "/v2/pull-requests/pr-1", first.Header.Get("Location"))
+
+	// Повтор отдаёт те же Location, ETag и Content-Type, что и исходный ответ.
+	again, _ := doIdempotent(t, srv, "/v2/pull-requests", "create-1", create)
+	require.Equal(t, http.StatusCreated, again.StatusCode)
+	require.Equal(t, "true", again.Header.Get("Idempotent-Replayed"))
+	for _, name := range []string{"Location", "ETag", "Content-Type"} {
+		require.NotEmpty(t, first.Header.Get(name), name)
+		require.Equal(t, first.Header.Get(name), again.Header.Get(name), name)
+	}
+}
+
+func TestIdempotencyKeyExpires(t *testing.T) {
+	h := NewHandler(service.NewService(memory.New()), WithIdempotency(IdempotencyConfig{Store: newFakeIdempotencyStore(), TTL: time.Millisecond}))
+	srv := httptest.NewServer(h.SetupRouter())
+	defer srv.Close()
+
+	team := map[string]any{"team_name": "core", "members": []map[string]any{}}
+	resp, _ := doIdempotent(t, srv, "/team/add", "team-1", team)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	time.Sleep(5 * time.Millisecond)
+
+	// Истёкший ключ не защищает от повторного выполнения.
+	resp, data := doIdempotent(t, srv, "/team/add", "team-1", team)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+	require.Contains(t, string(data), "TEAM_EXISTS")
+}
+
+func doConditional(t *testing.T, srv *httptest.Server, method, path, header, value string, body any) (*http.Response, []byte) {
+	t.Helper()
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		payload = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, srv.URL+path, payload)
+	require.NoError(t, err)
+	if value != "" {
+		req.Header.Set(header, value)
+	}
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, data
+}
+
+func TestETagsAndIfMatch(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(service.NewService(memory.New())).SetupRouter())
+	defer srv.Close()
+
+	members := []map[string]any{}
+	for _, id := range []string{"u1", "u2", "u3", "u4"} {
+		members = append(members, map[string]any{"user_id": id, "username": id, "is_active": true})
+	}
+	resp, _ := doConditional(t, srv, http.MethodPost, "/team/add", "", "", map[string]any{"team_name": "core", "members": members})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, _ = doConditional(t, srv, http.MethodPost, "/pullRequest/create", "", "", map[string]any{"pull_request_id": "pr-1", "pull_request_name": "search", "author_id": "u1"})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.Equal(t, `"1"`, resp.Header.Get("ETag"))
+
+	// GET отдаёт ETag, а совпадающий If-None-Match — 304 без тела.
+	resp, _ = doConditional(t, srv, http.MethodGet, "/pullRequest/get?pull_request_id=pr-1", "", "", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	tag := resp.Header.Get("ETag")
+	require.Equal(t, `"1"`, tag)
+	resp, body := doConditional(t, srv, http.MethodGet, "/pullRequest/get?pull_request_id=pr-1", "If-None-Match", tag, nil)
+	require.Equal(t, http.StatusNotModified, resp.StatusCode)
+	require.Empty(t, body)
+	// Версия команды растёт и при добавлении участников, поэтому берём её из ответа.
+	resp, _ = doConditional(t, srv, http.MethodGet, "/team/get?team_name=core", "If-None-Match", `"0"`, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	teamTag := resp.Header.Get("ETag")
+	require.NotEmpty(t, teamTag)
+	resp, _ = doConditional(t, srv, http.MethodGet, "/team/get?team_name=core", "If-None-Match", teamTag, nil)
+	require.Equal(t, http.StatusNotModified, resp.StatusCode)
+
+	// Мутация с актуальной версией проходит и возвращает новую.
+	var created struct {
+		PR model.PullRequest `json:"pr"`
+	}
+	_, body = doConditional(t, srv, http.MethodGet, "/pullRequest/get?pull_request_id=pr-1", "", "", nil)
+	require.NoError(t, json.Unmarshal(body, &created))
+	reassign := map[string]any{"pull_request_id": "pr-1", "old_user_id": created.PR.AssignedReviewers[0]}
+	resp, _ = doConditional(t, srv, http.MethodPost, "/pullRequest/reassign", "If-Match", tag, reassign)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, `"2"`, resp.Header.Get("ETag"))
+
+	// Устаревшая или некорректная версия — 412, PR не меняется.
+	for _, stale := range []string{tag, `W/"2"`, "2"} {
+		resp, body = doConditional(t, srv, http.MethodPost, "/pullRequest/merge", "If-Match", stale, map[string]any{"pull_request_id": "pr-1"})
+		require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode, stale)
+		require.Contains(t, string(body), "PRECONDITION_FAILED")
+	}
+	resp, _ = doConditional(t, srv, http.MethodPost, "/pullRequest/merge", "If-Match", `"2"`, map[string]any{"pull_request_id": "pr-1"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, `"3"`, resp.Header.Get("ETag"))
+
+	// Пользователи: If-Match сверяется с версией пользователя.
+	resp, _ = doConditional(t, srv, http.MethodPost, "/users/setIsActive", "If-Match", `"5"`, map[string]any{"user_id": "u4", "is_active": false})
+	require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+	resp, _ = doConditional(t, srv, http.MethodPost, "/users/setIsActive", "If-Match", `"1"`, map[string]any{"user_id": "u4", "is_active": false})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, `"2"`, resp.Header.Get("ETag"))
+	resp, _ = doConditional(t, srv, http.MethodGet, "/users/get?user_id=u4", "If-None-Match", `"2"`, nil)
+	require.Equal(t, http.StatusNotModified, resp.StatusCode)
+}
+
+func TestV2Resources(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(service.NewService(memory.New())).SetupRouter())
+	defer srv.Close()
+
+	members := []map[string]any{}
+	for _, id := range []string{"u1", "u2", "u3", "u4", "u5"} {
+		members = append(members, map[string]any{"user_id": id, "username": id, "is_active": true})
+	}
+	resp, _ := doConditional(t, srv, http.MethodPost, "/v2/teams", "", "", map[string]any{"team_name": "core", "members": members})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.Equal(t, "/v2/teams/core", resp.Header.Get("Location"))
+	require.Empty(t, resp.Header.Get("Deprecation"))
+
+	resp, body := doConditional(t, srv, http.MethodGet, "/v2/teams/core", "", "", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var team model.Team
+	require.NoError(t, json.Unmarshal(body, &team))
+	require.Len(t, team.Members, 5)
+
+	// Участник адресуется через свою команду.
+	resp, _ = doConditional(t, srv, http.MethodGet, "/v2/teams/core/members/u2", "", "", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, _ = doConditional(t, srv, http.MethodGet, "/v2/teams/other/members/u2", "", "", nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp, body = doConditional(t, srv, http.MethodPatch, "/v2/teams/core/members/u4", "If-Match", `"1"`, map[string]any{"is_active": false})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, string(body), `"is_active":false`)
+	resp, _ = doConditional(t, srv, http.MethodPatch, "/v2/teams/core/members/u4", "", "", map[string]any{})
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, body = doConditional(t, srv, http.MethodPost, "/v2/pull-requests", "", "", map[string]any{"pull_request_id": "pr-1", "pull_request_name": "search", "author_id": "u1"})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	require.Equal(t, "/v2/pull-requests/pr-1", resp.Header.Get("Location"))
+	var pr model.PullRequest
+	require.NoError(t, json.Unmarshal(body, &pr))
+	require.Len(t, pr.AssignedReviewers, 2)
+	require.NotContains(t, pr.AssignedReviewers, "u4")
+	resp, _ = doConditional(t, srv, http.MethodPost, "/v2/pull-requests", "", "", map[string]any{"pull_request_id": "pr-1", "pull_request_name": "search", "author_id": "u1"})
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, body = doConditional(t, srv, http.MethodGet, "/v2/pull-requests/pr-1/reviewers", "", "", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.JSONEq(t, fmt.Sprintf(`{"pull_request_id":"pr-1","reviewers":["%s","%s"]}`, pr.AssignedReviewers[0], pr.AssignedReviewers[1]), string(body))
+
+	resp, body = doConditional(t, srv, http.MethodDelete, "/v2/pull-requests/pr-1/reviewers/"+pr.AssignedReviewers[0], "", "", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, string(body), fmt.Sprintf(`"replaced_by":%q`, remaining(pr.AssignedReviewers, "u2", "u3", "u5")[0]))
+	resp, _ = doConditional(t, srv, http.MethodDelete, "/v2/pull-requests/pr-1/reviewers/u1", "", "", nil)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	// PATCH умеет только переводить PR в MERGED.
+	resp, _ = doConditional(t, srv, http.MethodPatch, "/v2/pull-requests/pr-1", "", "", map[string]any{"status": "OPEN"})
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, body = doConditional(t, srv, http.MethodPatch, "/v2/pull-requests/pr-1", "", "", map[string]any{"status": "MERGED"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.Unmarshal(body, &pr))
+	require.Equal(t, model.PRMerged, pr.Status)
+
+	resp, body = doConditional(t, srv, http.MethodGet, "/v2/users/u1", "", "", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, string(body), `"team_name":"core"`)
+	resp, _ = doConditional(t, srv, http.MethodGet, "/v2/pull-requests?status=MERGED", "", "", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, _ = doConditional(t, srv, http.MethodGet, "/v2/pull-requests/missing", "", "", nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+// remaining возвращает кандидатов, которые ещё не назначены ревьюерами.
+func remaining(assigned []string, candidates ...string) []string {
+	var out []string
+	for _, c := range candidates {
+		if !slices.Contains(assigned, c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func TestV1Deprecation(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(service.NewService(memory.New())).SetupRouter())
+	defer srv.Close()
+
+	resp, _ := doConditional(t, srv, http.MethodGet, "/pullRequest/get?pull_request_id=missing", "", "", nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	require.Equal(t, "@"+strconv.FormatInt(v1DeprecatedAt.Unix(), 10), resp.Header.Get("Deprecation"))
+	require.Equal(t, v1Sunset.Format(http.TimeFormat), resp.Header.Get("Sunset"))
+	require.Equal(t, `</v2/pull-requests/{id}>; rel="successor-version"`, resp.Header.Get("Link"))
+
+	// Служебные маршруты без замены не помечаются.
+	resp, _ = doConditional(t, srv, http.MethodGet, "/health", "", "", nil)
+	require.Empty(t, resp.Header.Get("Deprecation"))
+}
+
+// readSSE читает следующее событие SSE, пропуская комментарии.
+func readSSE(t *testing.T, r *bufio.Reader) (id, event, data string) {
+	t.Helper()
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && event != "":
+			return id, event, data
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func openStream(t *testing.T, ctx context.Context, url, lastEventID string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestEventStream(t *testing.T) {
+	broker := events.NewBroker()
+	h := NewHandler(service.NewService(memory.New(), service.WithEvents(broker)), WithEvents(broker))
+	srv := httptest.NewServer(h.SetupRouter())
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, _ := doJSON(t, srv.Client(), http.MethodPost, srv.URL+"/team/add", map[string]any{
+		"team_name": "core",
+		"members": []map[string]any{
+			{"user_id": "u1", "username": "A", "is_active": true},
+			{"user_id": "u2", "username": "B", "is_active": true},
+			{"user_id": "u3", "username": "C", "is_active": true},
+		},
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	stream := openStream(t, ctx, srv.URL+"/events?user_id=u2", "")
+	defer stream.Body.Close()
+	require.Equal(t, http.StatusOK, stream.StatusCode)
+	require.Equal(t, "text/event-stream", stream.Header.Get("Content-Type"))
+	live := bufio.NewReader(stream.Body)
+
+	// Событие другого пользователя отфильтровано.
+	resp, _ = doJSON(t, srv.Client(), http.MethodPost, srv.URL+"/users/setIsActive", map[string]any{"user_id": "u3", "is_active": false})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, _ = doJSON(t, srv.Client(), http.MethodPost, srv.URL+"/pullRequest/create", map[string]any{"pull_request_id": "pr-1", "pull_request_name": "search", "author_id": "u1"})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp, _ = doJSON(t, srv.Client(), http.MethodPost, srv.URL+"/pullRequest/merge", map[string]any{"pull_request_id": "pr-1"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	id, event, data := readSSE(t, live)
+	require.Equal(t, "2", id)
+	require.Equal(t, "pull_request.assigned", event)
+	require.JSONEq(t, `["u2"]`, mustField(t, data, "reviewers"))
+	_, event, data = readSSE(t, live)
+	require.Equal(t, "pull_request.merged", event)
+	require.Equal(t, `"core"`, mustField(t, data, "team_name"))
+
+	// Продолжение после разрыва: пропущенные события из буфера, с фильтром по команде.
+	resumed := openStream(t, ctx, srv.URL+"/events?team_name=core", "1")
+	defer resumed.Body.Close()
+	replay := bufio.NewReader(resumed.Body)
+	id, event, _ = readSSE(t, replay)
+	require.Equal(t, "2", id)
+	require.Equal(t, "pull_request.assigned", event)
+	id, _, _ = readSSE(t, replay)
+	require.Equal(t, "3", id)
+
+	// ID из другого запуска сервера: клиент получает stream.reset.
+	gap := openStream(t, ctx, srv.URL+"/events", "99")
+	defer gap.Body.Close()
+	_, event, _ = readSSE(t, bufio.NewReader(gap.Body))
+	require.Equal(t, "stream.reset", event)
+
+	bad := openStream(t, ctx, srv.URL+"/events", "abc")
+	bad.Body.Close()
+	require.Equal(t, http.StatusBadRequest, bad.StatusCode)
+
+	// Остановка сервера закрывает потоки.
+	broker.Close()
+	_, err := io.ReadAll(live)
+	require.NoError(t, err)
+}
+
+func mustField(t *testing.T, data, field string) string {
+	t.Helper()
+	var m map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal([]byte(data), &m))
+	return string(m[field])
+}
+
+// brokenTeamRepo отвечает на GetTeam непредвиденной ошибкой.
+type brokenTeamRepo struct {
+	*memory.Repository
+}
+
+func (brokenTeamRepo) GetTeam(context.Context, string) (*model.Team, error) {
+	return nil, errors.New("connection reset by peer")
+}
+
+// captureLogs направляет журнал в буфер на время теста.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(logging.NewHandler(slog.NewJSONHandler(&buf, nil))))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return &buf
+}
+
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		lines = append(lines, entry)
+	}
+	buf.Reset()
+	return lines
+}
+
+func TestAccessLog(t *testing.T) {
+	logs := captureLogs(t)
+	tokens := auth.NewTokens(&fakeTokenStore{tokens: make(map[string]model.APIToken)})
+	token, apiToken, err := tokens.Issue(context.Background(), "ci", []string{auth.ScopeRead}, 0)
+	require.NoError(t, err)
+	router := NewHandler(service.NewService(memory.New()), WithAuth(AuthConfig{Authenticator: tokens})).SetupRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("X-Request-ID", "edge-42")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, "edge-42", rec.Header().Get("X-Request-ID"))
+
+	lines := logLines(t, logs)
+	access := lines[len(lines)-1]
+	require.Equal(t, "http request", access["msg"])
+	require.Equal(t, "INFO", access["level"])
+	require.Equal(t, "edge-42", access["request_id"])
+	require.Equal(t, "/team/get", access["route"])
+	require.Equal(t, float64(http.StatusNotFound), access["status"])
+	require.Equal(t, apiToken.ID, access["subject"])
+	require.Equal(t, "api_token", access["auth_method"])
+	require.Equal(t, tenant.Default, access["org"])
+	require.Contains(t, access, "latency_ms")
+
+	// Некорректный request ID клиента заменяется новым.
+	req = httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set("X-Request-ID", "bad id")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Len(t, rec.Header().Get("X-Request-ID"), 32)
+	access = logLines(t, logs)[0]
+	require.Equal(t, rec.Header().Get("X-Request-ID"), access["request_id"])
+	require.NotContains(t, access, "subject")
+}
+
+func TestInternalErrorsShareRequestID(t *testing.T) {
+	logs := captureLogs(t)
+	router := NewHandler(service.NewService(brokenTeamRepo{memory.New()})).SetupRouter()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil))
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+	require.NotContains(t, rec.Body.String(), "connection reset")
+
+	id := rec.Header().Get("X-Request-ID")
+	lines := logLines(t, logs)
+	require.Len(t, lines, 2)
+	require.Equal(t, "Internal Server Error", lines[0]["msg"])
+	require.Equal(t, "connection reset by peer", lines[0]["error"])
+	require.Equal(t, "ERROR", lines[1]["level"])
+	for _, line := range lines {
+		require.Equal(t, id, line["request_id"])
+	}
+}
+
+func TestRecovererLogsPanic(t *testing.T) {
+	logs := captureLogs(t)
+	h := requestID(accessLog(recoverer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	lines := logLines(t, logs)
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0]["error"], "panic: boom")
+	require.Contains(t, lines[0]["error"], "goroutine")
+	require.Equal(t, float64(http.StatusInternalServerError), lines[1]["status"])
+	require.Equal(t, lines[0]["request_id"], lines[1]["request_id"])
+}
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	logs := captureLogs(t)
+	router := NewHandler(service.NewService(memory.New())).SetupRouter()
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodGet, "/team/get?team_name=backend", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	svc, server := spans[0], spans[1]
+	require.Equal(t, "GET /team/get", server.Name())
+	require.Equal(t, traceID, server.SpanContext().TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", server.Parent().SpanID().String())
+	require.Equal(t, "Service.GetTeam", svc.Name())
+	require.Equal(t, server.SpanContext().SpanID(), svc.Parent().SpanID())
+
+	// Строки журнала запроса связаны с трассой.
+	access := logLines(t, logs)[0]
+	require.Equal(t, traceID, access["trace_id"])
+
+	// Гистограмма латентности ссылается на трассу через exemplar.
+	req = httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	require.Contains(t, rec.Body.String(), `trace_id="`+traceID+`"`)
+}
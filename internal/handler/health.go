@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/trainee/review-service/internal/api"
+	"github.com/trainee/review-service/internal/health"
+)
+
+// WithHealth задаёт проверки готовности для /readyz.
+func WithHealth(c *health.Checker) Option {
+	return func(h *Handler) {
+		h.health = c
+	}
+}
+
+// GET /readyz
+// 200, если все проверки прошли, иначе 503; в теле — результат каждой проверки.
+// Без WithHealth проверок нет и сервис всегда готов.
+func (h *Handler) Readyz(ctx context.Context, _ api.ReadyzRequestObject) (api.ReadyzResponseObject, error) {
+	report := health.Report{Status: health.StatusReady, Checks: map[string]health.CheckResult{}}
+	if h.health != nil {
+		report = h.health.Check(ctx)
+	}
+	if !report.Ready() {
+		return api.Readyz503JSONResponse{
+			Body:    apiHealthReport(report),
+			Headers: api.Readyz503ResponseHeaders{CacheControl: "no-store"},
+		}, nil
+	}
+	return api.Readyz200JSONResponse{
+		Body:    apiHealthReport(report),
+		Headers: api.Readyz200ResponseHeaders{CacheControl: "no-store"},
+	}, nil
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/model"
+	repo "github.com/trainee/review-service/internal/repository"
+)
+
+// IdempotencyKeyHeader — заголовок, по которому повтор запроса получает исходный ответ.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// DefaultIdempotencyTTL — время хранения ответа, если не задано иное.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// maxIdempotentBodySize ограничивает тело запроса, которое хэшируется целиком.
+const maxIdempotentBodySize = 1 << 20
+
+// replayedHeaders — заголовки ответа, которые сохраняются и повторяются вместе с телом.
+// Остальные (X-Request-ID, Deprecation и т.п.) выставляет обвязка вокруг обработчика
+// заново на каждый запрос.
+var replayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// IdempotencyConfig включает поддержку Idempotency-Key на мутирующих эндпоинтах.
+type IdempotencyConfig struct {
+	Store repo.IdempotencyRepository
+	// TTL — сколько хранится ответ; 0 — DefaultIdempotencyTTL.
+	TTL time.Duration
+}
+
+// WithIdempotency включает повтор сохранённых ответов для запросов с Idempotency-Key.
+// Без неё заголовок игнорируется.
+func WithIdempotency(cfg IdempotencyConfig) Option {
+	return func(h *Handler) {
+		if cfg.TTL <= 0 {
+			cfg.TTL = DefaultIdempotencyTTL
+		}
+		h.idempotency = &cfg
+	}
+}
+
+// idempotent выполняет запрос с Idempotency-Key не более одного раза: повтор с тем же
+// ключом и телом получает сохранённый ответ, с другим телом — 422. Ответы 5xx не
+// сохраняются, чтобы запрос можно было повторить.
+func (h *Handler) idempotent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if h.idempotency == nil || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > 255 {
+			respondError(w, r, model.Invalid(IdempotencyKeyHeader, "maxLength", "must be at most 255 characters"))
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBodySize))
+		if err != nil {
+			if limitErr := bodyTooLarge(err); limitErr != nil {
+				err = limitErr
+			} else {
+				err = fmt.Errorf("%w: %v", model.ErrBadRequest, err)
+			}
+			respondError(w, r, err)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := r.Context()
+		scope := ""
+		if p := auth.FromContext(ctx); p != nil {
+			scope = p.Subject
+		}
+		now := time.Now().UTC()
+		rec := model.IdempotencyRecord{
+			Scope:       scope,
+			Key:         key,
+			Fingerprint: fingerprint(r.Method, r.URL.Path, body),
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(h.idempotency.TTL),
+		}
+
+		existing, err := h.idempotency.Store.ClaimIdempotencyKey(ctx, rec)
+		switch {
+		case errors.Is(err, repo.ErrAlreadyExists):
+			replay(w, r, existing, rec.Fingerprint)
+			return
+		case err != nil:
+			respondError(w, r, err)
+			return
+		}
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		var response bytes.Buffer
+		ww.Tee(&response)
+		next.ServeHTTP(ww, r)
+
+		// Сохраняем ответ даже при отменённом клиентом запросе: операция уже выполнена.
+		storeCtx := context.WithoutCancel(ctx)
+		status := ww.Status()
+		if status >= http.StatusInternalServerError {
+			err = h.idempotency.Store.ReleaseIdempotencyKey(storeCtx, scope, key)
+		} else {
+			err = h.idempotency.Store.CompleteIdempotencyKey(storeCtx, scope, key, status, savedHeaders(ww.Header()), response.Bytes())
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to store idempotent response", "key", key, "error", err)
+		}
+	})
+}
+
+// replay отвечает на повтор запроса с уже использованным ключом.
+func replay(w http.ResponseWriter, r *http.Request, rec *model.IdempotencyRecord, fp string) {
+	switch {
+	case rec.Fingerprint != fp:
+		respondError(w, r, model.ErrIdempotencyKeyReused)
+	case rec.StatusCode == 0:
+		respondError(w, r, model.ErrIdempotencyInProgress)
+	default:
+		// Записи, сохранённые до появления заголовков, отдаются как JSON.
+		if len(rec.Headers) == 0 {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		for name, value := range rec.Headers {
+			w.Header().Set(name, value)
+		}
+		w.Header().Set("Idempotent-Replayed", "true")
+		w.WriteHeader(rec.StatusCode)
+		_, _ = w.Write(rec.Response)
+	}
+}
+
+// savedHeaders выбирает из ответа заголовки для повтора.
+func savedHeaders(header http.Header) map[string]string {
+	saved := make(map[string]string, len(replayedHeaders))
+	for _, name := range replayedHeaders {
+		if value := header.Get(name); value != "" {
+			saved[name] = value
+		}
+	}
+	return saved
+}
+
+// fingerprint хэширует метод, путь и тело: повтор должен совпадать с исходным запросом целиком.
+func fingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + " " + path + "\n" + strconv.Itoa(len(body)) + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/logging"
+)
+
+// requestID берёт X-Request-ID клиента (если он корректен) или создаёт новый,
+// кладёт его в context и возвращает в ответе.
+func requestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(logging.Header)
+		if !logging.ValidRequestID(id) {
+			id = logging.NewRequestID()
+		}
+		w.Header().Set(logging.Header, id)
+		next.ServeHTTP(w, r.WithContext(logging.NewContext(r.Context(), id)))
+	})
+}
+
+// accessEntry заполняется внутренними middleware: вызывающий и организация
+// становятся известны позже, чем начинается запись access log.
+type accessEntry struct {
+	principal *auth.Principal
+	org       string
+}
+
+type accessEntryKey struct{}
+
+func accessEntryFrom(ctx context.Context) *accessEntry {
+	e, _ := ctx.Value(accessEntryKey{}).(*accessEntry)
+	return e
+}
+
+// accessLog пишет по строке на запрос: маршрут, статус, время и вызывающий.
+// 5xx пишутся с уровнем ERROR.
+func accessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		entry := &accessEntry{}
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		ctx := context.WithValue(r.Context(), accessEntryKey{}, entry)
+
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		route := ""
+		if rc := chi.RouteContext(ctx); rc != nil {
+			route = rc.RoutePattern()
+		}
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("route", route),
+			slog.Int("status", status),
+			slog.Int("bytes", ww.BytesWritten()),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("remote_addr", r.RemoteAddr),
+		}
+		if entry.principal != nil {
+			attrs = append(attrs,
+				slog.String("subject", entry.principal.Subject),
+				slog.String("auth_method", entry.principal.Method))
+		}
+		if entry.org != "" {
+			attrs = append(attrs, slog.String("org", entry.org))
+		}
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.LogAttrs(ctx, level, "http request", attrs...)
+	})
+}
+
+// recoverer превращает панику обработчика в 500 и пишет её со стеком в журнал
+// запроса.
+func recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rv := recover()
+			if rv == nil {
+				return
+			}
+			if rv == http.ErrAbortHandler {
+				panic(rv)
+			}
+			respondError(w, r, fmt.Errorf("panic: %v\n%s", rv, debug.Stack()))
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
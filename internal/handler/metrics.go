@@ -9,6 +9,8 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -24,6 +26,13 @@ var (
 	}, []string{"method", "path"})
 )
 
+// WithoutMetrics отключает сбор HTTP метрик и эндпоинт /metrics.
+func WithoutMetrics() Option {
+	return func(h *Handler) {
+		h.noMetrics = true
+	}
+}
+
 // prometheusMiddleware собирает базовые HTTP метрики.
 func prometheusMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -41,6 +50,23 @@ func prometheusMiddleware(next http.Handler) http.Handler {
 
 		status := strconv.Itoa(ww.Status())
 		httpRequestsTotal.WithLabelValues(r.Method, path, status).Inc()
-		httpRequestDuration.WithLabelValues(r.Method, path).Observe(time.Since(start).Seconds())
+		observe(httpRequestDuration.WithLabelValues(r.Method, path), time.Since(start).Seconds(), r)
 	})
 }
+
+// observe записывает значение гистограммы с exemplar trace_id, если запрос
+// попал в выборку трассировки: из графика латентности можно перейти к трассе.
+func observe(o prometheus.Observer, v float64, r *http.Request) {
+	sc := trace.SpanContextFromContext(r.Context())
+	if eo, ok := o.(prometheus.ExemplarObserver); ok && sc.IsSampled() {
+		eo.ObserveWithExemplar(v, prometheus.Labels{"trace_id": sc.TraceID().String()})
+		return
+	}
+	o.Observe(v)
+}
+
+// metricsHandler отдаёт метрики; exemplars видны только в формате OpenMetrics,
+// который Prometheus запрашивает сам.
+func metricsHandler() http.Handler {
+	return promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true})
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/trainee/review-service/internal/api"
+	"github.com/trainee/review-service/internal/health"
+	"github.com/trainee/review-service/internal/model"
+)
+
+// Handler реализует строгий серверный интерфейс, сгенерированный из openapi.yml.
+var _ api.StrictServerInterface = (*Handler)(nil)
+
+// openAPISpec — openapi.yml, встроенная в internal/api при генерации.
+var openAPISpec = func() *openapi3.T {
+	spec, err := api.GetSwagger()
+	if err != nil {
+		panic(fmt.Sprintf("embedded OpenAPI spec: %v", err))
+	}
+	return spec
+}()
+
+// apiServer связывает строгие обработчики с маршрутами: параметры и тело
+// разбирает сгенерированный код, ошибки отдаёт respondError.
+func (h *Handler) apiServer() *api.ServerInterfaceWrapper {
+	strict := api.NewStrictHandlerWithOptions(h, nil, api.StrictHTTPServerOptions{
+		RequestErrorHandlerFunc:  respondBadRequest,
+		ResponseErrorHandlerFunc: respondError,
+	})
+	return &api.ServerInterfaceWrapper{Handler: strict, ErrorHandlerFunc: respondBadRequest}
+}
+
+func deref[T any](p *T) T {
+	var zero T
+	if p == nil {
+		return zero
+	}
+	return *p
+}
+
+// optional возвращает nil для пустой строки: необязательные поля в ответах опускаются.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// --- Преобразование доменных моделей в типы ответов ---
+
+func apiTeam(t model.Team) api.Team {
+	members := make([]api.TeamMember, 0, len(t.Members))
+	for _, m := range t.Members {
+		members = append(members, api.TeamMember{UserId: m.UserID, Username: m.Username, IsActive: m.IsActive})
+	}
+	return api.Team{TeamName: t.TeamName, Members: members}
+}
+
+func apiUser(u model.User) api.User {
+	return api.User{UserId: u.UserID, Username: u.Username, TeamName: u.TeamName, IsActive: u.IsActive}
+}
+
+func apiUserDetails(u model.UserDetails) api.UserDetails {
+	return api.UserDetails{
+		UserId:          u.UserID,
+		Username:        u.Username,
+		TeamName:        u.TeamName,
+		IsActive:        u.IsActive,
+		OpenReviewCount: u.OpenReviewCount,
+	}
+}
+
+func apiUserPage(p model.UserPage) api.UserPage {
+	users := make([]api.UserDetails, 0, len(p.Users))
+	for _, u := range p.Users {
+		users = append(users, apiUserDetails(u))
+	}
+	return api.UserPage{Users: users, NextCursor: optional(p.NextCursor)}
+}
+
+func apiPullRequest(pr model.PullRequest) api.PullRequest {
+	return api.PullRequest{
+		PullRequestId:     pr.ID,
+		PullRequestName:   pr.Name,
+		AuthorId:          pr.AuthorID,
+		Status:            api.PRStatus(pr.Status),
+		AssignedReviewers: append([]string{}, pr.AssignedReviewers...),
+		CreatedAt:         pr.CreatedAt,
+		MergedAt:          pr.MergedAt,
+	}
+}
+
+func apiPullRequestPage(p model.PullRequestPage) api.PullRequestPage {
+	prs := make([]api.PullRequest, 0, len(p.PullRequests))
+	for _, pr := range p.PullRequests {
+		prs = append(prs, apiPullRequest(pr))
+	}
+	return api.PullRequestPage{PullRequests: prs, NextCursor: optional(p.NextCursor)}
+}
+
+func apiReviewPage(userID string, p model.ReviewPage) api.ReviewPage {
+	prs := make([]api.PullRequestShort, 0, len(p.PullRequests))
+	for _, pr := range p.PullRequests {
+		prs = append(prs, api.PullRequestShort{
+			PullRequestId:   pr.ID,
+			PullRequestName: pr.Name,
+			AuthorId:        pr.AuthorID,
+			Status:          api.PRStatus(pr.Status),
+		})
+	}
+	return api.ReviewPage{UserId: userID, PullRequests: prs, NextCursor: optional(p.NextCursor)}
+}
+
+func apiRole(r model.RoleAssignment) api.RoleAssignment {
+	return api.RoleAssignment{UserId: r.UserID, Role: api.RoleAssignmentRole(r.Role), TeamName: optional(r.TeamName)}
+}
+
+func modelRole(r api.RoleAssignment) model.RoleAssignment {
+	return model.RoleAssignment{UserID: r.UserId, Role: model.Role(r.Role), TeamName: deref(r.TeamName)}
+}
+
+func apiToken(t model.APIToken) api.APIToken {
+	scopes := make([]api.APITokenScopes, 0, len(t.Scopes))
+	for _, s := range t.Scopes {
+		scopes = append(scopes, api.APITokenScopes(s))
+	}
+	return api.APIToken{
+		TokenId:   t.ID,
+		OrgId:     optional(t.OrgID),
+		Name:      t.Name,
+		Scopes:    scopes,
+		CreatedAt: t.CreatedAt,
+		ExpiresAt: t.ExpiresAt,
+		RevokedAt: t.RevokedAt,
+	}
+}
+
+func apiHealthReport(r health.Report) api.HealthReport {
+	checks := make(map[string]api.CheckResult, len(r.Checks))
+	for name, c := range r.Checks {
+		result := api.CheckResult{Status: api.CheckResultStatus(c.Status), Error: optional(c.Error), DurationMs: c.DurationMS}
+		if c.Details != nil {
+			details := c.Details
+			result.Details = &details
+		}
+		checks[name] = result
+	}
+	return api.HealthReport{Status: api.HealthReportStatus(r.Status), Checks: checks}
+}
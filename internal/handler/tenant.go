@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/model"
+	"github.com/trainee/review-service/internal/tenant"
+)
+
+// resolveOrg определяет организацию запроса (auth.ResolveOrg) и кладёт её в context.
+// Для аутентифицированного вызывающего организация берётся из его учётных данных,
+// а заголовок X-Org-ID может только совпадать с ней. Без аутентификации
+// организация выбирается заголовком, по умолчанию — tenant.Default.
+func (h *Handler) resolveOrg(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested := r.Header.Get(tenant.Header)
+		principal := auth.FromContext(r.Context())
+		org, err := auth.ResolveOrg(principal, requested)
+		if err != nil {
+			if errors.Is(err, model.ErrForbidden) {
+				slog.WarnContext(r.Context(), "Organization mismatch", "subject", principal.Subject, "org", principal.Org, "requested", requested)
+			}
+			respondError(w, r, err)
+			return
+		}
+		if entry := accessEntryFrom(r.Context()); entry != nil {
+			entry.org = org
+		}
+		next.ServeHTTP(w, r.WithContext(tenant.NewContext(r.Context(), org)))
+	})
+}
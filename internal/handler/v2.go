@@ -0,0 +1,347 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/trainee/review-service/internal/api"
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/model"
+)
+
+// v1DeprecatedAt и v1Sunset — когда RPC-маршруты v1 объявлены устаревшими и когда
+// их планируется отключить.
+var (
+	v1DeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	v1Sunset       = time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// v1Successors связывает маршруты v1 с ресурсами v2, которые их заменяют.
+var v1Successors = map[string]string{
+	"/team/add":             "/v2/teams",
+	"/team/get":             "/v2/teams/{name}",
+	"/users/setIsActive":    "/v2/teams/{name}/members/{user_id}",
+	"/users/get":            "/v2/users/{user_id}",
+	"/users/list":           "/v2/users",
+	"/users/getReview":      "/v2/users/{user_id}/reviews",
+	"/pullRequest/create":   "/v2/pull-requests",
+	"/pullRequest/get":      "/v2/pull-requests/{id}",
+	"/pullRequest/list":     "/v2/pull-requests",
+	"/pullRequest/merge":    "/v2/pull-requests/{id}",
+	"/pullRequest/reassign": "/v2/pull-requests/{id}/reviewers/{user_id}",
+}
+
+// deprecateV1 помечает ответы маршрутов v1, у которых есть замена в v2, заголовками
+// Deprecation (RFC 9745), Sunset (RFC 8594) и ссылкой на преемника.
+func deprecateV1(next http.Handler) http.Handler {
+	deprecation := "@" + strconv.FormatInt(v1DeprecatedAt.Unix(), 10)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if successor, ok := v1Successors[r.URL.Path]; ok {
+			w.Header().Set("Deprecation", deprecation)
+			w.Header().Set("Sunset", v1Sunset.Format(http.TimeFormat))
+			w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// routesV2 регистрирует ресурсный API v2. Он использует тот же service.Service,
+// те же scopes и Idempotency-Key, что и v1, но ресурсы адресуются путём,
+// а операции — HTTP-методами.
+func (h *Handler) routesV2(r chi.Router, srv *api.ServerInterfaceWrapper) {
+	read := r.With(h.requireScope(auth.ScopeRead), validateRequest)
+	teamsWrite := r.With(h.requireScope(auth.ScopeTeamsWrite), h.idempotent, validateRequest)
+	prsWrite := r.With(h.requireScope(auth.ScopePRsWrite), h.idempotent, validateRequest)
+
+	// Teams
+	teamsWrite.Post("/teams", srv.CreateTeamV2)
+	read.Get("/teams/{name}", srv.GetTeamV2)
+	read.Get("/teams/{name}/members/{user_id}", srv.GetTeamMemberV2)
+	teamsWrite.Patch("/teams/{name}/members/{user_id}", srv.UpdateTeamMemberV2)
+	teamsWrite.Put("/teams/{name}/members/{user_id}", srv.PutTeamMemberV2)
+	teamsWrite.Delete("/teams/{name}/members/{user_id}", srv.RemoveTeamMemberV2)
+
+	// Users
+	read.Get("/users", srv.ListUsersV2)
+	read.Get("/users/{user_id}", srv.GetUserV2)
+	read.Get("/users/{user_id}/reviews", srv.GetUserReviewsV2)
+
+	// PullRequests
+	prsWrite.Post("/pull-requests", srv.CreatePullRequestV2)
+	read.Get("/pull-requests", srv.ListPullRequestsV2)
+	read.Get("/pull-requests/{id}", srv.GetPullRequestV2)
+	prsWrite.Patch("/pull-requests/{id}", srv.UpdatePullRequestV2)
+	read.Get("/pull-requests/{id}/reviewers", srv.ListReviewersV2)
+	prsWrite.Delete("/pull-requests/{id}/reviewers/{user_id}", srv.RemoveReviewerV2)
+}
+
+// POST /v2/teams
+func (h *Handler) CreateTeamV2(ctx context.Context, req api.CreateTeamV2RequestObject) (api.CreateTeamV2ResponseObject, error) {
+	team, err := newTeam(*req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.service.CreateTeam(ctx, team); err != nil {
+		return nil, err
+	}
+	return api.CreateTeamV2201JSONResponse{
+		Body:    apiTeam(team),
+		Headers: api.CreateTeamV2201ResponseHeaders{Location: "/v2/teams/" + url.PathEscape(team.TeamName)},
+	}, nil
+}
+
+// GET /v2/teams/{name}
+func (h *Handler) GetTeamV2(ctx context.Context, req api.GetTeamV2RequestObject) (api.GetTeamV2ResponseObject, error) {
+	if err := notBlank("name", req.Name); err != nil {
+		return nil, err
+	}
+	team, err := h.service.GetTeam(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if notModified(req.Params.IfNoneMatch, team.Version) {
+		return api.GetTeamV2304Response{Headers: api.NotModifiedResponseHeaders{ETag: etag(team.Version)}}, nil
+	}
+	return api.GetTeamV2200JSONResponse{
+		Body:    apiTeam(*team),
+		Headers: api.GetTeamV2200ResponseHeaders{ETag: etag(team.Version)},
+	}, nil
+}
+
+// teamMember находит пользователя по пути /teams/{name}/members/{user_id};
+// участник другой команды считается ненайденным.
+func (h *Handler) teamMember(ctx context.Context, name, userID string) (*model.UserDetails, error) {
+	if err := notBlank("name", name, "user_id", userID); err != nil {
+		return nil, err
+	}
+	user, err := h.service.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TeamName != name {
+		return nil, model.ErrNotFound
+	}
+	return user, nil
+}
+
+// GET /v2/teams/{name}/members/{user_id}
+func (h *Handler) GetTeamMemberV2(ctx context.Context, req api.GetTeamMemberV2RequestObject) (api.GetTeamMemberV2ResponseObject, error) {
+	user, err := h.teamMember(ctx, req.Name, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if notModified(req.Params.IfNoneMatch, user.Version) {
+		return api.GetTeamMemberV2304Response{Headers: api.NotModifiedResponseHeaders{ETag: etag(user.Version)}}, nil
+	}
+	return api.GetTeamMemberV2200JSONResponse{
+		Body:    apiUserDetails(*user),
+		Headers: api.GetTeamMemberV2200ResponseHeaders{ETag: etag(user.Version)},
+	}, nil
+}
+
+// PATCH /v2/teams/{name}/members/{user_id}
+func (h *Handler) UpdateTeamMemberV2(ctx context.Context, req api.UpdateTeamMemberV2RequestObject) (api.UpdateTeamMemberV2ResponseObject, error) {
+	member, err := h.teamMember(ctx, req.Name, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err = withIfMatch(ctx, req.Params.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+	user, err := h.service.SetUserActiveStatus(ctx, member.UserID, req.Body.IsActive)
+	if err != nil {
+		return nil, err
+	}
+	return api.UpdateTeamMemberV2200JSONResponse{
+		Body:    apiUser(*user),
+		Headers: api.UpdateTeamMemberV2200ResponseHeaders{ETag: etag(user.Version)},
+	}, nil
+}
+
+// PUT /v2/teams/{name}/members/{user_id}
+func (h *Handler) PutTeamMemberV2(ctx context.Context, req api.PutTeamMemberV2RequestObject) (api.PutTeamMemberV2ResponseObject, error) {
+	if err := notBlank("name", req.Name, "user_id", req.UserId, "username", req.Body.Username); err != nil {
+		return nil, err
+	}
+	ctx, err := withIfMatch(ctx, req.Params.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+	user, err := h.service.AddTeamMember(ctx, req.Name, model.TeamMember{UserID: req.UserId, Username: req.Body.Username, IsActive: req.Body.IsActive})
+	if err != nil {
+		return nil, err
+	}
+	return api.PutTeamMemberV2200JSONResponse{
+		Body:    apiUser(*user),
+		Headers: api.PutTeamMemberV2200ResponseHeaders{ETag: etag(user.Version)},
+	}, nil
+}
+
+// DELETE /v2/teams/{name}/members/{user_id}
+func (h *Handler) RemoveTeamMemberV2(ctx context.Context, req api.RemoveTeamMemberV2RequestObject) (api.RemoveTeamMemberV2ResponseObject, error) {
+	if err := notBlank("name", req.Name, "user_id", req.UserId); err != nil {
+		return nil, err
+	}
+	ctx, err := withIfMatch(ctx, req.Params.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.service.RemoveTeamMember(ctx, req.Name, req.UserId); err != nil {
+		return nil, err
+	}
+	return api.RemoveTeamMemberV2204Response{}, nil
+}
+
+// GET /v2/users
+func (h *Handler) ListUsersV2(ctx context.Context, req api.ListUsersV2RequestObject) (api.ListUsersV2ResponseObject, error) {
+	users, err := h.listUsers(ctx, api.ListUsersParams(req.Params))
+	if err != nil {
+		return nil, err
+	}
+	return api.ListUsersV2200JSONResponse(users), nil
+}
+
+// GET /v2/users/{user_id}
+func (h *Handler) GetUserV2(ctx context.Context, req api.GetUserV2RequestObject) (api.GetUserV2ResponseObject, error) {
+	if err := notBlank("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+	user, err := h.service.GetUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if notModified(req.Params.IfNoneMatch, user.Version) {
+		return api.GetUserV2304Response{Headers: api.NotModifiedResponseHeaders{ETag: etag(user.Version)}}, nil
+	}
+	return api.GetUserV2200JSONResponse{
+		Body:    apiUserDetails(*user),
+		Headers: api.GetUserV2200ResponseHeaders{ETag: etag(user.Version)},
+	}, nil
+}
+
+// GET /v2/users/{user_id}/reviews
+func (h *Handler) GetUserReviewsV2(ctx context.Context, req api.GetUserReviewsV2RequestObject) (api.GetUserReviewsV2ResponseObject, error) {
+	if err := notBlank("user_id", req.UserId); err != nil {
+		return nil, err
+	}
+	prs, err := h.userReviews(ctx, req.UserId, req.Params.Status, req.Params.Limit, req.Params.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	return api.GetUserReviewsV2200JSONResponse(prs), nil
+}
+
+// POST /v2/pull-requests
+func (h *Handler) CreatePullRequestV2(ctx context.Context, req api.CreatePullRequestV2RequestObject) (api.CreatePullRequestV2ResponseObject, error) {
+	body := req.Body
+	if err := notBlank("pull_request_id", body.PullRequestId, "pull_request_name", body.PullRequestName, "author_id", body.AuthorId); err != nil {
+		return nil, err
+	}
+	pr, err := h.service.CreatePullRequest(ctx, body.PullRequestId, body.PullRequestName, body.AuthorId)
+	if err != nil {
+		return nil, err
+	}
+	return api.CreatePullRequestV2201JSONResponse{
+		Body: apiPullRequest(*pr),
+		Headers: api.CreatePullRequestV2201ResponseHeaders{
+			ETag:     etag(pr.Version),
+			Location: "/v2/pull-requests/" + url.PathEscape(pr.ID),
+		},
+	}, nil
+}
+
+// GET /v2/pull-requests
+func (h *Handler) ListPullRequestsV2(ctx context.Context, req api.ListPullRequestsV2RequestObject) (api.ListPullRequestsV2ResponseObject, error) {
+	prs, err := h.listPullRequests(ctx, api.ListPullRequestsParams(req.Params))
+	if err != nil {
+		return nil, err
+	}
+	return api.ListPullRequestsV2200JSONResponse(prs), nil
+}
+
+// GET /v2/pull-requests/{id}
+func (h *Handler) GetPullRequestV2(ctx context.Context, req api.GetPullRequestV2RequestObject) (api.GetPullRequestV2ResponseObject, error) {
+	if err := notBlank("id", req.Id); err != nil {
+		return nil, err
+	}
+	pr, err := h.service.GetPullRequest(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if notModified(req.Params.IfNoneMatch, pr.Version) {
+		return api.GetPullRequestV2304Response{Headers: api.NotModifiedResponseHeaders{ETag: etag(pr.Version)}}, nil
+	}
+	return api.GetPullRequestV2200JSONResponse{
+		Body:    apiPullRequest(*pr),
+		Headers: api.GetPullRequestV2200ResponseHeaders{ETag: etag(pr.Version)},
+	}, nil
+}
+
+// PATCH /v2/pull-requests/{id}
+// Единственное изменяемое поле — status, и только в MERGED (повтор идемпотентен).
+func (h *Handler) UpdatePullRequestV2(ctx context.Context, req api.UpdatePullRequestV2RequestObject) (api.UpdatePullRequestV2ResponseObject, error) {
+	if err := notBlank("id", req.Id); err != nil {
+		return nil, err
+	}
+	if req.Body.Status != api.MERGED {
+		return nil, model.Invalid("status", "enum", "status can only be changed to "+string(model.PRMerged))
+	}
+
+	ctx, err := withIfMatch(ctx, req.Params.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := h.service.MergePullRequest(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return api.UpdatePullRequestV2200JSONResponse{
+		Body:    apiPullRequest(*pr),
+		Headers: api.UpdatePullRequestV2200ResponseHeaders{ETag: etag(pr.Version)},
+	}, nil
+}
+
+// GET /v2/pull-requests/{id}/reviewers
+func (h *Handler) ListReviewersV2(ctx context.Context, req api.ListReviewersV2RequestObject) (api.ListReviewersV2ResponseObject, error) {
+	if err := notBlank("id", req.Id); err != nil {
+		return nil, err
+	}
+	pr, err := h.service.GetPullRequest(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if notModified(req.Params.IfNoneMatch, pr.Version) {
+		return api.ListReviewersV2304Response{Headers: api.NotModifiedResponseHeaders{ETag: etag(pr.Version)}}, nil
+	}
+	return api.ListReviewersV2200JSONResponse{
+		Body:    api.ReviewerList{PullRequestId: pr.ID, Reviewers: append([]string{}, pr.AssignedReviewers...)},
+		Headers: api.ListReviewersV2200ResponseHeaders{ETag: etag(pr.Version)},
+	}, nil
+}
+
+// DELETE /v2/pull-requests/{id}/reviewers/{user_id}
+// Снимает ревьювера с PR; сервис назначает замену из его команды.
+func (h *Handler) RemoveReviewerV2(ctx context.Context, req api.RemoveReviewerV2RequestObject) (api.RemoveReviewerV2ResponseObject, error) {
+	if err := notBlank("id", req.Id, "user_id", req.UserId); err != nil {
+		return nil, err
+	}
+
+	ctx, err := withIfMatch(ctx, req.Params.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+	pr, replacedBy, err := h.service.ReassignReviewer(ctx, req.Id, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return api.RemoveReviewerV2200JSONResponse{
+		Body:    api.ReviewerReplacement{PullRequest: apiPullRequest(*pr), ReplacedBy: replacedBy},
+		Headers: api.RemoveReviewerV2200ResponseHeaders{ETag: etag(pr.Version)},
+	}, nil
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/trainee/review-service/internal/api"
+	"github.com/trainee/review-service/internal/model"
+)
+
+// maxBodySize ограничивает тело JSON-запросов API (импорт и restore
+// ограничены отдельно).
+const maxBodySize = 1 << 20
+
+// validateRequest проверяет запрос по операции из openapi.yml до вызова
+// обработчика: размер тела, синтаксис JSON без данных после значения, затем
+// параметры и тело по схеме — обязательные поля, типы, enum, форматы
+// идентификаторов и отсутствие неизвестных полей. Все нарушения возвращаются
+// разом в details. Операция находится по шаблону маршрута chi, который
+// совпадает с путём в спецификации.
+func validateRequest(next http.Handler) http.Handler {
+	opts := &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc, MultiError: true}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rc := chi.RouteContext(r.Context())
+		pattern := rc.RoutePattern()
+		item := openAPISpec.Paths.Value(pattern)
+		if item == nil || item.GetOperation(r.Method) == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		op := item.GetOperation(r.Method)
+
+		if op.RequestBody != nil {
+			// Клиенты v1 исторически отправляют JSON без Content-Type.
+			if r.Header.Get("Content-Type") == "" {
+				r.Header.Set("Content-Type", "application/json")
+			}
+			body, err := readBody(w, r)
+			if err != nil {
+				respondError(w, r, err)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		params := make(map[string]string, len(rc.URLParams.Keys))
+		for i, key := range rc.URLParams.Keys {
+			params[key] = rc.URLParams.Values[i]
+		}
+		err := openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: params,
+			Route: &routers.Route{
+				Spec:      openAPISpec,
+				Path:      pattern,
+				PathItem:  item,
+				Method:    r.Method,
+				Operation: op,
+			},
+			Options: opts,
+		})
+		if err != nil {
+			respondError(w, r, &model.ValidationError{Details: fieldErrors(err)})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// readBody читает тело не длиннее maxBodySize и проверяет, что JSON — ровно
+// одно значение без данных после него.
+func readBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		if limitErr := bodyTooLarge(err); limitErr != nil {
+			return nil, limitErr
+		}
+		return nil, err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/json" || len(bytes.TrimSpace(body)) == 0 {
+		return body, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, model.Invalid("body", "syntax", "malformed JSON: "+err.Error())
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, model.Invalid("body", "trailingData", "unexpected data after the JSON value")
+	}
+	return body, nil
+}
+
+// bodyTooLarge переводит ошибку http.MaxBytesReader в нарушение maxBodySize;
+// для остальных ошибок возвращает nil.
+func bodyTooLarge(err error) error {
+	var tooLarge *http.MaxBytesError
+	if !errors.As(err, &tooLarge) {
+		return nil
+	}
+	return model.Invalid("body", "maxBodySize", fmt.Sprintf("request body must not exceed %d bytes", tooLarge.Limit))
+}
+
+// fieldErrors раскладывает ошибку kin-openapi на нарушения по полям.
+func fieldErrors(err error) []model.FieldError {
+	var details []model.FieldError
+	var walk func(where string, err error)
+	walk = func(where string, err error) {
+		switch e := err.(type) {
+		case openapi3.MultiError:
+			for _, inner := range e {
+				walk(where, inner)
+			}
+		case *openapi3filter.RequestError:
+			where = "body"
+			if e.Parameter != nil {
+				where = e.Parameter.Name
+			}
+			if e.Err == nil {
+				details = append(details, model.FieldError{Field: where, Rule: "invalid", Message: e.Reason})
+				return
+			}
+			walk(where, e.Err)
+		case *openapi3.SchemaError:
+			details = append(details, schemaFieldError(where, e))
+		default:
+			rule := "type"
+			if errors.Is(err, openapi3filter.ErrInvalidRequired) || errors.Is(err, openapi3filter.ErrInvalidEmptyValue) {
+				rule = "required"
+			}
+			details = append(details, model.FieldError{Field: where, Rule: rule, Message: err.Error()})
+		}
+	}
+	walk("body", err)
+
+	sort.SliceStable(details, func(i, j int) bool { return details[i].Field < details[j].Field })
+	return details
+}
+
+// schemaFieldError строит нарушение из ошибки схемы; путь внутри тела
+// записывается через точку: members.0.user_id.
+func schemaFieldError(where string, err *openapi3.SchemaError) model.FieldError {
+	path := err.JSONPointer()
+	rule := err.SchemaField
+	// Лишнее свойство kin-openapi относит к объекту, а не к самому свойству.
+	if rule == "properties" && strings.HasSuffix(err.Reason, " is unsupported") {
+		rule = "additionalProperties"
+		if name, uerr := strconv.Unquote(strings.TrimSuffix(strings.TrimPrefix(err.Reason, "property "), " is unsupported")); uerr == nil {
+			path = append(path, name)
+		}
+	}
+	field := where
+	if len(path) > 0 {
+		field = strings.Join(path, ".")
+		if where != "body" {
+			field = where + "." + field
+		}
+	}
+	return model.FieldError{Field: field, Rule: rule, Message: err.Reason}
+}
+
+// respondBadRequest отвечает 400 на параметры или тело, которые не разобрал
+// сгенерированный код (обычно их раньше отклоняет validateRequest).
+func respondBadRequest(w http.ResponseWriter, r *http.Request, err error) {
+	field, rule := "body", "syntax"
+	var (
+		format    *api.InvalidParamFormatError
+		required  *api.RequiredParamError
+		header    *api.RequiredHeaderError
+		tooMany   *api.TooManyValuesForParamError
+		unmarshal *api.UnmarshalingParamError
+	)
+	switch {
+	case errors.As(err, &format):
+		field, rule = format.ParamName, "type"
+	case errors.As(err, &required):
+		field, rule = required.ParamName, "required"
+	case errors.As(err, &header):
+		field, rule = header.ParamName, "required"
+	case errors.As(err, &tooMany):
+		field, rule = tooMany.ParamName, "maxItems"
+	case errors.As(err, &unmarshal):
+		field, rule = unmarshal.ParamName, "type"
+	}
+	respondError(w, r, model.Invalid(field, rule, err.Error()))
+}
+
+// notBlank проверяет обязательные строковые поля, которые схема не может
+// выразить (например, одно из двух полей); fields — пары имя, значение.
+func notBlank(fields ...string) error {
+	var details []model.FieldError
+	for i := 0; i+1 < len(fields); i += 2 {
+		if strings.TrimSpace(fields[i+1]) == "" {
+			details = append(details, model.FieldError{Field: fields[i], Rule: "required", Message: "must not be blank"})
+		}
+	}
+	if len(details) == 0 {
+		return nil
+	}
+	return &model.ValidationError{Details: details}
+}
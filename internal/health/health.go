@@ -0,0 +1,271 @@
+// Package health собирает проверки готовности сервиса (/readyz): доступность
+// БД, версию схемы, загрузку пула и состояние фоновых задач.
+//
+// Живость (/livez) от зависимостей не зависит: процесс жив, пока отвечает.
+// Готовность пропадает при отказе любой проверки и сразу после начала
+// остановки, чтобы балансировщик перестал присылать новые запросы.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/trainee/review-service/migrations"
+)
+
+// DefaultTimeout — время на одну проверку, если не задано иное.
+const DefaultTimeout = 2 * time.Second
+
+// Статусы проверок и отчёта.
+const (
+	StatusOK       = "ok"
+	StatusFailing  = "failing"
+	StatusReady    = "ready"
+	StatusNotReady = "not_ready"
+)
+
+// ErrShuttingDown — сервис останавливается и новые запросы не принимает.
+var ErrShuttingDown = errors.New("shutting down")
+
+// CheckFunc проверяет зависимость. Детали попадают в отчёт и при отказе.
+type CheckFunc func(ctx context.Context) (details map[string]any, err error)
+
+// CheckResult — результат одной проверки.
+type CheckResult struct {
+	Status     string         `json:"status"`
+	Error      string         `json:"error,omitempty"`
+	DurationMS float64        `json:"duration_ms"`
+	Details    map[string]any `json:"details,omitempty"`
+}
+
+// Report — ответ /readyz.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Ready сообщает, готов ли сервис принимать запросы.
+func (r Report) Ready() bool {
+	return r.Status == StatusReady
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker выполняет проверки готовности.
+type Checker struct {
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+
+	mu     sync.Mutex
+	checks []check
+}
+
+// NewChecker создаёт Checker; timeout ограничивает каждую проверку
+// (0 — DefaultTimeout).
+func NewChecker(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Checker{timeout: timeout}
+}
+
+// Add регистрирует проверку.
+func (c *Checker) Add(name string, fn CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+// ShutDown переводит сервис в «не готов»; вызывается в начале остановки.
+func (c *Checker) ShutDown() {
+	c.shuttingDown.Store(true)
+}
+
+// Check выполняет все проверки параллельно.
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.Lock()
+	checks := append([]check(nil), c.checks...)
+	c.mu.Unlock()
+
+	report := Report{Status: StatusReady, Checks: make(map[string]CheckResult, len(checks)+1)}
+	if c.shuttingDown.Load() {
+		report.Status = StatusNotReady
+		report.Checks["shutdown"] = CheckResult{Status: StatusFailing, Error: ErrShuttingDown.Error()}
+	}
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, ch := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, ch.fn)
+		}()
+	}
+	wg.Wait()
+
+	for i, ch := range checks {
+		report.Checks[ch.name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusNotReady
+		}
+	}
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, fn CheckFunc) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	details, err := fn(ctx)
+	result := CheckResult{
+		Status:     StatusOK,
+		DurationMS: float64(time.Since(start).Microseconds()) / 1000,
+		Details:    details,
+	}
+	if err != nil {
+		result.Status = StatusFailing
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// --- Проверки ---
+
+// Database проверяет, что БД отвечает.
+func Database(pool *pgxpool.Pool) CheckFunc {
+	return func(ctx context.Context) (map[string]any, error) {
+		return nil, pool.Ping(ctx)
+	}
+}
+
+// Schema проверяет схему БД. Более новая схема, чем ожидает бинарник, готовности
+// не мешает: при обновлении миграции применяет новая версия, а старые поды ещё
+// обслуживают трафик. Строгое совпадение версий проверяется при старте.
+func Schema(m *migrations.Migrator) CheckFunc {
+	return func(ctx context.Context) (map[string]any, error) {
+		st, err := m.Status(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return schemaStatus(st)
+	}
+}
+
+// schemaStatus отказывает, если схема грязная или старше бинарника;
+// difference — на сколько версий схема новее (отрицательная — старее).
+func schemaStatus(st migrations.Status) (map[string]any, error) {
+	details := map[string]any{
+		"version":    st.Version,
+		"latest":     st.Latest,
+		"dirty":      st.Dirty,
+		"difference": int64(st.Version) - int64(st.Latest),
+	}
+	switch {
+	case st.Dirty:
+		return details, fmt.Errorf("%w at version %d", migrations.ErrDirty, st.Version)
+	case st.Version < st.Latest:
+		return details, fmt.Errorf("%w: database is at %d, binary expects %d", migrations.ErrVersionMismatch, st.Version, st.Latest)
+	}
+	return details, nil
+}
+
+// PoolStats — загрузка пула соединений.
+type PoolStats struct {
+	Acquired int32
+	Idle     int32
+	Total    int32
+	Max      int32
+}
+
+// Pool сообщает о насыщении пула: когда занята доля maxUsage соединений
+// (1 — все), новые запросы будут ждать соединения.
+func Pool(stat func() PoolStats, maxUsage float64) CheckFunc {
+	return func(context.Context) (map[string]any, error) {
+		s := stat()
+		usage := 0.0
+		if s.Max > 0 {
+			usage = float64(s.Acquired) / float64(s.Max)
+		}
+		details := map[string]any{"acquired": s.Acquired, "idle": s.Idle, "total": s.Total, "max": s.Max, "usage": usage}
+		if usage >= maxUsage {
+			return details, fmt.Errorf("connection pool is saturated: %d of %d connections in use", s.Acquired, s.Max)
+		}
+		return details, nil
+	}
+}
+
+// PgxPoolStats читает загрузку pgxpool.
+func PgxPoolStats(pool *pgxpool.Pool) func() PoolStats {
+	return func() PoolStats {
+		s := pool.Stat()
+		return PoolStats{Acquired: s.AcquiredConns(), Idle: s.IdleConns(), Total: s.TotalConns(), Max: s.MaxConns()}
+	}
+}
+
+// Worker отслеживает фоновую задачу, которая выполняется каждые interval.
+// Задача неисправна, если остановилась или не завершалась успешно дольше
+// двух интервалов.
+type Worker struct {
+	interval time.Duration
+	now      func() time.Time
+
+	mu          sync.Mutex
+	lastSuccess time.Time
+	lastError   error
+	stopped     bool
+}
+
+// NewWorker создаёт трекер задачи; отсчёт начинается с момента создания.
+func NewWorker(interval time.Duration) *Worker {
+	return &Worker{interval: interval, now: time.Now, lastSuccess: time.Now()}
+}
+
+// Success отмечает успешный запуск задачи.
+func (w *Worker) Success() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.lastSuccess = w.now()
+	w.lastError = nil
+}
+
+// Failure отмечает неудачный запуск задачи.
+func (w *Worker) Failure(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.lastError = err
+}
+
+// Stopped отмечает, что задача завершилась.
+func (w *Worker) Stopped() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopped = true
+}
+
+// Check — проверка готовности для задачи.
+func (w *Worker) Check(context.Context) (map[string]any, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	details := map[string]any{"last_success": w.lastSuccess.UTC().Format(time.RFC3339)}
+	if w.lastError != nil {
+		details["last_error"] = w.lastError.Error()
+	}
+	switch {
+	case w.stopped:
+		return details, errors.New("worker is not running")
+	case w.now().Sub(w.lastSuccess) > 2*w.interval:
+		return details, fmt.Errorf("no successful run for %s", w.now().Sub(w.lastSuccess).Round(time.Second))
+	}
+	return details, nil
+}
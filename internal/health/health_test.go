@@ -0,0 +1,111 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trainee/review-service/migrations"
+)
+
+func TestChecker(t *testing.T) {
+	c := NewChecker(50 * time.Millisecond)
+	c.Add("ok", func(context.Context) (map[string]any, error) {
+		return map[string]any{"version": 7}, nil
+	})
+	report := c.Check(context.Background())
+	require.True(t, report.Ready())
+	require.Equal(t, StatusOK, report.Checks["ok"].Status)
+	require.Equal(t, 7, report.Checks["ok"].Details["version"])
+
+	// Зависшая зависимость ограничена таймаутом и делает сервис неготовым.
+	c.Add("slow", func(ctx context.Context) (map[string]any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	start := time.Now()
+	report = c.Check(context.Background())
+	require.Less(t, time.Since(start), time.Second)
+	require.False(t, report.Ready())
+	require.Equal(t, StatusNotReady, report.Status)
+	require.Equal(t, StatusFailing, report.Checks["slow"].Status)
+	require.Equal(t, context.DeadlineExceeded.Error(), report.Checks["slow"].Error)
+	require.Equal(t, StatusOK, report.Checks["ok"].Status)
+}
+
+func TestCheckerShutDown(t *testing.T) {
+	c := NewChecker(0)
+	require.True(t, c.Check(context.Background()).Ready())
+
+	c.ShutDown()
+	report := c.Check(context.Background())
+	require.False(t, report.Ready())
+	require.Equal(t, ErrShuttingDown.Error(), report.Checks["shutdown"].Error)
+}
+
+func TestSchemaStatus(t *testing.T) {
+	details, err := schemaStatus(migrations.Status{Version: 8, Latest: 8})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), details["difference"])
+
+	// Схему уже обновил более новый бинарник — под остаётся готовым.
+	details, err = schemaStatus(migrations.Status{Version: 9, Latest: 8})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), details["difference"])
+
+	details, err = schemaStatus(migrations.Status{Version: 7, Latest: 8})
+	require.ErrorIs(t, err, migrations.ErrVersionMismatch)
+	require.Equal(t, int64(-1), details["difference"])
+
+	_, err = schemaStatus(migrations.Status{Version: 9, Latest: 8, Dirty: true})
+	require.ErrorIs(t, err, migrations.ErrDirty)
+}
+
+func TestPool(t *testing.T) {
+	stats := PoolStats{Acquired: 3, Idle: 1, Total: 4, Max: 4}
+	check := Pool(func() PoolStats { return stats }, 1)
+
+	details, err := check(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 0.75, details["usage"])
+
+	stats.Acquired, stats.Idle = 4, 0
+	_, err = check(context.Background())
+	require.ErrorContains(t, err, "4 of 4 connections in use")
+
+	_, err = Pool(func() PoolStats { return PoolStats{Acquired: 3, Max: 4} }, 0.7)(context.Background())
+	require.Error(t, err)
+}
+
+func TestWorker(t *testing.T) {
+	now := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+	w := NewWorker(time.Hour)
+	w.now = func() time.Time { return now }
+	w.lastSuccess = now
+
+	_, err := w.Check(context.Background())
+	require.NoError(t, err)
+
+	// Единичная ошибка видна в деталях, но задача ещё исправна.
+	now = now.Add(time.Hour)
+	w.Failure(errors.New("connection refused"))
+	details, err := w.Check(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "connection refused", details["last_error"])
+
+	now = now.Add(90 * time.Minute)
+	_, err = w.Check(context.Background())
+	require.ErrorContains(t, err, "no successful run for 2h30m0s")
+
+	w.Success()
+	details, err = w.Check(context.Background())
+	require.NoError(t, err)
+	require.NotContains(t, details, "last_error")
+
+	w.Stopped()
+	_, err = w.Check(context.Background())
+	require.ErrorContains(t, err, "not running")
+}
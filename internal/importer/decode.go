@@ -0,0 +1,201 @@
+// Package importer разбирает входные данные массового импорта (JSON Lines и CSV).
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trainee/review-service/internal/model"
+)
+
+// Format — формат входных данных.
+type Format string
+
+const (
+	FormatJSONL Format = "jsonl"
+	FormatCSV   Format = "csv"
+)
+
+// ErrUnknownFormat возвращается для неподдерживаемого формата.
+var ErrUnknownFormat = errors.New("importer: unknown format")
+
+// maxLineSize ограничивает длину одной строки JSON Lines.
+const maxLineSize = 1 << 20
+
+// csvColumns — допустимые колонки CSV; обязательна только kind.
+// assigned_reviewers перечисляются через ';', время — в RFC 3339.
+var csvColumns = []string{
+	"kind", "team_name", "user_id", "username", "is_active",
+	"pull_request_id", "pull_request_name", "author_id", "status",
+	"assigned_reviewers", "created_at", "merged_at",
+}
+
+// ParseFormat распознаёт формат по имени ("jsonl", "ndjson", "csv").
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "jsonl", "ndjson":
+		return FormatJSONL, nil
+	case "csv":
+		return FormatCSV, nil
+	default:
+		return "", ErrUnknownFormat
+	}
+}
+
+// Decode читает записи импорта. Ошибки отдельных строк попадают в ImportBatch.Rejected и не
+// прерывают разбор; ошибка верхнего уровня означает, что вход невозможно разобрать целиком.
+func Decode(r io.Reader, format Format) (*model.ImportBatch, error) {
+	switch format {
+	case FormatJSONL:
+		return decodeJSONL(r)
+	case FormatCSV:
+		return decodeCSV(r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func decodeJSONL(r io.Reader) (*model.ImportBatch, error) {
+	batch := &model.ImportBatch{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		var rec model.ImportRecord
+		if err := dec.Decode(&rec); err != nil {
+			batch.Rejected = append(batch.Rejected, model.ImportRowError{Row: line, Message: "invalid JSON: " + err.Error()})
+			continue
+		}
+		rec.Row = line
+		batch.Records = append(batch.Records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("importer: read line %d: %w", line+1, err)
+	}
+	return batch, nil
+}
+
+func decodeCSV(r io.Reader) (*model.ImportBatch, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("importer: read CSV header: %w", err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !isCSVColumn(name) {
+			return nil, fmt.Errorf("importer: unknown CSV column %q", name)
+		}
+		index[name] = i
+	}
+	if _, ok := index["kind"]; !ok {
+		return nil, errors.New("importer: CSV header must contain kind column")
+	}
+
+	batch := &model.ImportBatch{}
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				batch.Rejected = append(batch.Rejected, model.ImportRowError{Row: parseErr.Line, Message: "invalid CSV: " + parseErr.Err.Error()})
+				continue
+			}
+			return nil, fmt.Errorf("importer: read CSV: %w", err)
+		}
+
+		get := func(name string) string {
+			if i, ok := index[name]; ok && i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+		rec, err := csvRecord(get)
+		if err != nil {
+			batch.Rejected = append(batch.Rejected, model.ImportRowError{Row: line, Kind: model.ImportKind(get("kind")), Message: err.Error()})
+			continue
+		}
+		rec.Row = line
+		batch.Records = append(batch.Records, rec)
+	}
+	return batch, nil
+}
+
+func csvRecord(get func(string) string) (model.ImportRecord, error) {
+	rec := model.ImportRecord{
+		Kind:            model.ImportKind(get("kind")),
+		TeamName:        get("team_name"),
+		UserID:          get("user_id"),
+		Username:        get("username"),
+		PullRequestID:   get("pull_request_id"),
+		PullRequestName: get("pull_request_name"),
+		AuthorID:        get("author_id"),
+		Status:          model.PRStatus(get("status")),
+	}
+	if raw := get("is_active"); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return rec, fmt.Errorf("invalid is_active %q", raw)
+		}
+		rec.IsActive = &v
+	}
+	if raw := get("assigned_reviewers"); raw != "" {
+		for _, id := range strings.Split(raw, ";") {
+			if id = strings.TrimSpace(id); id != "" {
+				rec.AssignedReviewers = append(rec.AssignedReviewers, id)
+			}
+		}
+	}
+	var err error
+	if rec.CreatedAt, err = parseCSVTime(get("created_at")); err != nil {
+		return rec, fmt.Errorf("invalid created_at: %w", err)
+	}
+	if rec.MergedAt, err = parseCSVTime(get("merged_at")); err != nil {
+		return rec, fmt.Errorf("invalid merged_at: %w", err)
+	}
+	return rec, nil
+}
+
+func parseCSVTime(raw string) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func isCSVColumn(name string) bool {
+	for _, c := range csvColumns {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
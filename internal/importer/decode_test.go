@@ -0,0 +1,76 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trainee/review-service/internal/model"
+)
+
+func TestDecodeJSONL(t *testing.T) {
+	input := `{"kind":"team","team_name":"payments"}
+
+{"kind":"user","user_id":"u1","username":"alice","team_name":"payments","is_active":true}
+{"kind":"pull_request","pull_request_id":"pr-1","pull_request_name":"feat","author_id":"u1","status":"MERGED","assigned_reviewers":["u2"],"created_at":"2025-01-01T10:00:00Z","merged_at":"2025-01-02T10:00:00Z"}
+{"kind":"user","bogus":1}
+not json
+`
+	batch, err := Decode(strings.NewReader(input), FormatJSONL)
+	require.NoError(t, err)
+	require.Len(t, batch.Records, 3)
+	require.Equal(t, 1, batch.Records[0].Row)
+	require.Equal(t, 3, batch.Records[1].Row)
+	require.True(t, *batch.Records[1].IsActive)
+
+	pr := batch.Records[2]
+	require.Equal(t, model.ImportPR, pr.Kind)
+	require.Equal(t, model.PRMerged, pr.Status)
+	require.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+	require.Equal(t, 2025, pr.CreatedAt.Year())
+
+	require.Len(t, batch.Rejected, 2)
+	require.Equal(t, 5, batch.Rejected[0].Row)
+	require.Equal(t, 6, batch.Rejected[1].Row)
+}
+
+func TestDecodeCSV(t *testing.T) {
+	input := "kind,team_name,user_id,username,is_active,pull_request_id,pull_request_name,author_id,status,assigned_reviewers,created_at\n" +
+		"team,payments,,,,,,,,,\n" +
+		"user,payments,u1,alice,true,,,,,,\n" +
+		"user,payments,u2,bob,maybe,,,,,,\n" +
+		"pull_request,,,,,pr-1,feat,u1,OPEN,u2;u3,2025-01-01T10:00:00Z\n"
+
+	batch, err := Decode(strings.NewReader(input), FormatCSV)
+	require.NoError(t, err)
+	require.Len(t, batch.Records, 3)
+	require.Equal(t, model.ImportTeam, batch.Records[0].Kind)
+	require.Equal(t, 2, batch.Records[0].Row)
+	require.Equal(t, []string{"u2", "u3"}, batch.Records[2].AssignedReviewers)
+	require.NotNil(t, batch.Records[2].CreatedAt)
+
+	require.Len(t, batch.Rejected, 1)
+	require.Equal(t, 4, batch.Rejected[0].Row)
+	require.Contains(t, batch.Rejected[0].Message, "is_active")
+}
+
+func TestDecodeCSVHeaderErrors(t *testing.T) {
+	_, err := Decode(strings.NewReader("team_name\npayments\n"), FormatCSV)
+	require.Error(t, err)
+
+	_, err = Decode(strings.NewReader("kind,color\nteam,red\n"), FormatCSV)
+	require.Error(t, err)
+
+	_, err = Decode(strings.NewReader(""), Format("xml"))
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("NDJSON")
+	require.NoError(t, err)
+	require.Equal(t, FormatJSONL, f)
+
+	_, err = ParseFormat("xlsx")
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
@@ -0,0 +1,79 @@
+// Package logging связывает записи журнала с запросом: request ID передаётся
+// через context, а Handler добавляет его ко всем записям, сделанным через
+// slog.*Context. Так строки сервиса и репозитория можно сопоставить со строкой
+// access log.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"regexp"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Header — заголовок с request ID: принимается от клиента или прокси и
+// возвращается в ответе.
+const Header = "X-Request-ID"
+
+// Key — имя атрибута с request ID в записях журнала.
+const Key = "request_id"
+
+var validID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// ValidRequestID проверяет request ID клиента: в журнал не должны попадать
+// произвольные строки.
+func ValidRequestID(id string) bool {
+	return validID.MatchString(id)
+}
+
+// NewRequestID создаёт случайный request ID.
+func NewRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+type requestIDKey struct{}
+
+// NewContext возвращает копию ctx с request ID.
+func NewContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID возвращает request ID из ctx или пустую строку.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Handler добавляет request ID и, если запрос трассируется, trace_id и span_id
+// из context к записям вложенного обработчика.
+type Handler struct {
+	slog.Handler
+}
+
+// NewHandler оборачивает h.
+func NewHandler(h slog.Handler) *Handler {
+	return &Handler{Handler: h}
+}
+
+func (h *Handler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String(Key, id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Handler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{Handler: h.Handler.WithGroup(name)}
+}
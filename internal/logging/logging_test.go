@@ -0,0 +1,44 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerAddsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewJSONHandler(&buf, nil))).With("component", "test")
+
+	ctx := NewContext(context.Background(), "req-1")
+	logger.InfoContext(ctx, "with id")
+	logger.Info("without id")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	var first, second map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+	require.Equal(t, "req-1", first[Key])
+	require.Equal(t, "test", first["component"])
+	require.NotContains(t, second, Key)
+}
+
+func TestRequestID(t *testing.T) {
+	require.Empty(t, RequestID(context.Background()))
+
+	id := NewRequestID()
+	require.Len(t, id, 32)
+	require.True(t, ValidRequestID(id))
+	require.NotEqual(t, id, NewRequestID())
+
+	require.True(t, ValidRequestID("7f3c:edge-1.a_b"))
+	require.False(t, ValidRequestID(""))
+	require.False(t, ValidRequestID("bad id\n"))
+	require.False(t, ValidRequestID(strings.Repeat("a", 129)))
+}
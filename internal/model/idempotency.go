@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// IdempotencyRecord — сохранённый результат запроса с заголовком Idempotency-Key.
+type IdempotencyRecord struct {
+	// Scope отделяет ключи разных вызывающих внутри организации.
+	Scope string
+	Key   string
+	// Fingerprint — хэш метода, пути и тела запроса.
+	Fingerprint string
+	// StatusCode равен 0, пока исходный запрос выполняется.
+	StatusCode int
+	// Headers — заголовки ответа, которые повторяются вместе с телом.
+	Headers   map[string]string
+	Response  []byte
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
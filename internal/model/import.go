@@ -0,0 +1,90 @@
+package model
+
+import "time"
+
+// ImportKind — тип записи при массовом импорте.
+type ImportKind string
+
+const (
+	ImportTeam ImportKind = "team"
+	ImportUser ImportKind = "user"
+	ImportPR   ImportKind = "pull_request"
+)
+
+// ImportMode — стратегия применения импорта.
+type ImportMode string
+
+const (
+	// ImportAtomic применяет либо все строки, либо ни одной.
+	ImportAtomic ImportMode = "atomic"
+	// ImportBestEffort применяет все корректные строки и пропускает ошибочные.
+	ImportBestEffort ImportMode = "best_effort"
+)
+
+// ImportRecord — одна строка входных данных. Набор значимых полей зависит от Kind:
+// team — TeamName; user — UserID, Username, TeamName, IsActive;
+// pull_request — PullRequestID, PullRequestName, AuthorID и необязательные Status,
+// AssignedReviewers, CreatedAt, MergedAt.
+type ImportRecord struct {
+	Row  int        `json:"-"`
+	Kind ImportKind `json:"kind"`
+
+	TeamName string `json:"team_name,omitempty"`
+	UserID   string `json:"user_id,omitempty"`
+	Username string `json:"username,omitempty"`
+	IsActive *bool  `json:"is_active,omitempty"`
+
+	PullRequestID     string     `json:"pull_request_id,omitempty"`
+	PullRequestName   string     `json:"pull_request_name,omitempty"`
+	AuthorID          string     `json:"author_id,omitempty"`
+	Status            PRStatus   `json:"status,omitempty"`
+	AssignedReviewers []string   `json:"assigned_reviewers,omitempty"`
+	CreatedAt         *time.Time `json:"created_at,omitempty"`
+	MergedAt          *time.Time `json:"merged_at,omitempty"`
+}
+
+// Key возвращает идентификатор сущности, к которой относится запись.
+func (r ImportRecord) Key() string {
+	switch r.Kind {
+	case ImportTeam:
+		return r.TeamName
+	case ImportUser:
+		return r.UserID
+	case ImportPR:
+		return r.PullRequestID
+	default:
+		return ""
+	}
+}
+
+// ImportBatch — разобранные входные данные: корректные записи и строки, отвергнутые при разборе.
+type ImportBatch struct {
+	Records  []ImportRecord
+	Rejected []ImportRowError
+}
+
+// ImportOptions — параметры запуска импорта.
+type ImportOptions struct {
+	Mode   ImportMode
+	DryRun bool
+}
+
+// ImportRowError описывает ошибку в конкретной строке входных данных.
+type ImportRowError struct {
+	Row     int        `json:"row"`
+	Kind    ImportKind `json:"kind,omitempty"`
+	Key     string     `json:"key,omitempty"`
+	Message string     `json:"message"`
+}
+
+// ImportReport — итог импорта. Committed=false означает, что изменения не сохранены
+// (dry run или атомарный режим с ошибками).
+type ImportReport struct {
+	Mode      ImportMode       `json:"mode"`
+	DryRun    bool             `json:"dry_run"`
+	Committed bool             `json:"committed"`
+	Total     int              `json:"total"`
+	Applied   int              `json:"applied"`
+	Failed    int              `json:"failed"`
+	Errors    []ImportRowError `json:"errors"`
+}
@@ -14,7 +14,21 @@ var (
 	ErrNotAssigned = errors.New("reviewer is not assigned to this PR")
 	ErrNoCandidate = errors.New("no active replacement candidate in team")
 	ErrBadRequest  = errors.New("invalid request payload or parameters")
+	ErrNotEmpty    = errors.New("database is not empty")
+	ErrUserInUse   = errors.New("user is referenced by pull requests; deactivate instead")
 	ErrInternal    = errors.New("internal error")
+
+	// Ошибки аутентификации (401) и авторизации по scope токена и роли (403).
+	ErrUnauthorized      = errors.New("authentication required")
+	ErrInsufficientScope = errors.New("token does not have the required scope")
+	ErrForbidden         = errors.New("operation is not permitted for the caller")
+
+	// Ошибки повторных запросов с заголовком Idempotency-Key.
+	ErrIdempotencyKeyReused  = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyInProgress = errors.New("request with this idempotency key is still in progress")
+
+	// ErrPreconditionFailed — ресурс изменился с версии, указанной в If-Match.
+	ErrPreconditionFailed = errors.New("resource version does not match If-Match")
 )
 
 type PRStatus string
@@ -34,6 +48,8 @@ type TeamMember struct {
 type Team struct {
 	TeamName string       `json:"team_name"`
 	Members  []TeamMember `json:"members"`
+	// Version растёт при каждом изменении состава или участников команды (ETag).
+	Version int64 `json:"-"`
 }
 
 type User struct {
@@ -41,6 +57,8 @@ type User struct {
 	Username string `json:"username"`
 	TeamName string `json:"team_name"`
 	IsActive bool   `json:"is_active"`
+	// Version растёт при каждом изменении пользователя (ETag).
+	Version int64 `json:"-"`
 }
 
 type PullRequest struct {
@@ -51,6 +69,8 @@ type PullRequest struct {
 	AssignedReviewers []string   `json:"assigned_reviewers"`
 	CreatedAt         *time.Time `json:"createdAt,omitempty"`
 	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	// Version растёт при каждом изменении PR; UpdatePR ожидает текущую версию (ETag).
+	Version int64 `json:"-"`
 }
 
 type PullRequestShort struct {
@@ -58,4 +78,69 @@ type PullRequestShort struct {
 	Name     string   `json:"pull_request_name" db:"pull_request_name"`
 	AuthorID string   `json:"author_id" db:"author_id"`
 	Status   PRStatus `json:"status" db:"status"`
+	// CreatedAt нужен для курсора пагинации и не входит в схему PullRequestShort.
+	CreatedAt *time.Time `json:"-" db:"created_at"`
+}
+
+// UserDetails — пользователь вместе с текущей нагрузкой (число открытых PR на ревью).
+type UserDetails struct {
+	User
+	OpenReviewCount int `json:"open_review_count"`
+}
+
+// UserFilter — фильтры справочника пользователей (пустые значения не ограничивают выборку).
+type UserFilter struct {
+	TeamName       string
+	IsActive       *bool
+	UsernamePrefix string
+}
+
+// PageRequest — параметры курсорной пагинации.
+type PageRequest struct {
+	Limit  int
+	Cursor string
+}
+
+// UserPage — страница справочника пользователей.
+type UserPage struct {
+	Users      []UserDetails `json:"users"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+// PRFilter — фильтры поиска PR (пустые значения не ограничивают выборку).
+// Интервалы времени полуоткрытые: [From, To).
+type PRFilter struct {
+	Status       PRStatus
+	AuthorID     string
+	ReviewerID   string
+	TeamName     string // команда автора PR
+	NameContains string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	MergedFrom   *time.Time
+	MergedTo     *time.Time
+}
+
+// PRCursor — ключ keyset-пагинации PR: порядок (created_at DESC, pull_request_id DESC).
+type PRCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// PullRequestPage — страница результатов поиска PR.
+type PullRequestPage struct {
+	PullRequests []PullRequest `json:"pull_requests"`
+	NextCursor   string        `json:"next_cursor,omitempty"`
+}
+
+// ReviewFilter — выборка PR, где пользователь назначен ревьювером. Пустой Status — любой статус.
+type ReviewFilter struct {
+	UserID string
+	Status PRStatus
+}
+
+// ReviewPage — страница PR пользователя-ревьювера.
+type ReviewPage struct {
+	PullRequests []PullRequestShort `json:"pull_requests"`
+	NextCursor   string             `json:"next_cursor,omitempty"`
 }
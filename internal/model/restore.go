@@ -0,0 +1,9 @@
+package model
+
+// RestoreReport — итог восстановления данных из архива.
+type RestoreReport struct {
+	Version      int `json:"version"`
+	Teams        int `json:"teams"`
+	Users        int `json:"users"`
+	PullRequests int `json:"pull_requests"`
+}
@@ -0,0 +1,18 @@
+package model
+
+// Role — роль пользователя для проверки прав в сервисном слое.
+type Role string
+
+const (
+	// RoleOrgAdmin может всё.
+	RoleOrgAdmin Role = "org_admin"
+	// RoleTeamLead управляет составом и активностью своей команды (TeamName).
+	RoleTeamLead Role = "team_lead"
+)
+
+// RoleAssignment — роль пользователя; для team_lead указывается команда.
+type RoleAssignment struct {
+	UserID   string `json:"user_id"`
+	Role     Role   `json:"role"`
+	TeamName string `json:"team_name,omitempty"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// APIToken — метаданные API токена. Значение токена показывается только при выпуске.
+type APIToken struct {
+	ID        string     `json:"token_id"`
+	OrgID     string     `json:"org_id"`
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import "strings"
+
+// FieldError — нарушенное правило для одного поля запроса. Rule совпадает с
+// ключевым словом схемы OpenAPI (required, pattern, maxLength, enum...) или
+// называет проверку, которой в схеме нет (unique, maxBodySize, trailingData).
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ValidationError — некорректный запрос с перечнем нарушенных полей.
+// errors.Is(err, ErrBadRequest) для неё истинно, код ответа — BAD_REQUEST.
+type ValidationError struct {
+	Details []FieldError
+}
+
+// Invalid создаёт ValidationError с одним нарушением.
+func Invalid(field, rule, message string) error {
+	return &ValidationError{Details: []FieldError{{Field: field, Rule: rule, Message: message}}}
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Details))
+	for _, d := range e.Details {
+		parts = append(parts, d.Field+": "+d.Message)
+	}
+	return ErrBadRequest.Error() + ": " + strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrBadRequest
+}
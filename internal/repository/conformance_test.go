@@ -0,0 +1,22 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/repository/repotest"
+)
+
+func TestPostgresConformance(t *testing.T) {
+	db, cleanup := repo.SetupTestDB(t)
+	defer cleanup()
+
+	repotest.Run(t, func(t *testing.T) repo.Repository {
+		_, err := db.Exec(context.Background(), `TRUNCATE teams, users, pull_requests, api_tokens, user_roles, idempotency_keys CASCADE`)
+		require.NoError(t, err)
+		return repo.NewPostgresRepository(db)
+	})
+}
@@ -0,0 +1,4 @@
+package repository
+
+// SetupTestDB открывает тестовую БД для внешних тестов пакета.
+var SetupTestDB = setupTestDB
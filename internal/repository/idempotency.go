@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/trainee/review-service/internal/model"
+)
+
+// IdempotencyRepository хранит ответы на запросы с Idempotency-Key в организации из контекста.
+type IdempotencyRepository interface {
+	// ClaimIdempotencyKey резервирует ключ за запросом. Если ключ уже занят и не истёк,
+	// возвращает существующую запись и ErrAlreadyExists.
+	ClaimIdempotencyKey(ctx context.Context, rec model.IdempotencyRecord) (*model.IdempotencyRecord, error)
+	// CompleteIdempotencyKey сохраняет ответ на зарезервированный запрос.
+	CompleteIdempotencyKey(ctx context.Context, scope, key string, status int, headers map[string]string, response []byte) error
+	// ReleaseIdempotencyKey снимает резерв, чтобы запрос можно было повторить.
+	ReleaseIdempotencyKey(ctx context.Context, scope, key string) error
+	// DeleteExpiredIdempotencyKeys удаляет истёкшие ключи всех организаций.
+	DeleteExpiredIdempotencyKeys(ctx context.Context, before time.Time) (int64, error)
+}
+
+func (r *PostgresRepository) ClaimIdempotencyKey(ctx context.Context, rec model.IdempotencyRecord) (*model.IdempotencyRecord, error) {
+	// Истёкший ключ перезаписывается так же, как отсутствующий.
+	claim := `
+		INSERT INTO idempotency_keys (org_id, scope, idempotency_key, fingerprint, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (org_id, scope, idempotency_key) DO UPDATE SET
+			fingerprint = EXCLUDED.fingerprint,
+			status_code = 0,
+			headers = NULL,
+			response = NULL,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at
+		RETURNING idempotency_key
+	`
+	org := orgOf(ctx)
+	// Вторая попытка нужна, если существующий ключ удалили между INSERT и SELECT.
+	for attempt := 0; attempt < 2; attempt++ {
+		var key string
+		err := r.pool.QueryRow(ctx, claim, org, rec.Scope, rec.Key, rec.Fingerprint, rec.CreatedAt, rec.ExpiresAt).Scan(&key)
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, handleError(err)
+		}
+
+		existing := model.IdempotencyRecord{Scope: rec.Scope, Key: rec.Key}
+		err = r.pool.QueryRow(ctx, `
+			SELECT fingerprint, status_code, COALESCE(headers, '{}'), COALESCE(response, ''), created_at, expires_at
+			FROM idempotency_keys
+			WHERE org_id = $1 AND scope = $2 AND idempotency_key = $3
+		`, org, rec.Scope, rec.Key).Scan(&existing.Fingerprint, &existing.StatusCode, &existing.Headers, &existing.Response, &existing.CreatedAt, &existing.ExpiresAt)
+		if err == nil {
+			return &existing, ErrAlreadyExists
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+	}
+	return nil, errors.New("repository: idempotency key claim did not settle")
+}
+
+func (r *PostgresRepository) CompleteIdempotencyKey(ctx context.Context, scope, key string, status int, headers map[string]string, response []byte) error {
+	query := `
+		UPDATE idempotency_keys SET status_code = $4, headers = $5, response = $6
+		WHERE org_id = $1 AND scope = $2 AND idempotency_key = $3
+	`
+	_, err := r.pool.Exec(ctx, query, orgOf(ctx), scope, key, status, headers, response)
+	return err
+}
+
+func (r *PostgresRepository) ReleaseIdempotencyKey(ctx context.Context, scope, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE org_id = $1 AND scope = $2 AND idempotency_key = $3 AND status_code = 0`
+	_, err := r.pool.Exec(ctx, query, orgOf(ctx), scope, key)
+	return err
+}
+
+func (r *PostgresRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
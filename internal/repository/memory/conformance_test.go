@@ -0,0 +1,13 @@
+package memory_test
+
+import (
+	"testing"
+
+	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/repository/memory"
+	"github.com/trainee/review-service/internal/repository/repotest"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(*testing.T) repo.Repository { return memory.New() })
+}
@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/trainee/review-service/internal/model"
+	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/tenant"
+)
+
+type idempotencyKey struct {
+	org, scope, key string
+}
+
+func (r *Repository) ClaimIdempotencyKey(ctx context.Context, rec model.IdempotencyRecord) (*model.IdempotencyRecord, error) {
+	k := idempotencyKey{tenant.FromContext(ctx), rec.Scope, rec.Key}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Истёкший ключ перезаписывается так же, как отсутствующий.
+	if existing, ok := r.idempotency[k]; ok && existing.ExpiresAt.After(rec.CreatedAt) {
+		existing.Headers = maps.Clone(existing.Headers)
+		existing.Response = append([]byte(nil), existing.Response...)
+		return &existing, repo.ErrAlreadyExists
+	}
+	rec.StatusCode, rec.Headers, rec.Response = 0, nil, nil
+	r.idempotency[k] = rec
+	return nil, nil
+}
+
+func (r *Repository) CompleteIdempotencyKey(ctx context.Context, scope, key string, status int, headers map[string]string, response []byte) error {
+	k := idempotencyKey{tenant.FromContext(ctx), scope, key}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rec, ok := r.idempotency[k]; ok {
+		rec.StatusCode, rec.Headers, rec.Response = status, maps.Clone(headers), append([]byte(nil), response...)
+		r.idempotency[k] = rec
+	}
+	return nil
+}
+
+func (r *Repository) ReleaseIdempotencyKey(ctx context.Context, scope, key string) error {
+	k := idempotencyKey{tenant.FromContext(ctx), scope, key}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rec, ok := r.idempotency[k]; ok && rec.StatusCode == 0 {
+		delete(r.idempotency, k)
+	}
+	return nil
+}
+
+func (r *Repository) DeleteExpiredIdempotencyKeys(_ context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int64
+	for k, rec := range r.idempotency {
+		if !rec.ExpiresAt.After(before) {
+			delete(r.idempotency, k)
+			n++
+		}
+	}
+	return n, nil
+}
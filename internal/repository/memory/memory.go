@@ -0,0 +1,410 @@
+// Package memory — хранилище в памяти процесса с тем же поведением, что и
+// PostgreSQL: организации, версии, внешние ключи и транзакции с откатом.
+// Подходит для демонстрации и быстрых тестов; данные теряются при остановке.
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/trainee/review-service/internal/model"
+	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/tenant"
+)
+
+var (
+	_ repo.Repository            = (*Repository)(nil)
+	_ repo.TxRepository          = (*txRepository)(nil)
+	_ repo.IdempotencyRepository = (*Repository)(nil)
+	_ repo.TokenRepository       = (*Repository)(nil)
+)
+
+// Repository хранит данные каждой организации отдельно.
+//
+// Изменения выполняются в транзакциях: транзакция работает с копией данных
+// (таблица копируется при первом изменении) и при фиксации подменяет ею
+// текущую версию, а при ошибке копия просто отбрасывается. Чтения вне
+// транзакции видят последнюю зафиксированную версию и не блокируются.
+// Транзакции одной организации выполняются по очереди, поэтому
+// GetPRByIDForUpdate внутри транзакции держит PR до её завершения, как
+// SELECT ... FOR UPDATE.
+type Repository struct {
+	now func() time.Time
+
+	mu          sync.Mutex
+	orgs        map[string]*org
+	tokens      map[string]storedToken
+	idempotency map[idempotencyKey]model.IdempotencyRecord
+}
+
+type Option func(*Repository)
+
+// WithClock задаёт источник времени для created_at, который не передан явно.
+func WithClock(now func() time.Time) Option {
+	return func(r *Repository) {
+		r.now = now
+	}
+}
+
+func New(opts ...Option) *Repository {
+	r := &Repository{
+		now:         time.Now,
+		orgs:        make(map[string]*org),
+		tokens:      make(map[string]storedToken),
+		idempotency: make(map[idempotencyKey]model.IdempotencyRecord),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// org — данные одной организации.
+type org struct {
+	// writer — блокировка транзакций: канал, а не мьютекс, чтобы ожидание
+	// прерывалось отменой контекста.
+	writer chan struct{}
+
+	mu   sync.RWMutex
+	data dataset
+}
+
+// dataset — зафиксированная версия данных. После фиксации таблицы не
+// меняются: транзакция копирует таблицу перед первым изменением.
+type dataset struct {
+	teams map[string]int64 // team_name → version
+	users map[string]model.User
+	prs   map[string]model.PullRequest
+	roles []model.RoleAssignment
+}
+
+func (r *Repository) org(ctx context.Context) *org {
+	id := tenant.FromContext(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	o, ok := r.orgs[id]
+	if !ok {
+		o = &org{
+			writer: make(chan struct{}, 1),
+			data: dataset{
+				teams: map[string]int64{},
+				users: map[string]model.User{},
+				prs:   map[string]model.PullRequest{},
+			},
+		}
+		r.orgs[id] = o
+	}
+	return o
+}
+
+// view возвращает последнюю зафиксированную версию данных для чтения.
+func (r *Repository) view(ctx context.Context) *txRepository {
+	o := r.org(ctx)
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return &txRepository{data: o.data, now: r.now}
+}
+
+func (r *Repository) WithTransaction(ctx context.Context, fn func(tx repo.TxRepository) error) error {
+	return r.update(ctx, func(tx *txRepository) error { return fn(tx) })
+}
+
+// update выполняет fn в транзакции и фиксирует изменения, если fn не вернула ошибку.
+// Вложенный вызов для той же организации внутри fn заблокируется до отмены ctx.
+func (r *Repository) update(ctx context.Context, fn func(tx *txRepository) error) error {
+	o := r.org(ctx)
+	select {
+	case o.writer <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-o.writer }()
+
+	o.mu.RLock()
+	tx := &txRepository{data: o.data, now: r.now}
+	o.mu.RUnlock()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	o.mu.Lock()
+	o.data = tx.data
+	o.mu.Unlock()
+	return nil
+}
+
+// --- Teams & Users ---
+
+func (r *Repository) CreateTeamTx(ctx context.Context, team model.Team) error {
+	return r.update(ctx, func(tx *txRepository) error {
+		if _, ok := tx.data.teams[team.TeamName]; ok {
+			return repo.ErrAlreadyExists
+		}
+		tx.ownTeams()
+		tx.data.teams[team.TeamName] = 1
+		for _, m := range team.Members {
+			err := tx.UpsertUser(ctx, model.User{UserID: m.UserID, Username: m.Username, TeamName: team.TeamName, IsActive: m.IsActive})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Repository) GetTeam(ctx context.Context, teamName string) (*model.Team, error) {
+	d := r.view(ctx).data
+	version, ok := d.teams[teamName]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	team := &model.Team{TeamName: teamName, Members: []model.TeamMember{}, Version: version}
+	for _, u := range sortedUsers(d.users) {
+		if u.TeamName == teamName {
+			team.Members = append(team.Members, model.TeamMember{UserID: u.UserID, Username: u.Username, IsActive: u.IsActive})
+		}
+	}
+	return team, nil
+}
+
+func (r *Repository) SetUserActiveStatus(ctx context.Context, userID string, isActive bool, expectedVersion int64) (*model.User, error) {
+	var user model.User
+	err := r.update(ctx, func(tx *txRepository) error {
+		u, ok := tx.data.users[userID]
+		if !ok {
+			return repo.ErrNotFound
+		}
+		if expectedVersion != 0 && u.Version != expectedVersion {
+			return repo.ErrVersionConflict
+		}
+		u.IsActive = isActive
+		u.Version++
+		tx.ownUsers()
+		tx.data.users[userID] = u
+		tx.bumpTeams(u.TeamName)
+		user = u
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *Repository) DeleteUser(ctx context.Context, userID string, expectedVersion int64) (*model.User, error) {
+	var user model.User
+	err := r.update(ctx, func(tx *txRepository) error {
+		u, ok := tx.data.users[userID]
+		if !ok {
+			return repo.ErrNotFound
+		}
+		if expectedVersion != 0 && u.Version != expectedVersion {
+			return repo.ErrVersionConflict
+		}
+		for _, pr := range tx.data.prs {
+			if pr.AuthorID == userID || contains(pr.AssignedReviewers, userID) {
+				return repo.ErrInUse
+			}
+		}
+		tx.ownUsers()
+		delete(tx.data.users, userID)
+		tx.bumpTeams(u.TeamName)
+		user = u
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *Repository) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
+	return r.view(ctx).GetUserByID(ctx, userID)
+}
+
+func (r *Repository) ListTeamMembers(ctx context.Context, teamName string) ([]model.User, error) {
+	return r.view(ctx).ListTeamMembers(ctx, teamName)
+}
+
+func (r *Repository) GetUserDetails(ctx context.Context, userID string) (*model.UserDetails, error) {
+	d := r.view(ctx).data
+	u, ok := d.users[userID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return &model.UserDetails{User: u, OpenReviewCount: openReviews(d.prs, userID)}, nil
+}
+
+// ListUsers возвращает пользователей по фильтру, упорядоченных по user_id (keyset-пагинация по afterUserID).
+func (r *Repository) ListUsers(ctx context.Context, filter model.UserFilter, afterUserID string, limit int) ([]model.UserDetails, error) {
+	d := r.view(ctx).data
+	prefix := strings.ToLower(filter.UsernamePrefix)
+	result := []model.UserDetails{}
+	for _, u := range sortedUsers(d.users) {
+		if len(result) == limit {
+			break
+		}
+		switch {
+		case filter.TeamName != "" && u.TeamName != filter.TeamName,
+			filter.IsActive != nil && u.IsActive != *filter.IsActive,
+			!strings.HasPrefix(strings.ToLower(u.Username), prefix),
+			u.UserID <= afterUserID:
+			continue
+		}
+		result = append(result, model.UserDetails{User: u, OpenReviewCount: openReviews(d.prs, u.UserID)})
+	}
+	return result, nil
+}
+
+// openReviews считает открытые PR, где пользователь назначен ревьювером.
+func openReviews(prs map[string]model.PullRequest, userID string) int {
+	count := 0
+	for _, pr := range prs {
+		if pr.Status == model.PROpen && contains(pr.AssignedReviewers, userID) {
+			count++
+		}
+	}
+	return count
+}
+
+// --- Pull Requests ---
+
+func (r *Repository) CreatePR(ctx context.Context, pr *model.PullRequest) error {
+	return r.update(ctx, func(tx *txRepository) error { return tx.CreatePR(ctx, pr) })
+}
+
+func (r *Repository) GetPRByID(ctx context.Context, prID string) (*model.PullRequest, error) {
+	return r.view(ctx).GetPRByID(ctx, prID)
+}
+
+// GetPRByIDForUpdate вне транзакции ничего не блокирует: блокировка, как и
+// в PostgreSQL, снялась бы сразу после чтения.
+func (r *Repository) GetPRByIDForUpdate(ctx context.Context, prID string) (*model.PullRequest, error) {
+	return r.GetPRByID(ctx, prID)
+}
+
+func (r *Repository) UpdatePR(ctx context.Context, pr *model.PullRequest) (*model.PullRequest, error) {
+	var updated *model.PullRequest
+	err := r.update(ctx, func(tx *txRepository) error {
+		var err error
+		updated, err = tx.UpdatePR(ctx, pr)
+		return err
+	})
+	return updated, err
+}
+
+// ListPRs ищет PR по фильтру в порядке (created_at DESC, pull_request_id DESC), начиная после курсора.
+func (r *Repository) ListPRs(ctx context.Context, filter model.PRFilter, after *model.PRCursor, limit int) ([]model.PullRequest, error) {
+	d := r.view(ctx).data
+	name := strings.ToLower(filter.NameContains)
+	result := []model.PullRequest{}
+	for _, pr := range sortedPRs(d.prs, after) {
+		if len(result) == limit {
+			break
+		}
+		switch {
+		case filter.Status != "" && pr.Status != filter.Status,
+			filter.AuthorID != "" && pr.AuthorID != filter.AuthorID,
+			filter.ReviewerID != "" && !contains(pr.AssignedReviewers, filter.ReviewerID),
+			filter.TeamName != "" && d.users[pr.AuthorID].TeamName != filter.TeamName,
+			!strings.Contains(strings.ToLower(pr.Name), name),
+			!inRange(pr.CreatedAt, filter.CreatedFrom, filter.CreatedTo),
+			!inRange(pr.MergedAt, filter.MergedFrom, filter.MergedTo):
+			continue
+		}
+		result = append(result, clonePR(pr))
+	}
+	return result, nil
+}
+
+// inRange проверяет t ∈ [from, to); без границ подходит и пустое значение.
+func inRange(t, from, to *time.Time) bool {
+	if from == nil && to == nil {
+		return true
+	}
+	return t != nil && (from == nil || !t.Before(*from)) && (to == nil || t.Before(*to))
+}
+
+func (r *Repository) GetPRsByReviewer(ctx context.Context, filter model.ReviewFilter, after *model.PRCursor, limit int) ([]model.PullRequestShort, error) {
+	return r.view(ctx).GetPRsByReviewer(ctx, filter, after, limit)
+}
+
+// --- Export ---
+
+// Export читает одну зафиксированную версию данных, поэтому выгрузка согласована.
+func (r *Repository) Export(ctx context.Context, sink repo.ExportSink) error {
+	d := r.view(ctx).data
+
+	teams := make([]string, 0, len(d.teams))
+	for name := range d.teams {
+		teams = append(teams, name)
+	}
+	sort.Strings(teams)
+	for _, name := range teams {
+		if err := sink.WriteTeam(name); err != nil {
+			return err
+		}
+	}
+
+	for _, u := range sortedUsers(d.users) {
+		if err := sink.WriteUser(model.User{UserID: u.UserID, Username: u.Username, TeamName: u.TeamName, IsActive: u.IsActive}); err != nil {
+			return err
+		}
+	}
+
+	prs := sortedPRs(d.prs, nil)
+	for i := len(prs) - 1; i >= 0; i-- {
+		if err := sink.WritePullRequest(clonePR(prs[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// --- Хелперы ---
+
+func sortedUsers(users map[string]model.User) []model.User {
+	result := make([]model.User, 0, len(users))
+	for _, u := range users {
+		result = append(result, u)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].UserID < result[j].UserID })
+	return result
+}
+
+// sortedPRs возвращает PR после курсора в порядке (created_at DESC, pull_request_id DESC).
+func sortedPRs(prs map[string]model.PullRequest, after *model.PRCursor) []model.PullRequest {
+	result := make([]model.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if after != nil && !pr.CreatedAt.Before(after.CreatedAt) && !(pr.CreatedAt.Equal(after.CreatedAt) && pr.ID < after.ID) {
+			continue
+		}
+		result = append(result, pr)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(*result[j].CreatedAt) {
+			return result[i].CreatedAt.After(*result[j].CreatedAt)
+		}
+		return result[i].ID > result[j].ID
+	})
+	return result
+}
+
+// clonePR копирует PR вместе со списком ревьюверов, чтобы вызывающий код не
+// мог изменить сохранённые данные.
+func clonePR(pr model.PullRequest) model.PullRequest {
+	pr.AssignedReviewers = append([]string{}, pr.AssignedReviewers...)
+	return pr
+}
+
+func contains(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
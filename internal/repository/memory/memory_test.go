@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trainee/review-service/internal/model"
+	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/tenant"
+)
+
+func seed(t *testing.T, r *Repository) {
+	t.Helper()
+	ctx := context.Background()
+	require.NoError(t, r.CreateTeamTx(ctx, model.Team{TeamName: "backend", Members: []model.TeamMember{
+		{UserID: "u1", Username: "Alice", IsActive: true},
+		{UserID: "u2", Username: "Bob", IsActive: true},
+	}}))
+	require.NoError(t, r.CreatePR(ctx, &model.PullRequest{ID: "pr1", Name: "feat", AuthorID: "u1", Status: model.PROpen, AssignedReviewers: []string{"u2"}}))
+}
+
+func TestTransactionRollback(t *testing.T) {
+	r := New()
+	seed(t, r)
+	ctx := context.Background()
+	errAbort := errors.New("abort")
+
+	err := r.WithTransaction(ctx, func(tx repo.TxRepository) error {
+		require.NoError(t, tx.EnsureTeam(ctx, "frontend"))
+		require.NoError(t, tx.UpsertUser(ctx, model.User{UserID: "u2", Username: "Bob", TeamName: "frontend"}))
+		pr, err := tx.GetPRByIDForUpdate(ctx, "pr1")
+		require.NoError(t, err)
+		pr.Status = model.PRMerged
+		_, err = tx.UpdatePR(ctx, pr)
+		require.NoError(t, err)
+
+		// Изменения видны внутри транзакции, но не снаружи.
+		u, err := tx.GetUserByID(ctx, "u2")
+		require.NoError(t, err)
+		require.Equal(t, "frontend", u.TeamName)
+		u, err = r.GetUserByID(ctx, "u2")
+		require.NoError(t, err)
+		require.Equal(t, "backend", u.TeamName)
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	_, err = r.GetTeam(ctx, "frontend")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	team, err := r.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	require.Len(t, team.Members, 2)
+	pr, err := r.GetPRByID(ctx, "pr1")
+	require.NoError(t, err)
+	require.Equal(t, model.PROpen, pr.Status)
+	require.Equal(t, int64(1), pr.Version)
+}
+
+func TestSavepointRollback(t *testing.T) {
+	r := New()
+	seed(t, r)
+	ctx := context.Background()
+
+	err := r.WithTransaction(ctx, func(tx repo.TxRepository) error {
+		require.NoError(t, tx.EnsureTeam(ctx, "frontend"))
+		err := tx.WithSavepoint(ctx, func(sp repo.TxRepository) error {
+			require.NoError(t, sp.UpsertUser(ctx, model.User{UserID: "u3", Username: "Carol", TeamName: "frontend"}))
+			return sp.CreatePR(ctx, &model.PullRequest{ID: "pr1", AuthorID: "u3", Status: model.PROpen})
+		})
+		require.ErrorIs(t, err, repo.ErrAlreadyExists)
+		return tx.WithSavepoint(ctx, func(sp repo.TxRepository) error {
+			return sp.UpsertUser(ctx, model.User{UserID: "u4", Username: "Dave", TeamName: "frontend", IsActive: true})
+		})
+	})
+	require.NoError(t, err)
+
+	team, err := r.GetTeam(ctx, "frontend")
+	require.NoError(t, err)
+	require.Equal(t, []model.TeamMember{{UserID: "u4", Username: "Dave", IsActive: true}}, team.Members)
+	_, err = r.GetUserByID(ctx, "u3")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+}
+
+// Транзакции читают PR через GetPRByIDForUpdate и сохраняют его с проверкой
+// версии: без сериализации часть из них получила бы ErrVersionConflict.
+func TestForUpdateSerializesTransactions(t *testing.T) {
+	r := New()
+	seed(t, r)
+	ctx := context.Background()
+
+	const n = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- r.WithTransaction(ctx, func(tx repo.TxRepository) error {
+				pr, err := tx.GetPRByIDForUpdate(ctx, "pr1")
+				if err != nil {
+					return err
+				}
+				time.Sleep(time.Millisecond)
+				_, err = tx.UpdatePR(ctx, pr)
+				return err
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	pr, err := r.GetPRByID(ctx, "pr1")
+	require.NoError(t, err)
+	require.Equal(t, int64(n+1), pr.Version)
+}
+
+func TestTransactionWaitHonorsContext(t *testing.T) {
+	r := New()
+	seed(t, r)
+	started, release := make(chan struct{}), make(chan struct{})
+	go func() {
+		_ = r.WithTransaction(context.Background(), func(repo.TxRepository) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := r.WithTransaction(ctx, func(repo.TxRepository) error { return nil })
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Чтения не ждут транзакцию.
+	_, err = r.GetPRByID(context.Background(), "pr1")
+	require.NoError(t, err)
+	// Транзакции другой организации тоже.
+	other := tenant.NewContext(context.Background(), "acme")
+	require.NoError(t, r.WithTransaction(other, func(tx repo.TxRepository) error { return tx.EnsureTeam(other, "backend") }))
+}
+
+func TestStoredDataIsCopied(t *testing.T) {
+	r := New()
+	seed(t, r)
+	ctx := context.Background()
+
+	pr, err := r.GetPRByID(ctx, "pr1")
+	require.NoError(t, err)
+	pr.AssignedReviewers[0] = "changed"
+	again, err := r.GetPRByID(ctx, "pr1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"u2"}, again.AssignedReviewers)
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/trainee/review-service/internal/model"
+	repo "github.com/trainee/review-service/internal/repository"
+)
+
+func (r *Repository) ListRoles(ctx context.Context, userID string) ([]model.RoleAssignment, error) {
+	roles := []model.RoleAssignment{}
+	for _, ra := range r.view(ctx).data.roles {
+		if userID == "" || ra.UserID == userID {
+			roles = append(roles, ra)
+		}
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		a, b := roles[i], roles[j]
+		if a.UserID != b.UserID {
+			return a.UserID < b.UserID
+		}
+		if a.Role != b.Role {
+			return a.Role < b.Role
+		}
+		return a.TeamName < b.TeamName
+	})
+	return roles, nil
+}
+
+func (r *Repository) AssignRole(ctx context.Context, role model.RoleAssignment) error {
+	return r.update(ctx, func(tx *txRepository) error {
+		if role.TeamName != "" {
+			if _, ok := tx.data.teams[role.TeamName]; !ok {
+				return repo.ErrNotFound
+			}
+		}
+		for _, ra := range tx.data.roles {
+			if ra == role {
+				return nil
+			}
+		}
+		tx.ownRoles()
+		tx.data.roles = append(tx.data.roles, role)
+		return nil
+	})
+}
+
+func (r *Repository) RevokeRole(ctx context.Context, role model.RoleAssignment) error {
+	return r.update(ctx, func(tx *txRepository) error {
+		for i, ra := range tx.data.roles {
+			if ra == role {
+				tx.ownRoles()
+				tx.data.roles = append(tx.data.roles[:i], tx.data.roles[i+1:]...)
+				return nil
+			}
+		}
+		return repo.ErrNotFound
+	})
+}
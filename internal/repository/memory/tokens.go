@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/trainee/review-service/internal/model"
+	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/tenant"
+)
+
+// storedToken — токен вместе с хэшем значения.
+type storedToken struct {
+	token model.APIToken
+	hash  string
+}
+
+func cloneToken(t model.APIToken) model.APIToken {
+	t.Scopes = append([]string{}, t.Scopes...)
+	return t
+}
+
+func (r *Repository) CreateAPIToken(_ context.Context, token model.APIToken, hash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, st := range r.tokens {
+		if id == token.ID || st.hash == hash {
+			return repo.ErrAlreadyExists
+		}
+	}
+	r.tokens[token.ID] = storedToken{token: cloneToken(token), hash: hash}
+	return nil
+}
+
+// GetAPITokenByHash ищет токен во всех организациях.
+func (r *Repository) GetAPITokenByHash(_ context.Context, hash string) (*model.APIToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, st := range r.tokens {
+		if st.hash == hash {
+			t := cloneToken(st.token)
+			return &t, nil
+		}
+	}
+	return nil, repo.ErrNotFound
+}
+
+func (r *Repository) ListAPITokens(ctx context.Context) ([]model.APIToken, error) {
+	org := tenant.FromContext(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tokens := []model.APIToken{}
+	for _, st := range r.tokens {
+		if st.token.OrgID == org {
+			tokens = append(tokens, cloneToken(st.token))
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		if !tokens[i].CreatedAt.Equal(tokens[j].CreatedAt) {
+			return tokens[i].CreatedAt.Before(tokens[j].CreatedAt)
+		}
+		return tokens[i].ID < tokens[j].ID
+	})
+	return tokens, nil
+}
+
+// RevokeAPIToken отзывает токен; повторный отзыв не меняет исходное время.
+func (r *Repository) RevokeAPIToken(ctx context.Context, tokenID string, at time.Time) error {
+	org := tenant.FromContext(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	st, ok := r.tokens[tokenID]
+	if !ok || st.token.OrgID != org {
+		return repo.ErrNotFound
+	}
+	if st.token.RevokedAt == nil {
+		st.token.RevokedAt = &at
+		r.tokens[tokenID] = st
+	}
+	return nil
+}
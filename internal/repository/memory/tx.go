@@ -0,0 +1,209 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/trainee/review-service/internal/model"
+	repo "github.com/trainee/review-service/internal/repository"
+)
+
+// txRepository — транзакция над копией данных организации. Таблицы,
+// полученные от зафиксированной версии, общие с ней; own* копирует таблицу
+// перед первым изменением в этой транзакции.
+type txRepository struct {
+	data dataset
+	now  func() time.Time
+
+	ownsTeams, ownsUsers, ownsPRs, ownsRoles bool
+}
+
+func (t *txRepository) ownTeams() {
+	if !t.ownsTeams {
+		teams := make(map[string]int64, len(t.data.teams)+1)
+		for k, v := range t.data.teams {
+			teams[k] = v
+		}
+		t.data.teams, t.ownsTeams = teams, true
+	}
+}
+
+func (t *txRepository) ownUsers() {
+	if !t.ownsUsers {
+		users := make(map[string]model.User, len(t.data.users)+1)
+		for k, v := range t.data.users {
+			users[k] = v
+		}
+		t.data.users, t.ownsUsers = users, true
+	}
+}
+
+func (t *txRepository) ownPRs() {
+	if !t.ownsPRs {
+		prs := make(map[string]model.PullRequest, len(t.data.prs)+1)
+		for k, v := range t.data.prs {
+			prs[k] = v
+		}
+		t.data.prs, t.ownsPRs = prs, true
+	}
+}
+
+func (t *txRepository) ownRoles() {
+	if !t.ownsRoles {
+		t.data.roles, t.ownsRoles = append([]model.RoleAssignment(nil), t.data.roles...), true
+	}
+}
+
+// WithSavepoint выполняет fn над ещё одной копией: при ошибке её изменения
+// отбрасываются, а изменения самой транзакции остаются.
+func (t *txRepository) WithSavepoint(_ context.Context, fn func(tx repo.TxRepository) error) error {
+	sp := &txRepository{data: t.data, now: t.now}
+	if err := fn(sp); err != nil {
+		return err
+	}
+	t.data = sp.data
+	// Таблица, скопированная точкой сохранения, принадлежит только ей.
+	t.ownsTeams = t.ownsTeams || sp.ownsTeams
+	t.ownsUsers = t.ownsUsers || sp.ownsUsers
+	t.ownsPRs = t.ownsPRs || sp.ownsPRs
+	t.ownsRoles = t.ownsRoles || sp.ownsRoles
+	return nil
+}
+
+func (t *txRepository) IsEmpty(_ context.Context) (bool, error) {
+	return len(t.data.teams) == 0 && len(t.data.users) == 0 && len(t.data.prs) == 0, nil
+}
+
+func (t *txRepository) EnsureTeam(_ context.Context, teamName string) error {
+	if _, ok := t.data.teams[teamName]; !ok {
+		t.ownTeams()
+		t.data.teams[teamName] = 1
+	}
+	return nil
+}
+
+// UpsertUser создаёт пользователя или переносит существующего в другую команду.
+// Версии пользователя, его прежней и новой команды увеличиваются.
+func (t *txRepository) UpsertUser(_ context.Context, user model.User) error {
+	if _, ok := t.data.teams[user.TeamName]; !ok {
+		return repo.ErrNotFound
+	}
+	user.Version = 1
+	previous, exists := t.data.users[user.UserID]
+	if exists {
+		user.Version = previous.Version + 1
+	}
+	t.ownUsers()
+	t.data.users[user.UserID] = user
+	if exists && previous.TeamName != user.TeamName {
+		t.bumpTeams(previous.TeamName)
+	}
+	t.bumpTeams(user.TeamName)
+	return nil
+}
+
+// bumpTeams увеличивает версию команды после изменения её участников.
+func (t *txRepository) bumpTeams(teamName string) {
+	if _, ok := t.data.teams[teamName]; ok {
+		t.ownTeams()
+		t.data.teams[teamName]++
+	}
+}
+
+func (t *txRepository) GetUserByID(_ context.Context, userID string) (*model.User, error) {
+	u, ok := t.data.users[userID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return &u, nil
+}
+
+func (t *txRepository) ListTeamMembers(_ context.Context, teamName string) ([]model.User, error) {
+	members := []model.User{}
+	for _, u := range sortedUsers(t.data.users) {
+		if u.TeamName == teamName {
+			members = append(members, u)
+		}
+	}
+	return members, nil
+}
+
+// CreatePR сохраняет PR; если CreatedAt не задан, подставляется текущее время.
+func (t *txRepository) CreatePR(_ context.Context, pr *model.PullRequest) error {
+	if _, ok := t.data.prs[pr.ID]; ok {
+		return repo.ErrAlreadyExists
+	}
+	if _, ok := t.data.users[pr.AuthorID]; !ok {
+		return repo.ErrNotFound
+	}
+	stored := clonePR(*pr)
+	if stored.CreatedAt == nil {
+		now := t.now()
+		stored.CreatedAt = &now
+	}
+	if stored.Status == "" {
+		stored.Status = model.PROpen
+	}
+	stored.Version = 1
+	t.ownPRs()
+	t.data.prs[pr.ID] = stored
+
+	pr.CreatedAt, pr.Status, pr.Version = stored.CreatedAt, stored.Status, stored.Version
+	return nil
+}
+
+func (t *txRepository) GetPRByID(_ context.Context, prID string) (*model.PullRequest, error) {
+	pr, ok := t.data.prs[prID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	pr = clonePR(pr)
+	return &pr, nil
+}
+
+// GetPRByIDForUpdate не берёт отдельной блокировки: транзакция и так
+// единственная, кто меняет данные организации, до своего завершения.
+func (t *txRepository) GetPRByIDForUpdate(ctx context.Context, prID string) (*model.PullRequest, error) {
+	return t.GetPRByID(ctx, prID)
+}
+
+// UpdatePR сохраняет статус и ревьюверов, если версия PR равна pr.Version, и увеличивает её.
+func (t *txRepository) UpdatePR(_ context.Context, pr *model.PullRequest) (*model.PullRequest, error) {
+	current, ok := t.data.prs[pr.ID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	if current.Version != pr.Version {
+		return nil, repo.ErrVersionConflict
+	}
+	current.Status = pr.Status
+	current.AssignedReviewers = append([]string{}, pr.AssignedReviewers...)
+	current.MergedAt = pr.MergedAt
+	current.Version++
+	t.ownPRs()
+	t.data.prs[pr.ID] = current
+
+	updated := clonePR(current)
+	return &updated, nil
+}
+
+// GetPRsByReviewer находит PR, назначенные пользователю, в порядке (created_at DESC, pull_request_id DESC).
+func (t *txRepository) GetPRsByReviewer(_ context.Context, filter model.ReviewFilter, after *model.PRCursor, limit int) ([]model.PullRequestShort, error) {
+	result := []model.PullRequestShort{}
+	for _, pr := range sortedPRs(t.data.prs, after) {
+		if len(result) == limit {
+			break
+		}
+		if !contains(pr.AssignedReviewers, filter.UserID) || (filter.Status != "" && pr.Status != filter.Status) {
+			continue
+		}
+		result = append(result, model.PullRequestShort{
+			ID:        pr.ID,
+			Name:      pr.Name,
+			AuthorID:  pr.AuthorID,
+			Status:    pr.Status,
+			CreatedAt: pr.CreatedAt,
+		})
+	}
+	return result, nil
+}
@@ -4,41 +4,80 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
 
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/trainee/review-service/internal/model"
+	"github.com/trainee/review-service/internal/tenant"
 )
 
 // Ошибки уровня репозитория
 var (
 	ErrNotFound      = errors.New("repository: not found")
 	ErrAlreadyExists = errors.New("repository: already exists")
+	// ErrVersionConflict — запись существует, но её версия отличается от ожидаемой.
+	ErrVersionConflict = errors.New("repository: version conflict")
+	// ErrInUse — на запись ссылаются другие записи, удалить её нельзя.
+	ErrInUse = errors.New("repository: in use")
 )
 
 // Repository описывает операции доступа к данным без бизнес-логики.
+// Все операции выполняются в организации из контекста (tenant.FromContext).
 type Repository interface {
 	CreateTeamTx(ctx context.Context, team model.Team) error
 	GetTeam(ctx context.Context, teamName string) (*model.Team, error)
-	SetUserActiveStatus(ctx context.Context, userID string, isActive bool) (*model.User, error)
+	// SetUserActiveStatus меняет активность, если версия пользователя равна expectedVersion
+	// (0 — без проверки), и увеличивает версии пользователя и его команды.
+	SetUserActiveStatus(ctx context.Context, userID string, isActive bool, expectedVersion int64) (*model.User, error)
+	// DeleteUser удаляет пользователя, на которого не ссылается ни один PR (иначе ErrInUse),
+	// с той же проверкой версии, и увеличивает версию его команды.
+	DeleteUser(ctx context.Context, userID string, expectedVersion int64) (*model.User, error)
 
 	GetUserByID(ctx context.Context, userID string) (*model.User, error)
 	ListTeamMembers(ctx context.Context, teamName string) ([]model.User, error)
+	GetUserDetails(ctx context.Context, userID string) (*model.UserDetails, error)
+	ListUsers(ctx context.Context, filter model.UserFilter, afterUserID string, limit int) ([]model.UserDetails, error)
 
 	CreatePR(ctx context.Context, pr *model.PullRequest) error
 	GetPRByID(ctx context.Context, prID string) (*model.PullRequest, error)
 	GetPRByIDForUpdate(ctx context.Context, prID string) (*model.PullRequest, error)
+	// UpdatePR сохраняет PR, только если его версия в БД равна pr.Version, и увеличивает её;
+	// иначе возвращает ErrVersionConflict.
 	UpdatePR(ctx context.Context, pr *model.PullRequest) (*model.PullRequest, error)
+	ListPRs(ctx context.Context, filter model.PRFilter, after *model.PRCursor, limit int) ([]model.PullRequest, error)
 
-	GetPRsByReviewer(ctx context.Context, userID string) ([]model.PullRequestShort, error)
+	GetPRsByReviewer(ctx context.Context, filter model.ReviewFilter, after *model.PRCursor, limit int) ([]model.PullRequestShort, error)
+
+	// ListRoles возвращает роли пользователя или, если userID пуст, все роли.
+	ListRoles(ctx context.Context, userID string) ([]model.RoleAssignment, error)
+	// AssignRole выдаёт роль; повторная выдача не считается ошибкой.
+	AssignRole(ctx context.Context, role model.RoleAssignment) error
+	RevokeRole(ctx context.Context, role model.RoleAssignment) error
+
+	// Export передаёт в sink все команды, пользователей и PR из согласованного снимка БД.
+	Export(ctx context.Context, sink ExportSink) error
 
 	WithTransaction(ctx context.Context, fn func(tx TxRepository) error) error
 }
 
+// ExportSink принимает данные выгрузки: сначала команды, затем пользователей, затем PR.
+type ExportSink interface {
+	WriteTeam(teamName string) error
+	WriteUser(user model.User) error
+	WritePullRequest(pr model.PullRequest) error
+}
+
 // TxRepository используется внутри транзакций (тот же набор методов, кроме управления транзакцией).
 type TxRepository interface {
+	// EnsureTeam создаёт команду, если её ещё нет.
+	EnsureTeam(ctx context.Context, teamName string) error
+	// UpsertUser создаёт пользователя или обновляет имя, команду и активность существующего.
+	UpsertUser(ctx context.Context, user model.User) error
 	GetUserByID(ctx context.Context, userID string) (*model.User, error)
 	ListTeamMembers(ctx context.Context, teamName string) ([]model.User, error)
 
@@ -47,7 +86,13 @@ type TxRepository interface {
 	GetPRByIDForUpdate(ctx context.Context, prID string) (*model.PullRequest, error)
 	UpdatePR(ctx context.Context, pr *model.PullRequest) (*model.PullRequest, error)
 
-	GetPRsByReviewer(ctx context.Context, userID string) ([]model.PullRequestShort, error)
+	GetPRsByReviewer(ctx context.Context, filter model.ReviewFilter, after *model.PRCursor, limit int) ([]model.PullRequestShort, error)
+
+	// IsEmpty сообщает, что в организации нет ни команд, ни пользователей, ни PR.
+	IsEmpty(ctx context.Context) (bool, error)
+
+	// WithSavepoint выполняет fn во вложенной транзакции: при ошибке откатываются только её изменения.
+	WithSavepoint(ctx context.Context, fn func(tx TxRepository) error) error
 }
 
 type PostgresRepository struct {
@@ -64,6 +109,17 @@ func NewPostgresRepository(pool *pgxpool.Pool) *PostgresRepository {
 	return &PostgresRepository{pool: pool}
 }
 
+// EnableRowLevelSecurity настраивает пул так, что каждое соединение перед выдачей
+// получает app.org_id организации из контекста. Политики RLS (миграция 000005)
+// по этому параметру отсекают строки чужих организаций, если сервис подключается
+// к БД не владельцем таблиц.
+func EnableRowLevelSecurity(cfg *pgxpool.Config) {
+	cfg.BeforeAcquire = func(ctx context.Context, conn *pgx.Conn) bool {
+		_, err := conn.Exec(ctx, `SELECT set_config('app.org_id', $1, false)`, tenant.FromContext(ctx))
+		return err == nil
+	}
+}
+
 // handleError маппит ошибки PostgreSQL на ошибки репозитория.
 func handleError(err error) error {
 	if err == nil {
@@ -88,14 +144,55 @@ func handleError(err error) error {
 
 // --- Хелперы ---
 
+// whereBuilder собирает WHERE из опциональных условий с позиционными параметрами.
+type whereBuilder struct {
+	conds []string
+	args  []any
+}
+
+// arg регистрирует значение параметра и возвращает его плейсхолдер.
+func (w *whereBuilder) arg(v any) string {
+	w.args = append(w.args, v)
+	return "$" + strconv.Itoa(len(w.args))
+}
+
+// add добавляет условие; каждый %s в cond заменяется плейсхолдером очередного значения.
+func (w *whereBuilder) add(cond string, vals ...any) {
+	placeholders := make([]any, len(vals))
+	for i, v := range vals {
+		placeholders[i] = w.arg(v)
+	}
+	w.conds = append(w.conds, fmt.Sprintf(cond, placeholders...))
+}
+
+func (w *whereBuilder) sql() string {
+	if len(w.conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(w.conds, " AND ")
+}
+
+// orgOf возвращает организацию, которой ограничиваются запросы.
+func orgOf(ctx context.Context) string {
+	return tenant.FromContext(ctx)
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 // getUser - хелпер для работы с пулом и транзакциями.
-func getUser(ctx context.Context, q queryable, userID string) (*model.User, error) {
+func getUser(ctx context.Context, q queryable, userID string, forUpdate bool) (*model.User, error) {
 	query := `
-		SELECT user_id, username, team_name, is_active
-		FROM users WHERE user_id = $1
+		SELECT user_id, username, team_name, is_active, version
+		FROM users WHERE org_id = $1 AND user_id = $2
 	`
+	if forUpdate {
+		query += " FOR UPDATE"
+	}
 	var user model.User
-	err := q.QueryRow(ctx, query, userID).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
+	err := q.QueryRow(ctx, query, orgOf(ctx), userID).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Version)
 	if err != nil {
 		return nil, handleError(err)
 	}
@@ -116,7 +213,8 @@ func (r *PostgresRepository) CreateTeamTx(ctx context.Context, team model.Team)
 	}()
 
 	// 1. Вставка команды
-	_, err = tx.Exec(ctx, `INSERT INTO teams (team_name) VALUES ($1)`, team.TeamName)
+	org := orgOf(ctx)
+	_, err = tx.Exec(ctx, `INSERT INTO teams (org_id, team_name) VALUES ($1, $2)`, org, team.TeamName)
 	if err != nil {
 		return handleError(err)
 	}
@@ -124,16 +222,8 @@ func (r *PostgresRepository) CreateTeamTx(ctx context.Context, team model.Team)
 	// 2. Вставка/Обновление пользователей (UPSERT) с использованием pgx.Batch
 	if len(team.Members) > 0 {
 		batch := &pgx.Batch{}
-		query := `
-			INSERT INTO users (user_id, username, team_name, is_active)
-			VALUES ($1, $2, $3, $4)
-			ON CONFLICT (user_id) DO UPDATE SET
-				username = EXCLUDED.username,
-				is_active = EXCLUDED.is_active,
-				team_name = EXCLUDED.team_name
-		`
 		for _, member := range team.Members {
-			batch.Queue(query, member.UserID, member.Username, team.TeamName, member.IsActive)
+			batch.Queue(upsertUserQuery, org, member.UserID, member.Username, team.TeamName, member.IsActive)
 		}
 
 		br := tx.SendBatch(ctx, batch)
@@ -145,63 +235,150 @@ func (r *PostgresRepository) CreateTeamTx(ctx context.Context, team model.Team)
 	return tx.Commit(ctx)
 }
 
-func (r *PostgresRepository) GetTeam(ctx context.Context, teamName string) (*model.Team, error) {
-	// Проверка существования команды
-	var exists bool
-	err := r.pool.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = $1)", teamName).Scan(&exists)
-	if err != nil || !exists {
-		if !exists {
-			return nil, ErrNotFound
-		}
-		return nil, err
-	}
+// upsertUserQuery создаёт пользователя или переносит существующего в другую команду.
+// Версии пользователя, его прежней и новой команды увеличиваются.
+const upsertUserQuery = `
+	WITH previous AS (
+		SELECT team_name FROM users WHERE org_id = $1 AND user_id = $2
+	), upserted AS (
+		INSERT INTO users (org_id, user_id, username, team_name, is_active)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (org_id, user_id) DO UPDATE SET
+			username = EXCLUDED.username,
+			is_active = EXCLUDED.is_active,
+			team_name = EXCLUDED.team_name,
+			version = users.version + 1
+		RETURNING team_name
+	)
+	UPDATE teams SET version = version + 1
+	WHERE org_id = $1 AND (team_name IN (SELECT team_name FROM previous) OR team_name IN (SELECT team_name FROM upserted))
+`
 
-	// Получение участников
+func (r *PostgresRepository) GetTeam(ctx context.Context, teamName string) (*model.Team, error) {
+	// Версия и участники читаются одним запросом, чтобы ETag соответствовал составу.
 	query := `
-		SELECT user_id, username, is_active
-		FROM users
-		WHERE team_name = $1
-		ORDER BY user_id
+		SELECT t.version, u.user_id, u.username, u.is_active
+		FROM teams t
+		LEFT JOIN users u ON u.org_id = t.org_id AND u.team_name = t.team_name
+		WHERE t.org_id = $1 AND t.team_name = $2
+		ORDER BY u.user_id
 	`
-	rows, err := r.pool.Query(ctx, query, teamName)
+	rows, err := r.pool.Query(ctx, query, orgOf(ctx), teamName)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	// Используем маппинг по имени структуры для удобства
-	members, err := pgx.CollectRows(rows, pgx.RowToStructByName[model.TeamMember])
-	if err != nil {
+	var team *model.Team
+	for rows.Next() {
+		var (
+			version  int64
+			userID   *string
+			username *string
+			isActive *bool
+		)
+		if err := rows.Scan(&version, &userID, &username, &isActive); err != nil {
+			return nil, err
+		}
+		if team == nil {
+			team = &model.Team{TeamName: teamName, Members: []model.TeamMember{}, Version: version}
+		}
+		// Команда без участников даёт одну строку с NULL из LEFT JOIN.
+		if userID != nil {
+			team.Members = append(team.Members, model.TeamMember{UserID: *userID, Username: *username, IsActive: *isActive})
+		}
+	}
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-
-	return &model.Team{TeamName: teamName, Members: members}, nil
+	if team == nil {
+		return nil, ErrNotFound
+	}
+	return team, nil
 }
 
-func (r *PostgresRepository) SetUserActiveStatus(ctx context.Context, userID string, isActive bool) (*model.User, error) {
+func (r *PostgresRepository) SetUserActiveStatus(ctx context.Context, userID string, isActive bool, expectedVersion int64) (*model.User, error) {
 	query := `
-		UPDATE users SET is_active = $2 WHERE user_id = $1
-		RETURNING user_id, username, team_name, is_active
+		WITH updated AS (
+			UPDATE users SET is_active = $3, version = version + 1
+			WHERE org_id = $1 AND user_id = $2 AND ($4::BIGINT = 0 OR version = $4)
+			RETURNING user_id, username, team_name, is_active, version
+		), team AS (
+			UPDATE teams SET version = version + 1
+			WHERE org_id = $1 AND team_name IN (SELECT team_name FROM updated)
+		)
+		SELECT user_id, username, team_name, is_active, version FROM updated
 	`
 	var user model.User
-	err := r.pool.QueryRow(ctx, query, userID, isActive).Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
+	err := r.pool.QueryRow(ctx, query, orgOf(ctx), userID, isActive, expectedVersion).Scan(
+		&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Version,
+	)
+	if errors.Is(err, pgx.ErrNoRows) && expectedVersion != 0 {
+		if _, getErr := getUser(ctx, r.pool, userID, false); getErr == nil {
+			return nil, ErrVersionConflict
+		}
+	}
 	if err != nil {
 		return nil, handleError(err)
 	}
 	return &user, nil
 }
 
+func (r *PostgresRepository) DeleteUser(ctx context.Context, userID string, expectedVersion int64) (*model.User, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	org := orgOf(ctx)
+	// FOR UPDATE: проверка внешнего ключа в параллельном CreatePR ждёт конца транзакции.
+	user, err := getUser(ctx, tx, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	if expectedVersion != 0 && user.Version != expectedVersion {
+		return nil, ErrVersionConflict
+	}
+	var referenced bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM pull_requests
+			WHERE org_id = $1 AND (author_id = $2 OR assigned_reviewers @> ARRAY[$2]::TEXT[])
+		)`, org, userID).Scan(&referenced)
+	if err != nil {
+		return nil, err
+	}
+	if referenced {
+		return nil, ErrInUse
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM users WHERE org_id = $1 AND user_id = $2`, org, userID); err != nil {
+		return nil, handleError(err)
+	}
+	if _, err := tx.Exec(ctx, `UPDATE teams SET version = version + 1 WHERE org_id = $1 AND team_name = $2`, org, user.TeamName); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
 func (r *PostgresRepository) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
-	return getUser(ctx, r.pool, userID)
+	return getUser(ctx, r.pool, userID, false)
 }
 
 func (r *PostgresRepository) ListTeamMembers(ctx context.Context, teamName string) ([]model.User, error) {
+	return listTeamMembers(ctx, r.pool, teamName)
+}
+
+func listTeamMembers(ctx context.Context, q queryable, teamName string) ([]model.User, error) {
 	query := `
-		SELECT user_id, username, team_name, is_active
+		SELECT user_id, username, team_name, is_active, version
 		FROM users
-		WHERE team_name = $1
+		WHERE org_id = $1 AND team_name = $2
 	`
-	rows, err := r.pool.Query(ctx, query, teamName)
+	rows, err := q.Query(ctx, query, orgOf(ctx), teamName)
 	if err != nil {
 		return nil, err
 	}
@@ -211,20 +388,76 @@ func (r *PostgresRepository) ListTeamMembers(ctx context.Context, teamName strin
 	return members, err
 }
 
+// userDetailsSelect выбирает пользователя вместе с количеством открытых PR, где он ревьюер.
+const userDetailsSelect = `
+	SELECT u.user_id, u.username, u.team_name, u.is_active, u.version,
+	       (SELECT COUNT(*) FROM pull_requests p
+	        WHERE p.org_id = u.org_id AND p.status = 'OPEN' AND p.assigned_reviewers @> ARRAY[u.user_id]) AS open_review_count
+	FROM users u
+`
+
+func scanUserDetails(row pgx.Row) (model.UserDetails, error) {
+	var u model.UserDetails
+	err := row.Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive, &u.Version, &u.OpenReviewCount)
+	return u, err
+}
+
+func (r *PostgresRepository) GetUserDetails(ctx context.Context, userID string) (*model.UserDetails, error) {
+	user, err := scanUserDetails(r.pool.QueryRow(ctx, userDetailsSelect+` WHERE u.org_id = $1 AND u.user_id = $2`, orgOf(ctx), userID))
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &user, nil
+}
+
+// ListUsers возвращает пользователей по фильтру, упорядоченных по user_id (keyset-пагинация по afterUserID).
+func (r *PostgresRepository) ListUsers(ctx context.Context, filter model.UserFilter, afterUserID string, limit int) ([]model.UserDetails, error) {
+	var w whereBuilder
+	w.add("u.org_id = %s", orgOf(ctx))
+	if filter.TeamName != "" {
+		w.add("u.team_name = %s", filter.TeamName)
+	}
+	if filter.IsActive != nil {
+		w.add("u.is_active = %s", *filter.IsActive)
+	}
+	if filter.UsernamePrefix != "" {
+		w.add(`u.username ILIKE %s ESCAPE '\'`, escapeLike(filter.UsernamePrefix)+"%")
+	}
+	if afterUserID != "" {
+		w.add("u.user_id > %s", afterUserID)
+	}
+	query := userDetailsSelect + w.sql() + " ORDER BY u.user_id LIMIT " + w.arg(limit)
+
+	rows, err := r.pool.Query(ctx, query, w.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.UserDetails, error) {
+		return scanUserDetails(row)
+	})
+}
+
 // --- Pull Requests ---
 
 // CreatePR добавляет PR с уже подготовленными данными.
 func (r *PostgresRepository) CreatePR(ctx context.Context, pr *model.PullRequest) error {
+	return insertPR(ctx, r.pool, pr)
+}
+
+// insertPR сохраняет PR; если CreatedAt не задан, его выставляет БД.
+func insertPR(ctx context.Context, q queryable, pr *model.PullRequest) error {
 	reviewers := pr.AssignedReviewers
 	if reviewers == nil {
 		reviewers = []string{}
 	}
 	query := `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, assigned_reviewers, merged_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING created_at
+		INSERT INTO pull_requests (org_id, pull_request_id, pull_request_name, author_id, status, assigned_reviewers, merged_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8, NOW()))
+		RETURNING created_at, version
 	`
-	err := r.pool.QueryRow(ctx, query, pr.ID, pr.Name, pr.AuthorID, pr.Status, reviewers, pr.MergedAt).Scan(&pr.CreatedAt)
+	err := q.QueryRow(ctx, query, orgOf(ctx), pr.ID, pr.Name, pr.AuthorID, pr.Status, reviewers, pr.MergedAt, pr.CreatedAt).Scan(&pr.CreatedAt, &pr.Version)
 	return handleError(err)
 }
 
@@ -232,24 +465,30 @@ func (r *PostgresRepository) GetPRByID(ctx context.Context, prID string) (*model
 	return fetchPR(ctx, r.pool, prID, false)
 }
 
+// prSelect выбирает полные данные PR; используется вместе с scanPR.
+const prSelect = `
+	SELECT p.pull_request_id, p.pull_request_name, p.author_id, p.status, p.assigned_reviewers, p.created_at, p.merged_at, p.version
+	FROM pull_requests p
+`
+
+func scanPR(row pgx.Row) (model.PullRequest, error) {
+	var pr model.PullRequest
+	err := row.Scan(&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.AssignedReviewers, &pr.CreatedAt, &pr.MergedAt, &pr.Version)
+	if pr.AssignedReviewers == nil {
+		pr.AssignedReviewers = []string{}
+	}
+	return pr, err
+}
+
 func fetchPR(ctx context.Context, q queryable, prID string, forUpdate bool) (*model.PullRequest, error) {
 	suffix := ""
 	if forUpdate {
 		suffix = " FOR UPDATE"
 	}
-	query := `
-		SELECT pull_request_id, pull_request_name, author_id, status, assigned_reviewers, created_at, merged_at
-		FROM pull_requests WHERE pull_request_id = $1` + suffix
-	var pr model.PullRequest
-	err := q.QueryRow(ctx, query, prID).Scan(
-		&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.AssignedReviewers, &pr.CreatedAt, &pr.MergedAt,
-	)
+	pr, err := scanPR(q.QueryRow(ctx, prSelect+` WHERE p.org_id = $1 AND p.pull_request_id = $2`+suffix, orgOf(ctx), prID))
 	if err != nil {
 		return nil, handleError(err)
 	}
-	if pr.AssignedReviewers == nil {
-		pr.AssignedReviewers = []string{}
-	}
 	return &pr, nil
 }
 
@@ -259,22 +498,33 @@ func (r *PostgresRepository) GetPRByIDForUpdate(ctx context.Context, prID string
 
 // UpdatePR обновляет статус/ревьюеров и возвращает текущее состояние.
 func (r *PostgresRepository) UpdatePR(ctx context.Context, pr *model.PullRequest) (*model.PullRequest, error) {
+	return updatePR(ctx, r.pool, pr)
+}
+
+func updatePR(ctx context.Context, q queryable, pr *model.PullRequest) (*model.PullRequest, error) {
 	reviewers := pr.AssignedReviewers
 	if reviewers == nil {
 		reviewers = []string{}
 	}
+	// Сравнение и увеличение версии в одном UPDATE: параллельное изменение не перезапишется молча.
 	query := `
 		UPDATE pull_requests
-		SET status = $2,
-		    assigned_reviewers = $3,
-		    merged_at = $4
-		WHERE pull_request_id = $1
-		RETURNING pull_request_id, pull_request_name, author_id, status, assigned_reviewers, created_at, merged_at
+		SET status = $3,
+		    assigned_reviewers = $4,
+		    merged_at = $5,
+		    version = version + 1
+		WHERE org_id = $1 AND pull_request_id = $2 AND version = $6
+		RETURNING pull_request_id, pull_request_name, author_id, status, assigned_reviewers, created_at, merged_at, version
 	`
 	var updated model.PullRequest
-	err := r.pool.QueryRow(ctx, query, pr.ID, pr.Status, reviewers, pr.MergedAt).Scan(
-		&updated.ID, &updated.Name, &updated.AuthorID, &updated.Status, &updated.AssignedReviewers, &updated.CreatedAt, &updated.MergedAt,
+	err := q.QueryRow(ctx, query, orgOf(ctx), pr.ID, pr.Status, reviewers, pr.MergedAt, pr.Version).Scan(
+		&updated.ID, &updated.Name, &updated.AuthorID, &updated.Status, &updated.AssignedReviewers, &updated.CreatedAt, &updated.MergedAt, &updated.Version,
 	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		if _, getErr := fetchPR(ctx, q, pr.ID, false); getErr == nil {
+			return nil, ErrVersionConflict
+		}
+	}
 	if err != nil {
 		return nil, handleError(err)
 	}
@@ -284,15 +534,76 @@ func (r *PostgresRepository) UpdatePR(ctx context.Context, pr *model.PullRequest
 	return &updated, nil
 }
 
-// GetPRsByReviewer находит все PR, назначенные пользователю.
-func (r *PostgresRepository) GetPRsByReviewer(ctx context.Context, userID string) ([]model.PullRequestShort, error) {
+// ListPRs ищет PR по фильтру в порядке (created_at DESC, pull_request_id DESC), начиная после курсора.
+func (r *PostgresRepository) ListPRs(ctx context.Context, filter model.PRFilter, after *model.PRCursor, limit int) ([]model.PullRequest, error) {
+	var w whereBuilder
+	w.add("p.org_id = %s", orgOf(ctx))
+	if filter.Status != "" {
+		w.add("p.status = %s", filter.Status)
+	}
+	if filter.AuthorID != "" {
+		w.add("p.author_id = %s", filter.AuthorID)
+	}
+	if filter.ReviewerID != "" {
+		w.add("p.assigned_reviewers @> ARRAY[%s]::TEXT[]", filter.ReviewerID)
+	}
+	if filter.TeamName != "" {
+		w.add("p.author_id IN (SELECT user_id FROM users WHERE org_id = p.org_id AND team_name = %s)", filter.TeamName)
+	}
+	if filter.NameContains != "" {
+		w.add(`p.pull_request_name ILIKE %s ESCAPE '\'`, "%"+escapeLike(filter.NameContains)+"%")
+	}
+	if filter.CreatedFrom != nil {
+		w.add("p.created_at >= %s", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		w.add("p.created_at < %s", *filter.CreatedTo)
+	}
+	if filter.MergedFrom != nil {
+		w.add("p.merged_at >= %s", *filter.MergedFrom)
+	}
+	if filter.MergedTo != nil {
+		w.add("p.merged_at < %s", *filter.MergedTo)
+	}
+	if after != nil {
+		w.add("(p.created_at, p.pull_request_id) < (%s, %s)", after.CreatedAt, after.ID)
+	}
+	query := prSelect + w.sql() + " ORDER BY p.created_at DESC, p.pull_request_id DESC LIMIT " + w.arg(limit)
+
+	rows, err := r.pool.Query(ctx, query, w.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.PullRequest, error) {
+		return scanPR(row)
+	})
+}
+
+// GetPRsByReviewer находит PR, назначенные пользователю, в порядке (created_at DESC, pull_request_id DESC).
+func (r *PostgresRepository) GetPRsByReviewer(ctx context.Context, filter model.ReviewFilter, after *model.PRCursor, limit int) ([]model.PullRequestShort, error) {
+	return listPRsByReviewer(ctx, r.pool, filter, after, limit)
+}
+
+func listPRsByReviewer(ctx context.Context, q queryable, filter model.ReviewFilter, after *model.PRCursor, limit int) ([]model.PullRequestShort, error) {
+	var w whereBuilder
+	w.add("org_id = %s", orgOf(ctx))
+	w.add("assigned_reviewers @> ARRAY[%s]::TEXT[]", filter.UserID)
+	if filter.Status != "" {
+		w.add("status = %s", filter.Status)
+	}
+	if after != nil {
+		// pull_request_id служит тай-брейкером для PR с одинаковым created_at.
+		w.add("(created_at, pull_request_id) < (%s, %s)", after.CreatedAt, after.ID)
+	}
 	query := `
-		SELECT pull_request_id, pull_request_name, author_id, status
-		FROM pull_requests
-		WHERE assigned_reviewers @> ARRAY[$1]::TEXT[]
-		ORDER BY created_at DESC
-	`
-	rows, err := r.pool.Query(ctx, query, userID)
+		SELECT pull_request_id, pull_request_name, author_id, status, created_at
+		FROM pull_requests` + w.sql() + `
+		ORDER BY created_at DESC, pull_request_id DESC
+		LIMIT ` + w.arg(limit)
+
+	rows, err := q.Query(ctx, query, w.args...)
 	if err != nil {
 		return nil, err
 	}
@@ -302,6 +613,66 @@ func (r *PostgresRepository) GetPRsByReviewer(ctx context.Context, userID string
 	return prs, err
 }
 
+// --- Export ---
+
+// Export читает данные организации в одной REPEATABLE READ транзакции, чтобы выгрузка была согласованной.
+func (r *PostgresRepository) Export(ctx context.Context, sink ExportSink) error {
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	org := orgOf(ctx)
+	err = forEachRow(ctx, tx, `SELECT team_name FROM teams WHERE org_id = $1 ORDER BY team_name`, org, func(row pgx.Rows) error {
+		var name string
+		if err := row.Scan(&name); err != nil {
+			return err
+		}
+		return sink.WriteTeam(name)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = forEachRow(ctx, tx, `SELECT user_id, username, team_name, is_active FROM users WHERE org_id = $1 ORDER BY user_id`, org, func(row pgx.Rows) error {
+		var u model.User
+		if err := row.Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+			return err
+		}
+		return sink.WriteUser(u)
+	})
+	if err != nil {
+		return err
+	}
+
+	return forEachRow(ctx, tx, prSelect+` WHERE p.org_id = $1 ORDER BY p.created_at, p.pull_request_id`, org, func(row pgx.Rows) error {
+		pr, err := scanPR(row)
+		if err != nil {
+			return err
+		}
+		return sink.WritePullRequest(pr)
+	})
+}
+
+// forEachRow построчно обрабатывает результат запроса, не загружая его в память целиком.
+func forEachRow(ctx context.Context, q queryable, query string, orgID string, fn func(row pgx.Rows) error) error {
+	rows, err := q.Query(ctx, query, orgID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 func (r *PostgresRepository) WithTransaction(ctx context.Context, fn func(tx TxRepository) error) error {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
@@ -310,7 +681,7 @@ func (r *PostgresRepository) WithTransaction(ctx context.Context, fn func(tx TxR
 	wrapper := &txRepository{tx: tx}
 
 	if err := fn(wrapper); err != nil {
-		_ = tx.Rollback(ctx)
+		rollback(ctx, tx)
 		return err
 	}
 	return tx.Commit(ctx)
@@ -320,40 +691,61 @@ type txRepository struct {
 	tx pgx.Tx
 }
 
-func (t *txRepository) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
-	return getUser(ctx, t.tx, userID)
-}
-
-func (t *txRepository) ListTeamMembers(ctx context.Context, teamName string) ([]model.User, error) {
-	query := `
-		SELECT user_id, username, team_name, is_active
-		FROM users
-		WHERE team_name = $1
-	`
-	rows, err := t.tx.Query(ctx, query, teamName)
+func (t *txRepository) WithSavepoint(ctx context.Context, fn func(tx TxRepository) error) error {
+	// Begin внутри pgx.Tx создаёт SAVEPOINT.
+	sp, err := t.tx.Begin(ctx)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer rows.Close()
-
-	members, err := pgx.CollectRows(rows, pgx.RowToStructByName[model.User])
-	return members, err
+	if err := fn(&txRepository{tx: sp}); err != nil {
+		rollback(ctx, sp)
+		return err
+	}
+	return sp.Commit(ctx)
 }
 
-func (t *txRepository) CreatePR(ctx context.Context, pr *model.PullRequest) error {
-	reviewers := pr.AssignedReviewers
-	if reviewers == nil {
-		reviewers = []string{}
+// rollback откатывает транзакцию после ошибки. Неудачный откат не меняет
+// результат вызова, но говорит о проблеме с соединением — пишем его в журнал
+// запроса.
+func rollback(ctx context.Context, tx pgx.Tx) {
+	if err := tx.Rollback(context.WithoutCancel(ctx)); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+		slog.WarnContext(ctx, "Transaction rollback failed", "error", err)
 	}
+}
+
+func (t *txRepository) IsEmpty(ctx context.Context) (bool, error) {
 	query := `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, assigned_reviewers, merged_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		RETURNING created_at
+		SELECT NOT EXISTS (SELECT 1 FROM teams WHERE org_id = $1)
+		   AND NOT EXISTS (SELECT 1 FROM users WHERE org_id = $1)
+		   AND NOT EXISTS (SELECT 1 FROM pull_requests WHERE org_id = $1)
 	`
-	err := t.tx.QueryRow(ctx, query, pr.ID, pr.Name, pr.AuthorID, pr.Status, reviewers, pr.MergedAt).Scan(&pr.CreatedAt)
+	var empty bool
+	err := t.tx.QueryRow(ctx, query, orgOf(ctx)).Scan(&empty)
+	return empty, err
+}
+
+func (t *txRepository) EnsureTeam(ctx context.Context, teamName string) error {
+	_, err := t.tx.Exec(ctx, `INSERT INTO teams (org_id, team_name) VALUES ($1, $2) ON CONFLICT (org_id, team_name) DO NOTHING`, orgOf(ctx), teamName)
+	return handleError(err)
+}
+
+func (t *txRepository) UpsertUser(ctx context.Context, user model.User) error {
+	_, err := t.tx.Exec(ctx, upsertUserQuery, orgOf(ctx), user.UserID, user.Username, user.TeamName, user.IsActive)
 	return handleError(err)
 }
 
+func (t *txRepository) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
+	return getUser(ctx, t.tx, userID, false)
+}
+
+func (t *txRepository) ListTeamMembers(ctx context.Context, teamName string) ([]model.User, error) {
+	return listTeamMembers(ctx, t.tx, teamName)
+}
+
+func (t *txRepository) CreatePR(ctx context.Context, pr *model.PullRequest) error {
+	return insertPR(ctx, t.tx, pr)
+}
+
 func (t *txRepository) GetPRByID(ctx context.Context, prID string) (*model.PullRequest, error) {
 	return fetchPR(ctx, t.tx, prID, false)
 }
@@ -363,44 +755,9 @@ func (t *txRepository) GetPRByIDForUpdate(ctx context.Context, prID string) (*mo
 }
 
 func (t *txRepository) UpdatePR(ctx context.Context, pr *model.PullRequest) (*model.PullRequest, error) {
-	reviewers := pr.AssignedReviewers
-	if reviewers == nil {
-		reviewers = []string{}
-	}
-	query := `
-		UPDATE pull_requests
-		SET status = $2,
-		    assigned_reviewers = $3,
-		    merged_at = $4
-		WHERE pull_request_id = $1
-		RETURNING pull_request_id, pull_request_name, author_id, status, assigned_reviewers, created_at, merged_at
-	`
-	var updated model.PullRequest
-	err := t.tx.QueryRow(ctx, query, pr.ID, pr.Status, reviewers, pr.MergedAt).Scan(
-		&updated.ID, &updated.Name, &updated.AuthorID, &updated.Status, &updated.AssignedReviewers, &updated.CreatedAt, &updated.MergedAt,
-	)
-	if err != nil {
-		return nil, handleError(err)
-	}
-	if updated.AssignedReviewers == nil {
-		updated.AssignedReviewers = []string{}
-	}
-	return &updated, nil
+	return updatePR(ctx, t.tx, pr)
 }
 
-func (t *txRepository) GetPRsByReviewer(ctx context.Context, userID string) ([]model.PullRequestShort, error) {
-	query := `
-		SELECT pull_request_id, pull_request_name, author_id, status
-		FROM pull_requests
-		WHERE assigned_reviewers @> ARRAY[$1]::TEXT[]
-		ORDER BY created_at DESC
-	`
-	rows, err := t.tx.Query(ctx, query, userID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	prs, err := pgx.CollectRows(rows, pgx.RowToStructByName[model.PullRequestShort])
-	return prs, err
+func (t *txRepository) GetPRsByReviewer(ctx context.Context, filter model.ReviewFilter, after *model.PRCursor, limit int) ([]model.PullRequestShort, error) {
+	return listPRsByReviewer(ctx, t.tx, filter, after, limit)
 }
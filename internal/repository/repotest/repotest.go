@@ -0,0 +1,806 @@
+// Package repotest — общий набор тестов поведения для реализаций
+// repository.Repository. Один и тот же набор запускается для PostgreSQL и
+// хранилища в памяти, чтобы их поведение не расходилось незаметно.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trainee/review-service/internal/model"
+	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/tenant"
+)
+
+// Run проверяет реализацию Repository. newRepo вызывается для каждого
+// подтеста и должна возвращать пустое хранилище. Проверки API токенов и
+// ключей идемпотентности выполняются, если хранилище реализует
+// repository.TokenRepository и repository.IdempotencyRepository.
+func Run(t *testing.T, newRepo func(t *testing.T) repo.Repository) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, r repo.Repository)
+	}{
+		{"Errors", testErrors},
+		{"Workflow", testWorkflow},
+		{"Ordering", testOrdering},
+		{"Versions", testVersions},
+		{"DeleteUser", testDeleteUser},
+		{"Organizations", testOrganizations},
+		{"Roles", testRoles},
+		{"TransactionRollback", testTransactionRollback},
+		{"ForUpdateBlocks", testForUpdateBlocks},
+		{"ForUpdateSerializes", testForUpdateSerializes},
+		{"APITokens", testAPITokens},
+		{"IdempotencyKeys", testIdempotencyKeys},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.fn(t, newRepo(t))
+		})
+	}
+}
+
+// seedTeam создаёт команду backend из u1–u4 и PR pr-1 автора u1 с ревьювером u2.
+func seedTeam(t *testing.T, ctx context.Context, r repo.Repository) {
+	t.Helper()
+	require.NoError(t, r.CreateTeamTx(ctx, model.Team{TeamName: "backend", Members: []model.TeamMember{
+		{UserID: "u1", Username: "Alice", IsActive: true},
+		{UserID: "u2", Username: "Bob", IsActive: true},
+		{UserID: "u3", Username: "Carol", IsActive: true},
+		{UserID: "u4", Username: "Dave", IsActive: false},
+	}}))
+	require.NoError(t, r.CreatePR(ctx, &model.PullRequest{ID: "pr-1", Name: "Fix", AuthorID: "u1", Status: model.PROpen, AssignedReviewers: []string{"u2"}}))
+}
+
+// testErrors проверяет перевод ошибок: отсутствующие записи и нарушенные
+// внешние ключи — ErrNotFound, дубликаты — ErrAlreadyExists.
+func testErrors(t *testing.T, r repo.Repository) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r)
+
+	_, err := r.GetTeam(ctx, "missing")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	_, err = r.GetUserByID(ctx, "missing")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	_, err = r.GetUserDetails(ctx, "missing")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	_, err = r.SetUserActiveStatus(ctx, "missing", true, 0)
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	_, err = r.GetPRByID(ctx, "missing")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	_, err = r.GetPRByIDForUpdate(ctx, "missing")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	_, err = r.UpdatePR(ctx, &model.PullRequest{ID: "missing", Status: model.PROpen, Version: 1})
+	require.ErrorIs(t, err, repo.ErrNotFound)
+
+	require.ErrorIs(t, r.CreateTeamTx(ctx, model.Team{TeamName: "backend"}), repo.ErrAlreadyExists)
+	require.ErrorIs(t, r.CreatePR(ctx, &model.PullRequest{ID: "pr-1", Name: "Dup", AuthorID: "u1", Status: model.PROpen}), repo.ErrAlreadyExists)
+
+	// Внешние ключи: автор PR, команда пользователя и команда роли.
+	require.ErrorIs(t, r.CreatePR(ctx, &model.PullRequest{ID: "pr-2", Name: "x", AuthorID: "ghost", Status: model.PROpen}), repo.ErrNotFound)
+	err = r.WithTransaction(ctx, func(tx repo.TxRepository) error {
+		return tx.UpsertUser(ctx, model.User{UserID: "u9", Username: "x", TeamName: "missing"})
+	})
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	require.ErrorIs(t, r.AssignRole(ctx, model.RoleAssignment{UserID: "u1", Role: model.RoleTeamLead, TeamName: "missing"}), repo.ErrNotFound)
+	require.ErrorIs(t, r.RevokeRole(ctx, model.RoleAssignment{UserID: "u1", Role: model.RoleOrgAdmin}), repo.ErrNotFound)
+
+	// Неудачные вызовы ничего не оставляют.
+	_, err = r.GetPRByID(ctx, "pr-2")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	_, err = r.GetUserByID(ctx, "u9")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+}
+
+func testWorkflow(t *testing.T, r repo.Repository) {
+	ctx := context.Background()
+
+	require.NoError(t, r.WithTransaction(ctx, func(tx repo.TxRepository) error {
+		empty, err := tx.IsEmpty(ctx)
+		require.True(t, empty)
+		return err
+	}))
+
+	team := model.Team{
+		TeamName: "backend",
+		Members: []model.TeamMember{
+			{UserID: "u1", Username: "alice", IsActive: true},
+			{UserID: "u2", Username: "bob", IsActive: true},
+			{UserID: "u3", Username: "carol", IsActive: true},
+			{UserID: "u4", Username: "dave", IsActive: true},
+			{UserID: "u5", Username: "erin", IsActive: false},
+		},
+	}
+	require.NoError(t, r.CreateTeamTx(ctx, team))
+
+	stored, err := r.GetTeam(ctx, team.TeamName)
+	require.NoError(t, err)
+	require.Equal(t, team.Members, stored.Members)
+
+	updatedUser, err := r.SetUserActiveStatus(ctx, "u2", false, 0)
+	require.NoError(t, err)
+	require.False(t, updatedUser.IsActive)
+	_, err = r.SetUserActiveStatus(ctx, "u2", true, 0)
+	require.NoError(t, err)
+
+	// Создание PR сохраняет данные и выставляет created_at.
+	pr := &model.PullRequest{ID: "pr1", Name: "feat", AuthorID: "u1", Status: model.PROpen}
+	require.NoError(t, r.CreatePR(ctx, pr))
+	require.NotNil(t, pr.CreatedAt)
+	require.WithinDuration(t, time.Now(), *pr.CreatedAt, time.Minute)
+
+	// Merge через UpdatePR; повторный update сохраняет merged_at.
+	pr.Status = model.PRMerged
+	now := time.Now()
+	pr.MergedAt = &now
+	merged, err := r.UpdatePR(ctx, pr)
+	require.NoError(t, err)
+	require.Equal(t, model.PRMerged, merged.Status)
+	require.Equal(t, []string{}, merged.AssignedReviewers)
+	mergedAgain, err := r.UpdatePR(ctx, merged)
+	require.NoError(t, err)
+	require.WithinDuration(t, *merged.MergedAt, *mergedAgain.MergedAt, time.Second)
+
+	pr2 := &model.PullRequest{ID: "pr2", Name: "bugfix", AuthorID: "u1", Status: model.PROpen, AssignedReviewers: []string{"u2", "u3"}}
+	require.NoError(t, r.CreatePR(ctx, pr2))
+	pr2.AssignedReviewers = []string{"u4", "u3"}
+	updated, err := r.UpdatePR(ctx, pr2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"u4", "u3"}, updated.AssignedReviewers)
+
+	// Поиск PR по ревьюверу и статусу.
+	prs, err := r.GetPRsByReviewer(ctx, model.ReviewFilter{UserID: "u4"}, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	require.Equal(t, model.PullRequestShort{ID: "pr2", Name: "bugfix", AuthorID: "u1", Status: model.PROpen, CreatedAt: prs[0].CreatedAt}, prs[0])
+	require.True(t, pr2.CreatedAt.Equal(*prs[0].CreatedAt))
+	prs, err = r.GetPRsByReviewer(ctx, model.ReviewFilter{UserID: "u3", Status: model.PRMerged}, nil, 10)
+	require.NoError(t, err)
+	require.Empty(t, prs)
+	prs, err = r.GetPRsByReviewer(ctx, model.ReviewFilter{UserID: "u3"}, &model.PRCursor{CreatedAt: *pr2.CreatedAt, ID: pr2.ID}, 10)
+	require.NoError(t, err)
+	require.Empty(t, prs)
+
+	// Фильтры поиска PR.
+	list, err := r.ListPRs(ctx, model.PRFilter{Status: model.PROpen, ReviewerID: "u4", TeamName: "backend", NameContains: "BUG"}, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "pr2", list[0].ID)
+	list, err = r.ListPRs(ctx, model.PRFilter{}, nil, 1)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "pr2", list[0].ID)
+	list, err = r.ListPRs(ctx, model.PRFilter{}, &model.PRCursor{CreatedAt: *list[0].CreatedAt, ID: list[0].ID}, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "pr1", list[0].ID)
+	list, err = r.ListPRs(ctx, model.PRFilter{AuthorID: "u2"}, nil, 10)
+	require.NoError(t, err)
+	require.Empty(t, list)
+	list, err = r.ListPRs(ctx, model.PRFilter{TeamName: "frontend"}, nil, 10)
+	require.NoError(t, err)
+	require.Empty(t, list)
+	mergedFrom := now.Add(-time.Minute)
+	list, err = r.ListPRs(ctx, model.PRFilter{MergedFrom: &mergedFrom}, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, "pr1", list[0].ID)
+	createdTo := pr.CreatedAt.Add(-time.Hour)
+	list, err = r.ListPRs(ctx, model.PRFilter{CreatedTo: &createdTo}, nil, 10)
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	// Справочник пользователей: открытые ревью и фильтры.
+	details, err := r.GetUserDetails(ctx, "u4")
+	require.NoError(t, err)
+	require.Equal(t, 1, details.OpenReviewCount)
+	inactive := false
+	users, err := r.ListUsers(ctx, model.UserFilter{TeamName: "backend", IsActive: &inactive}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, "u5", users[0].UserID)
+	users, err = r.ListUsers(ctx, model.UserFilter{UsernamePrefix: "A"}, "", 10)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	require.Equal(t, "alice", users[0].Username)
+
+	// Импорт: EnsureTeam/UpsertUser идемпотентны, точка сохранения откатывает только свою часть.
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	err = r.WithTransaction(ctx, func(tx repo.TxRepository) error {
+		require.NoError(t, tx.EnsureTeam(ctx, "imported"))
+		require.NoError(t, tx.EnsureTeam(ctx, "imported"))
+		require.NoError(t, tx.UpsertUser(ctx, model.User{UserID: "i1", Username: "ivan", TeamName: "imported", IsActive: true}))
+
+		spErr := tx.WithSavepoint(ctx, func(sp repo.TxRepository) error {
+			return sp.UpsertUser(ctx, model.User{UserID: "i2", Username: "x", TeamName: "missing", IsActive: true})
+		})
+		require.ErrorIs(t, spErr, repo.ErrNotFound)
+
+		spErr = tx.WithSavepoint(ctx, func(sp repo.TxRepository) error {
+			require.NoError(t, sp.UpsertUser(ctx, model.User{UserID: "i3", Username: "rolled back", TeamName: "imported"}))
+			return repo.ErrAlreadyExists
+		})
+		require.ErrorIs(t, spErr, repo.ErrAlreadyExists)
+		_, getErr := tx.GetUserByID(ctx, "i3")
+		require.ErrorIs(t, getErr, repo.ErrNotFound)
+
+		members, err := tx.ListTeamMembers(ctx, "imported")
+		require.NoError(t, err)
+		require.Len(t, members, 1)
+
+		return tx.CreatePR(ctx, &model.PullRequest{ID: "pr-imp", Name: "legacy", AuthorID: "i1", Status: model.PROpen, CreatedAt: &created})
+	})
+	require.NoError(t, err)
+	imported, err := r.GetPRByID(ctx, "pr-imp")
+	require.NoError(t, err)
+	require.True(t, created.Equal(*imported.CreatedAt))
+
+	var sink recordingSink
+	require.NoError(t, r.Export(ctx, &sink))
+	require.Equal(t, []string{"backend", "imported"}, sink.teams)
+	require.Len(t, sink.users, 6)
+	require.Equal(t, "i1", sink.users[0].UserID)
+	require.Equal(t, []string{"pr-imp", "pr1", "pr2"}, sink.prs)
+
+	// Перенос пользователя в другую команду.
+	require.NoError(t, r.WithTransaction(ctx, func(tx repo.TxRepository) error {
+		return tx.UpsertUser(ctx, model.User{UserID: "u5", Username: "erin", TeamName: "imported", IsActive: true})
+	}))
+	members, err := r.ListTeamMembers(ctx, "imported")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"i1", "u5"}, userIDs(members))
+
+	require.NoError(t, r.WithTransaction(ctx, func(tx repo.TxRepository) error {
+		empty, err := tx.IsEmpty(ctx)
+		require.False(t, empty)
+		return err
+	}))
+}
+
+// testOrdering проверяет стабильный порядок списков и keyset-пагинацию,
+// в том числе для PR с одинаковым created_at.
+func testOrdering(t *testing.T, r repo.Repository) {
+	ctx := context.Background()
+	require.NoError(t, r.CreateTeamTx(ctx, model.Team{TeamName: "backend", Members: []model.TeamMember{
+		{UserID: "u3", Username: "Carol", IsActive: true},
+		{UserID: "u1", Username: "Alice", IsActive: true},
+		{UserID: "u2", Username: "Bob", IsActive: true},
+	}}))
+	require.NoError(t, r.CreateTeamTx(ctx, model.Team{TeamName: "alpha"}))
+
+	team, err := r.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	require.Equal(t, []string{"u1", "u2", "u3"}, memberIDs(team.Members))
+	empty, err := r.GetTeam(ctx, "alpha")
+	require.NoError(t, err)
+	require.Equal(t, []model.TeamMember{}, empty.Members)
+
+	users, err := r.ListUsers(ctx, model.UserFilter{}, "", 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"u1", "u2"}, detailIDs(users))
+	users, err = r.ListUsers(ctx, model.UserFilter{}, "u2", 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"u3"}, detailIDs(users))
+	users, err = r.ListUsers(ctx, model.UserFilter{}, "u3", 2)
+	require.NoError(t, err)
+	require.Equal(t, []model.UserDetails{}, users)
+
+	base := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	// pr-b и pr-c созданы одновременно: порядок между ними задаёт pull_request_id.
+	created := map[string]time.Time{
+		"pr-a": base,
+		"pr-c": base.Add(time.Hour),
+		"pr-b": base.Add(time.Hour),
+		"pr-d": base.Add(2 * time.Hour),
+	}
+	for _, id := range []string{"pr-a", "pr-c", "pr-b", "pr-d"} {
+		at := created[id]
+		require.NoError(t, r.CreatePR(ctx, &model.PullRequest{ID: id, Name: id, AuthorID: "u1", Status: model.PROpen, AssignedReviewers: []string{"u2"}, CreatedAt: &at}))
+	}
+	want := []string{"pr-d", "pr-c", "pr-b", "pr-a"}
+
+	prs, err := r.ListPRs(ctx, model.PRFilter{}, nil, 10)
+	require.NoError(t, err)
+	require.Equal(t, want, prIDs(prs))
+
+	var got []string
+	var after *model.PRCursor
+	for {
+		page, err := r.ListPRs(ctx, model.PRFilter{}, after, 1)
+		require.NoError(t, err)
+		if len(page) == 0 {
+			break
+		}
+		got = append(got, page[0].ID)
+		after = &model.PRCursor{CreatedAt: *page[0].CreatedAt, ID: page[0].ID}
+	}
+	require.Equal(t, want, got)
+
+	got, after = nil, nil
+	for {
+		page, err := r.GetPRsByReviewer(ctx, model.ReviewFilter{UserID: "u2"}, after, 3)
+		require.NoError(t, err)
+		for _, pr := range page {
+			got = append(got, pr.ID)
+		}
+		if len(page) < 3 {
+			break
+		}
+		last := page[len(page)-1]
+		after = &model.PRCursor{CreatedAt: *last.CreatedAt, ID: last.ID}
+	}
+	require.Equal(t, want, got)
+
+	// Курсор между PR с одинаковым created_at.
+	prs, err = r.ListPRs(ctx, model.PRFilter{}, &model.PRCursor{CreatedAt: created["pr-c"], ID: "pr-c"}, 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{"pr-b", "pr-a"}, prIDs(prs))
+
+	// Выгрузка: команды и пользователи по имени, PR от старых к новым.
+	var sink recordingSink
+	require.NoError(t, r.Export(ctx, &sink))
+	require.Equal(t, []string{"alpha", "backend"}, sink.teams)
+	require.Equal(t, []string{"u1", "u2", "u3"}, userIDs(sink.users))
+	require.Equal(t, []string{"pr-a", "pr-b", "pr-c", "pr-d"}, sink.prs)
+}
+
+func testVersions(t *testing.T, r repo.Repository) {
+	ctx := context.Background()
+	team := model.Team{TeamName: "backend", Members: []model.TeamMember{
+		{UserID: "u1", Username: "Alice", IsActive: true},
+		{UserID: "u2", Username: "Bob", IsActive: true},
+	}}
+	require.NoError(t, r.CreateTeamTx(ctx, team))
+	stored, err := r.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	teamVersion := stored.Version
+
+	// Изменение участника увеличивает версии пользователя и команды.
+	user, err := r.SetUserActiveStatus(ctx, "u2", false, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), user.Version)
+	_, err = r.SetUserActiveStatus(ctx, "u2", true, 1)
+	require.ErrorIs(t, err, repo.ErrVersionConflict)
+	_, err = r.SetUserActiveStatus(ctx, "missing", true, 1)
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	stored, err = r.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	require.Greater(t, stored.Version, teamVersion)
+
+	pr := &model.PullRequest{ID: "pr-1", Name: "Fix", AuthorID: "u1", Status: model.PROpen, AssignedReviewers: []string{"u2"}}
+	require.NoError(t, r.CreatePR(ctx, pr))
+	require.Equal(t, int64(1), pr.Version)
+
+	// Второй писатель с устаревшей версией не перезаписывает изменения первого.
+	first, err := r.GetPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	second := *first
+	first.AssignedReviewers = []string{}
+	updated, err := r.UpdatePR(ctx, first)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), updated.Version)
+
+	second.Status = model.PRMerged
+	_, err = r.UpdatePR(ctx, &second)
+	require.ErrorIs(t, err, repo.ErrVersionConflict)
+	current, err := r.GetPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, model.PROpen, current.Status)
+
+	// Изменение возвращённых данных не меняет сохранённые.
+	current.AssignedReviewers = append(current.AssignedReviewers, "u1")
+	again, err := r.GetPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, []string{}, again.AssignedReviewers)
+
+	missing := second
+	missing.ID = "pr-missing"
+	_, err = r.UpdatePR(ctx, &missing)
+	require.ErrorIs(t, err, repo.ErrNotFound)
+}
+
+// testDeleteUser проверяет, что удалить можно только пользователя, на которого не ссылаются PR.
+func testDeleteUser(t *testing.T, r repo.Repository) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r)
+	before, err := r.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+
+	// u1 — автор pr-1, u2 — его ревьювер.
+	_, err = r.DeleteUser(ctx, "u1", 0)
+	require.ErrorIs(t, err, repo.ErrInUse)
+	_, err = r.DeleteUser(ctx, "u2", 0)
+	require.ErrorIs(t, err, repo.ErrInUse)
+	_, err = r.DeleteUser(ctx, "missing", 0)
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	_, err = r.DeleteUser(ctx, "u3", 5)
+	require.ErrorIs(t, err, repo.ErrVersionConflict)
+	_, err = r.DeleteUser(tenant.NewContext(ctx, "acme"), "u3", 0)
+	require.ErrorIs(t, err, repo.ErrNotFound)
+
+	deleted, err := r.DeleteUser(ctx, "u3", 1)
+	require.NoError(t, err)
+	require.Equal(t, "backend", deleted.TeamName)
+	_, err = r.GetUserByID(ctx, "u3")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+
+	after, err := r.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	require.Greater(t, after.Version, before.Version)
+	require.Equal(t, []string{"u1", "u2", "u4"}, memberIDs(after.Members))
+}
+
+func testOrganizations(t *testing.T, r repo.Repository) {
+	acme := tenant.NewContext(context.Background(), "acme")
+	globex := tenant.NewContext(context.Background(), "globex")
+
+	// Одинаковые имена команд, user_id и pull_request_id в разных организациях не конфликтуют.
+	for _, ctx := range []context.Context{acme, globex} {
+		team := model.Team{TeamName: "backend", Members: []model.TeamMember{
+			{UserID: "u1", Username: tenant.FromContext(ctx) + "-alice", IsActive: true},
+			{UserID: "u2", Username: tenant.FromContext(ctx) + "-bob", IsActive: true},
+		}}
+		require.NoError(t, r.CreateTeamTx(ctx, team))
+		require.NoError(t, r.CreatePR(ctx, &model.PullRequest{ID: "pr-1", Name: "x", AuthorID: "u1", Status: model.PROpen, AssignedReviewers: []string{"u2"}}))
+	}
+
+	user, err := r.GetUserByID(globex, "u1")
+	require.NoError(t, err)
+	require.Equal(t, "globex-alice", user.Username)
+
+	_, err = r.SetUserActiveStatus(acme, "u2", false, 0)
+	require.NoError(t, err)
+	other, err := r.GetUserByID(globex, "u2")
+	require.NoError(t, err)
+	require.True(t, other.IsActive)
+
+	_, err = r.UpdatePR(acme, &model.PullRequest{ID: "pr-1", Status: model.PRMerged, AssignedReviewers: []string{"u2"}, Version: 1})
+	require.NoError(t, err)
+	pr, err := r.GetPRByID(globex, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, model.PROpen, pr.Status)
+
+	prs, err := r.ListPRs(globex, model.PRFilter{Status: model.PRMerged}, nil, 10)
+	require.NoError(t, err)
+	require.Empty(t, prs)
+	details, err := r.GetUserDetails(globex, "u2")
+	require.NoError(t, err)
+	require.Equal(t, 1, details.OpenReviewCount)
+
+	// Организация без данных ничего не видит.
+	empty := tenant.NewContext(context.Background(), "initech")
+	_, err = r.GetTeam(empty, "backend")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	// Автор из другой организации не найден.
+	require.ErrorIs(t, r.CreatePR(empty, &model.PullRequest{ID: "pr-2", Name: "x", AuthorID: "u1", Status: model.PROpen}), repo.ErrNotFound)
+	err = r.WithTransaction(empty, func(tx repo.TxRepository) error {
+		isEmpty, err := tx.IsEmpty(empty)
+		require.True(t, isEmpty)
+		return err
+	})
+	require.NoError(t, err)
+
+	sink := &recordingSink{}
+	require.NoError(t, r.Export(acme, sink))
+	require.Equal(t, []string{"backend"}, sink.teams)
+	require.Len(t, sink.users, 2)
+	require.Equal(t, []string{"pr-1"}, sink.prs)
+}
+
+func testRoles(t *testing.T, r repo.Repository) {
+	ctx := context.Background()
+	require.NoError(t, r.CreateTeamTx(ctx, model.Team{TeamName: "backend"}))
+
+	admin := model.RoleAssignment{UserID: "u1", Role: model.RoleOrgAdmin}
+	lead := model.RoleAssignment{UserID: "u1", Role: model.RoleTeamLead, TeamName: "backend"}
+	other := model.RoleAssignment{UserID: "u0", Role: model.RoleTeamLead, TeamName: "backend"}
+	require.NoError(t, r.AssignRole(ctx, lead))
+	require.NoError(t, r.AssignRole(ctx, admin))
+	require.NoError(t, r.AssignRole(ctx, admin))
+	require.NoError(t, r.AssignRole(ctx, other))
+
+	roles, err := r.ListRoles(ctx, "u1")
+	require.NoError(t, err)
+	require.Equal(t, []model.RoleAssignment{admin, lead}, roles)
+	roles, err = r.ListRoles(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, []model.RoleAssignment{other, admin, lead}, roles)
+	roles, err = r.ListRoles(tenant.NewContext(ctx, "acme"), "")
+	require.NoError(t, err)
+	require.Equal(t, []model.RoleAssignment{}, roles)
+
+	require.NoError(t, r.RevokeRole(ctx, lead))
+	require.ErrorIs(t, r.RevokeRole(ctx, lead), repo.ErrNotFound)
+	roles, err = r.ListRoles(ctx, "u1")
+	require.NoError(t, err)
+	require.Equal(t, []model.RoleAssignment{admin}, roles)
+}
+
+// testTransactionRollback проверяет, что ошибка fn откатывает все изменения
+// транзакции, а до фиксации они не видны снаружи.
+func testTransactionRollback(t *testing.T, r repo.Repository) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r)
+	errAbort := errors.New("abort")
+
+	err := r.WithTransaction(ctx, func(tx repo.TxRepository) error {
+		require.NoError(t, tx.EnsureTeam(ctx, "frontend"))
+		require.NoError(t, tx.UpsertUser(ctx, model.User{UserID: "u2", Username: "Bob", TeamName: "frontend", IsActive: true}))
+		require.NoError(t, tx.UpsertUser(ctx, model.User{UserID: "u5", Username: "Eve", TeamName: "frontend", IsActive: true}))
+		require.NoError(t, tx.CreatePR(ctx, &model.PullRequest{ID: "pr-2", Name: "new", AuthorID: "u5", Status: model.PROpen}))
+		pr, err := tx.GetPRByIDForUpdate(ctx, "pr-1")
+		require.NoError(t, err)
+		pr.Status = model.PRMerged
+		_, err = tx.UpdatePR(ctx, pr)
+		require.NoError(t, err)
+
+		inside, err := tx.GetUserByID(ctx, "u2")
+		require.NoError(t, err)
+		require.Equal(t, "frontend", inside.TeamName)
+		outside, err := r.GetUserByID(ctx, "u2")
+		require.NoError(t, err)
+		require.Equal(t, "backend", outside.TeamName)
+		_, err = r.GetPRByID(ctx, "pr-2")
+		require.ErrorIs(t, err, repo.ErrNotFound)
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	_, err = r.GetTeam(ctx, "frontend")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	_, err = r.GetUserByID(ctx, "u5")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	_, err = r.GetPRByID(ctx, "pr-2")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	user, err := r.GetUserByID(ctx, "u2")
+	require.NoError(t, err)
+	require.Equal(t, "backend", user.TeamName)
+	pr, err := r.GetPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, model.PROpen, pr.Status)
+	require.Equal(t, int64(1), pr.Version)
+
+	// Транзакция без ошибки фиксируется.
+	require.NoError(t, r.WithTransaction(ctx, func(tx repo.TxRepository) error {
+		return tx.EnsureTeam(ctx, "frontend")
+	}))
+	_, err = r.GetTeam(ctx, "frontend")
+	require.NoError(t, err)
+}
+
+// testForUpdateBlocks проверяет, что GetPRByIDForUpdate второй транзакции
+// ждёт завершения первой и видит её изменения.
+func testForUpdateBlocks(t *testing.T, r repo.Repository) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r)
+
+	locked, release := make(chan struct{}), make(chan struct{})
+	first := make(chan error, 1)
+	go func() {
+		first <- r.WithTransaction(ctx, func(tx repo.TxRepository) error {
+			pr, err := tx.GetPRByIDForUpdate(ctx, "pr-1")
+			if err != nil {
+				return err
+			}
+			close(locked)
+			<-release
+			pr.AssignedReviewers = []string{"u3"}
+			_, err = tx.UpdatePR(ctx, pr)
+			return err
+		})
+	}()
+	<-locked
+
+	type result struct {
+		pr  *model.PullRequest
+		err error
+	}
+	second := make(chan result, 1)
+	go func() {
+		var res result
+		res.err = r.WithTransaction(ctx, func(tx repo.TxRepository) error {
+			res.pr, res.err = tx.GetPRByIDForUpdate(ctx, "pr-1")
+			return res.err
+		})
+		second <- res
+	}()
+
+	select {
+	case res := <-second:
+		t.Fatalf("GetPRByIDForUpdate did not wait for the locking transaction: %+v", res)
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	require.NoError(t, <-first)
+
+	res := <-second
+	require.NoError(t, res.err)
+	require.Equal(t, int64(2), res.pr.Version)
+	require.Equal(t, []string{"u3"}, res.pr.AssignedReviewers)
+}
+
+// testForUpdateSerializes запускает параллельные read-modify-write через
+// GetPRByIDForUpdate: ни одна транзакция не должна получить ErrVersionConflict.
+func testForUpdateSerializes(t *testing.T, r repo.Repository) {
+	ctx := context.Background()
+	seedTeam(t, ctx, r)
+
+	const n = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- r.WithTransaction(ctx, func(tx repo.TxRepository) error {
+				pr, err := tx.GetPRByIDForUpdate(ctx, "pr-1")
+				if err != nil {
+					return err
+				}
+				_, err = tx.UpdatePR(ctx, pr)
+				return err
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	pr, err := r.GetPRByID(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, int64(n+1), pr.Version)
+}
+
+func testAPITokens(t *testing.T, r repo.Repository) {
+	tokens, ok := r.(repo.TokenRepository)
+	if !ok {
+		t.Skip("repository does not store API tokens")
+	}
+	ctx := context.Background()
+
+	created := time.Now().UTC().Truncate(time.Microsecond)
+	token := model.APIToken{ID: "tok_1", OrgID: tenant.Default, Name: "ci", Scopes: []string{"prs:write", "read"}, CreatedAt: created}
+	require.NoError(t, tokens.CreateAPIToken(ctx, token, "hash-1"))
+	require.ErrorIs(t, tokens.CreateAPIToken(ctx, model.APIToken{ID: "tok_2", OrgID: tenant.Default, Name: "dup", CreatedAt: created}, "hash-1"), repo.ErrAlreadyExists)
+	require.ErrorIs(t, tokens.CreateAPIToken(ctx, model.APIToken{ID: "tok_1", OrgID: tenant.Default, Name: "dup", CreatedAt: created}, "hash-2"), repo.ErrAlreadyExists)
+	acmeToken := model.APIToken{ID: "tok_3", OrgID: "acme", Name: "acme", Scopes: []string{"read"}, CreatedAt: created}
+	require.NoError(t, tokens.CreateAPIToken(ctx, acmeToken, "hash-3"))
+
+	// Поиск по хэшу идёт по всем организациям.
+	stored, err := tokens.GetAPITokenByHash(ctx, "hash-3")
+	require.NoError(t, err)
+	require.Equal(t, "acme", stored.OrgID)
+	stored, err = tokens.GetAPITokenByHash(ctx, "hash-1")
+	require.NoError(t, err)
+	require.Equal(t, token.Scopes, stored.Scopes)
+	require.Nil(t, stored.RevokedAt)
+	_, err = tokens.GetAPITokenByHash(ctx, "unknown")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+
+	revokedAt := created.Add(time.Minute)
+	require.NoError(t, tokens.RevokeAPIToken(ctx, "tok_1", revokedAt))
+	require.NoError(t, tokens.RevokeAPIToken(ctx, "tok_1", revokedAt.Add(time.Hour)))
+	require.ErrorIs(t, tokens.RevokeAPIToken(ctx, "tok_missing", revokedAt), repo.ErrNotFound)
+	// Токен чужой организации не отзывается.
+	require.ErrorIs(t, tokens.RevokeAPIToken(ctx, "tok_3", revokedAt), repo.ErrNotFound)
+
+	list, err := tokens.ListAPITokens(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.True(t, revokedAt.Equal(*list[0].RevokedAt))
+}
+
+func testIdempotencyKeys(t *testing.T, r repo.Repository) {
+	keys, ok := r.(repo.IdempotencyRepository)
+	if !ok {
+		t.Skip("repository does not store idempotency keys")
+	}
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	rec := model.IdempotencyRecord{Scope: "tok_1", Key: "k1", Fingerprint: "fp", CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+
+	existing, err := keys.ClaimIdempotencyKey(ctx, rec)
+	require.NoError(t, err)
+	require.Nil(t, existing)
+
+	// Пока ответ не сохранён, ключ виден как выполняющийся.
+	existing, err = keys.ClaimIdempotencyKey(ctx, rec)
+	require.ErrorIs(t, err, repo.ErrAlreadyExists)
+	require.Equal(t, 0, existing.StatusCode)
+	require.Equal(t, "fp", existing.Fingerprint)
+
+	headers := map[string]string{"Content-Type": "application/json", "Location": "/v2/pull-requests/pr-1"}
+	require.NoError(t, keys.CompleteIdempotencyKey(ctx, "tok_1", "k1", 201, headers, []byte(`{"ok":true}`)))
+	existing, err = keys.ClaimIdempotencyKey(ctx, rec)
+	require.ErrorIs(t, err, repo.ErrAlreadyExists)
+	require.Equal(t, 201, existing.StatusCode)
+	require.Equal(t, headers, existing.Headers)
+	require.Equal(t, `{"ok":true}`, string(existing.Response))
+	// Сохранённый ответ не снимается.
+	require.NoError(t, keys.ReleaseIdempotencyKey(ctx, "tok_1", "k1"))
+	_, err = keys.ClaimIdempotencyKey(ctx, rec)
+	require.ErrorIs(t, err, repo.ErrAlreadyExists)
+
+	// Тот же ключ другого вызывающего или организации свободен.
+	other := rec
+	other.Scope = "tok_2"
+	_, err = keys.ClaimIdempotencyKey(ctx, other)
+	require.NoError(t, err)
+	_, err = keys.ClaimIdempotencyKey(tenant.NewContext(ctx, "acme"), rec)
+	require.NoError(t, err)
+
+	// Снятый резерв и истёкший ключ можно занять заново.
+	require.NoError(t, keys.ReleaseIdempotencyKey(ctx, "tok_2", "k1"))
+	_, err = keys.ClaimIdempotencyKey(ctx, other)
+	require.NoError(t, err)
+	later := rec
+	later.CreatedAt, later.ExpiresAt = now.Add(2*time.Hour), now.Add(3*time.Hour)
+	_, err = keys.ClaimIdempotencyKey(ctx, later)
+	require.NoError(t, err)
+
+	deleted, err := keys.DeleteExpiredIdempotencyKeys(ctx, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.EqualValues(t, 2, deleted)
+}
+
+// --- Хелперы ---
+
+type recordingSink struct {
+	teams []string
+	users []model.User
+	prs   []string
+}
+
+func (s *recordingSink) WriteTeam(teamName string) error {
+	s.teams = append(s.teams, teamName)
+	return nil
+}
+
+func (s *recordingSink) WriteUser(user model.User) error {
+	s.users = append(s.users, user)
+	return nil
+}
+
+func (s *recordingSink) WritePullRequest(pr model.PullRequest) error {
+	s.prs = append(s.prs, pr.ID)
+	return nil
+}
+
+func userIDs(users []model.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.UserID)
+	}
+	return ids
+}
+
+func detailIDs(users []model.UserDetails) []string {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.UserID)
+	}
+	return ids
+}
+
+func memberIDs(members []model.TeamMember) []string {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		ids = append(ids, m.UserID)
+	}
+	return ids
+}
+
+func prIDs(prs []model.PullRequest) []string {
+	ids := make([]string, 0, len(prs))
+	for _, pr := range prs {
+		ids = append(ids, pr.ID)
+	}
+	return ids
+}
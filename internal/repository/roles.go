@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/trainee/review-service/internal/model"
+)
+
+func (r *PostgresRepository) ListRoles(ctx context.Context, userID string) ([]model.RoleAssignment, error) {
+	var w whereBuilder
+	w.add("org_id = %s", orgOf(ctx))
+	if userID != "" {
+		w.add("user_id = %s", userID)
+	}
+	query := `SELECT user_id, role, COALESCE(team_name, '') FROM user_roles` + w.sql() + ` ORDER BY user_id, role, team_name`
+	rows, err := r.pool.Query(ctx, query, w.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.RoleAssignment, error) {
+		var ra model.RoleAssignment
+		err := row.Scan(&ra.UserID, &ra.Role, &ra.TeamName)
+		return ra, err
+	})
+}
+
+func (r *PostgresRepository) AssignRole(ctx context.Context, role model.RoleAssignment) error {
+	query := `
+		INSERT INTO user_roles (org_id, user_id, role, team_name)
+		VALUES ($1, $2, $3, NULLIF($4, ''))
+		ON CONFLICT (org_id, user_id, role, COALESCE(team_name, '')) DO NOTHING
+	`
+	_, err := r.pool.Exec(ctx, query, orgOf(ctx), role.UserID, role.Role, role.TeamName)
+	return handleError(err)
+}
+
+func (r *PostgresRepository) RevokeRole(ctx context.Context, role model.RoleAssignment) error {
+	query := `
+		DELETE FROM user_roles
+		WHERE org_id = $1 AND user_id = $2 AND role = $3 AND COALESCE(team_name, '') = $4
+	`
+	tag, err := r.pool.Exec(ctx, query, orgOf(ctx), role.UserID, role.Role, role.TeamName)
+	if err != nil {
+		return handleError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/trainee/review-service/internal/model"
+)
+
+// TokenRepository хранит API токены (только хэши значений). Токен принадлежит
+// организации: список и отзыв ограничены организацией из контекста.
+type TokenRepository interface {
+	CreateAPIToken(ctx context.Context, token model.APIToken, hash string) error
+	// GetAPITokenByHash возвращает токен по хэшу, включая отозванные и истёкшие.
+	// Поиск идёт по всем организациям: организация вызывающего определяется токеном.
+	GetAPITokenByHash(ctx context.Context, hash string) (*model.APIToken, error)
+	ListAPITokens(ctx context.Context) ([]model.APIToken, error)
+	RevokeAPIToken(ctx context.Context, tokenID string, at time.Time) error
+}
+
+const tokenSelect = `SELECT token_id, org_id, name, scopes, created_at, expires_at, revoked_at FROM api_tokens`
+
+func scanToken(row pgx.Row) (model.APIToken, error) {
+	var t model.APIToken
+	err := row.Scan(&t.ID, &t.OrgID, &t.Name, &t.Scopes, &t.CreatedAt, &t.ExpiresAt, &t.RevokedAt)
+	return t, err
+}
+
+func (r *PostgresRepository) CreateAPIToken(ctx context.Context, token model.APIToken, hash string) error {
+	query := `
+		INSERT INTO api_tokens (token_id, org_id, name, token_hash, scopes, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.pool.Exec(ctx, query, token.ID, token.OrgID, token.Name, hash, token.Scopes, token.CreatedAt, token.ExpiresAt)
+	return handleError(err)
+}
+
+func (r *PostgresRepository) GetAPITokenByHash(ctx context.Context, hash string) (*model.APIToken, error) {
+	t, err := scanToken(r.pool.QueryRow(ctx, tokenSelect+` WHERE token_hash = $1`, hash))
+	if err != nil {
+		return nil, handleError(err)
+	}
+	return &t, nil
+}
+
+func (r *PostgresRepository) ListAPITokens(ctx context.Context) ([]model.APIToken, error) {
+	rows, err := r.pool.Query(ctx, tokenSelect+` WHERE org_id = $1 ORDER BY created_at, token_id`, orgOf(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (model.APIToken, error) {
+		return scanToken(row)
+	})
+}
+
+// RevokeAPIToken отзывает токен; повторный отзыв не меняет исходное время.
+func (r *PostgresRepository) RevokeAPIToken(ctx context.Context, tokenID string, at time.Time) error {
+	query := `
+		UPDATE api_tokens SET revoked_at = COALESCE(revoked_at, $2)
+		WHERE token_id = $1 AND org_id = $3
+		RETURNING token_id
+	`
+	var id string
+	return handleError(r.pool.QueryRow(ctx, query, tokenID, at, orgOf(ctx)).Scan(&id))
+}
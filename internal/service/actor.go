@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/tenant"
+)
+
+// Actor возвращает вызывающего, от имени которого выполняется операция, или nil,
+// если аутентификация выключена. Транспорт кладёт его в контекст через auth.NewContext.
+func Actor(ctx context.Context) *auth.Principal {
+	return auth.FromContext(ctx)
+}
+
+// audit пишет в лог успешную мутацию вместе с её инициатором и организацией.
+func audit(ctx context.Context, action string, attrs ...any) {
+	actor, method := "anonymous", ""
+	if p := Actor(ctx); p != nil {
+		actor, method = p.Subject, p.Method
+	}
+	slog.InfoContext(ctx, "audit", append([]any{"action", action, "actor", actor, "auth_method", method, "org", tenant.FromContext(ctx)}, attrs...)...)
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/trainee/review-service/internal/archive"
+	"github.com/trainee/review-service/internal/model"
+	repo "github.com/trainee/review-service/internal/repository"
+)
+
+// Export пишет в w полный архив данных сервиса (см. пакет archive).
+func (s *Service) Export(ctx context.Context, w io.Writer) error {
+	ctx, span := startSpan(ctx, "Export")
+	defer span.End()
+	if err := s.requireOrgAdmin(ctx, "export"); err != nil {
+		return err
+	}
+	aw, err := archive.NewWriter(w, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Export(ctx, aw); err != nil {
+		return err
+	}
+	return aw.Close()
+}
+
+// Restore загружает архив в пустую БД одной транзакцией. Любое нарушение целостности
+// (ссылка на отсутствующую команду или пользователя, дубликат, несовпадение контрольной
+// суммы) откатывает восстановление целиком.
+func (s *Service) Restore(ctx context.Context, r io.Reader) (*model.RestoreReport, error) {
+	ctx, span := startSpan(ctx, "Restore")
+	defer span.End()
+	if err := s.requireOrgAdmin(ctx, "restore"); err != nil {
+		return nil, err
+	}
+	ar, err := archive.NewReader(r)
+	if err != nil {
+		return nil, restoreError(err)
+	}
+	report := &model.RestoreReport{Version: ar.Header().Version}
+
+	err = s.repo.WithTransaction(ctx, func(tx repo.TxRepository) error {
+		empty, err := tx.IsEmpty(ctx)
+		if err != nil {
+			return err
+		}
+		if !empty {
+			return model.ErrNotEmpty
+		}
+
+		rs := &restoreState{teams: map[string]bool{}, users: map[string]bool{}, prs: map[string]bool{}}
+		for {
+			rec, err := ar.Next()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := rs.check(rec, report); err != nil {
+				return fmt.Errorf("%w: line %d: %v", archive.ErrInvalid, rec.Line, err)
+			}
+			// Ошибки хранилища не относятся к содержимому архива: они не превращаются в 400.
+			if err := store(ctx, tx, rec); err != nil {
+				return fmt.Errorf("line %d: %w", rec.Line, mapError(err))
+			}
+		}
+	})
+	if err != nil {
+		return nil, restoreError(err)
+	}
+	audit(ctx, "restore", "teams", report.Teams, "users", report.Users, "pull_requests", report.PullRequests)
+	return report, nil
+}
+
+// restoreError переводит ошибки целостности архива в ErrBadRequest.
+func restoreError(err error) error {
+	if errors.Is(err, archive.ErrInvalid) {
+		return fmt.Errorf("%w: %v", model.ErrBadRequest, err)
+	}
+	return mapError(err)
+}
+
+// restoreState хранит уже загруженные ключи для проверки ссылок и дубликатов.
+type restoreState struct {
+	teams map[string]bool
+	users map[string]bool
+	prs   map[string]bool
+}
+
+// check проверяет запись архива и запоминает её ключ.
+func (rs *restoreState) check(rec *archive.Record, report *model.RestoreReport) error {
+	switch rec.Kind {
+	case archive.KindTeam:
+		if rec.TeamName == "" {
+			return errors.New("team_name is required")
+		}
+		if rs.teams[rec.TeamName] {
+			return fmt.Errorf("duplicate team %q", rec.TeamName)
+		}
+		rs.teams[rec.TeamName] = true
+		report.Teams++
+		return nil
+
+	case archive.KindUser:
+		user := rec.User()
+		if user.UserID == "" || user.Username == "" || rec.IsActive == nil {
+			return errors.New("user_id, username and is_active are required")
+		}
+		if rs.users[user.UserID] {
+			return fmt.Errorf("duplicate user %q", user.UserID)
+		}
+		if !rs.teams[user.TeamName] {
+			return fmt.Errorf("user %q references unknown team %q", user.UserID, user.TeamName)
+		}
+		rs.users[user.UserID] = true
+		report.Users++
+		return nil
+
+	case archive.KindPullRequest:
+		pr := rec.PullRequest()
+		if err := rs.checkPR(pr); err != nil {
+			return err
+		}
+		rs.prs[pr.ID] = true
+		report.PullRequests++
+		return nil
+	}
+	return fmt.Errorf("unexpected record kind %q", rec.Kind)
+}
+
+// store сохраняет проверенную запись архива.
+func store(ctx context.Context, tx repo.TxRepository, rec *archive.Record) error {
+	switch rec.Kind {
+	case archive.KindTeam:
+		return tx.EnsureTeam(ctx, rec.TeamName)
+	case archive.KindUser:
+		return tx.UpsertUser(ctx, rec.User())
+	default:
+		pr := rec.PullRequest()
+		return tx.CreatePR(ctx, &pr)
+	}
+}
+
+func (rs *restoreState) checkPR(pr model.PullRequest) error {
+	if pr.ID == "" || pr.Name == "" || pr.CreatedAt == nil {
+		return errors.New("pull_request_id, pull_request_name and created_at are required")
+	}
+	if rs.prs[pr.ID] {
+		return fmt.Errorf("duplicate pull request %q", pr.ID)
+	}
+	if !rs.users[pr.AuthorID] {
+		return fmt.Errorf("pull request %q references unknown author %q", pr.ID, pr.AuthorID)
+	}
+	for _, id := range pr.AssignedReviewers {
+		if !rs.users[id] {
+			return fmt.Errorf("pull request %q references unknown reviewer %q", pr.ID, id)
+		}
+	}
+	switch pr.Status {
+	case model.PROpen:
+		if pr.MergedAt != nil {
+			return fmt.Errorf("open pull request %q has merged_at", pr.ID)
+		}
+	case model.PRMerged:
+		if pr.MergedAt == nil {
+			return fmt.Errorf("merged pull request %q has no merged_at", pr.ID)
+		}
+	default:
+		return fmt.Errorf("pull request %q has unknown status %q", pr.ID, pr.Status)
+	}
+	return nil
+}
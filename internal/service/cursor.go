@@ -0,0 +1,78 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/trainee/review-service/internal/model"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+)
+
+// Виды курсоров: курсор одного списка нельзя передать в другой.
+const (
+	userCursorKind   = "users"
+	prCursorKind     = "pull_requests"
+	reviewCursorKind = "reviews"
+)
+
+// normalizeLimit подставляет размер страницы по умолчанию и ограничивает максимальный.
+func normalizeLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		return defaultPageLimit
+	case limit > maxPageLimit:
+		return maxPageLimit
+	default:
+		return limit
+	}
+}
+
+// encodeCursor упаковывает ключ последнего элемента страницы в непрозрачную строку.
+// kind защищает от передачи курсора одного списка в другой.
+func encodeCursor(kind string, key ...string) string {
+	raw, _ := json.Marshal(append([]string{kind}, key...))
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor распаковывает курсор; пустая строка означает первую страницу (nil, nil).
+func decodeCursor(cursor, kind string, keyLen int) ([]string, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, model.ErrBadRequest
+	}
+	var parts []string
+	if err := json.Unmarshal(raw, &parts); err != nil || len(parts) != keyLen+1 || parts[0] != kind {
+		return nil, model.ErrBadRequest
+	}
+	return parts[1:], nil
+}
+
+// encodePRCursor строит курсор по последнему PR страницы.
+func encodePRCursor(kind string, createdAt *time.Time, id string) string {
+	var ts string
+	if createdAt != nil {
+		ts = createdAt.UTC().Format(time.RFC3339Nano)
+	}
+	return encodeCursor(kind, ts, id)
+}
+
+// decodePRCursor восстанавливает ключ (created_at, pull_request_id) из курсора.
+func decodePRCursor(cursor, kind string) (*model.PRCursor, error) {
+	key, err := decodeCursor(cursor, kind, 2)
+	if err != nil || key == nil {
+		return nil, err
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, key[0])
+	if err != nil {
+		return nil, model.ErrBadRequest
+	}
+	return &model.PRCursor{CreatedAt: createdAt, ID: key[1]}, nil
+}
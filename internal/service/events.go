@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/trainee/review-service/internal/events"
+	"github.com/trainee/review-service/internal/tenant"
+)
+
+// Option настраивает Service.
+type Option func(*Service)
+
+// WithEvents включает публикацию доменных событий после успешных мутаций.
+func WithEvents(b *events.Broker) Option {
+	return func(s *Service) {
+		s.events = b
+	}
+}
+
+// publish отправляет событие организации вызывающего, если события включены.
+func (s *Service) publish(ctx context.Context, e events.Event) {
+	if s.events == nil {
+		return
+	}
+	e.Org = tenant.FromContext(ctx)
+	// Подписчики читают событие конкурентно; срез ревьюеров не должен делиться с PR.
+	e.Reviewers = slices.Clone(e.Reviewers)
+	s.events.Publish(e)
+}
+
+// teamOf возвращает команду пользователя для фильтрации событий по команде.
+// Без событий запрос к БД не выполняется; ошибка не мешает уже выполненной операции.
+func (s *Service) teamOf(ctx context.Context, userID string) string {
+	if s.events == nil {
+		return ""
+	}
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to resolve team for event", "user_id", userID, "error", err)
+		return ""
+	}
+	return user.TeamName
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/trainee/review-service/internal/model"
+	repo "github.com/trainee/review-service/internal/repository"
+)
+
+// errImportRollback откатывает транзакцию импорта без ошибки для вызывающего кода.
+var errImportRollback = errors.New("import rolled back")
+
+// importOrder задаёт порядок применения записей: сначала команды, затем пользователи, затем PR.
+var importOrder = map[model.ImportKind]int{
+	model.ImportTeam: 0,
+	model.ImportUser: 1,
+	model.ImportPR:   2,
+}
+
+// Import загружает команды, пользователей и существующие PR.
+//
+// Все записи применяются в одной транзакции, каждая — в своей точке сохранения, поэтому
+// отчёт содержит ошибки всех строк в обоих режимах. В атомарном режиме любая ошибка
+// (включая отвергнутые при разборе строки) откатывает импорт целиком; dry run откатывает
+// его всегда.
+func (s *Service) Import(ctx context.Context, batch model.ImportBatch, opts model.ImportOptions) (*model.ImportReport, error) {
+	ctx, span := startSpan(ctx, "Import")
+	defer span.End()
+	if err := s.requireOrgAdmin(ctx, "import"); err != nil {
+		return nil, err
+	}
+	if opts.Mode == "" {
+		opts.Mode = model.ImportAtomic
+	}
+	if opts.Mode != model.ImportAtomic && opts.Mode != model.ImportBestEffort {
+		return nil, model.ErrBadRequest
+	}
+
+	report := &model.ImportReport{
+		Mode:   opts.Mode,
+		DryRun: opts.DryRun,
+		Total:  len(batch.Records) + len(batch.Rejected),
+		Errors: append([]model.ImportRowError{}, batch.Rejected...),
+	}
+	fail := func(rec model.ImportRecord, err error) {
+		report.Errors = append(report.Errors, model.ImportRowError{
+			Row:     rec.Row,
+			Kind:    rec.Kind,
+			Key:     rec.Key(),
+			Message: importErrorMessage(ctx, err),
+		})
+	}
+
+	records := append([]model.ImportRecord(nil), batch.Records...)
+	sort.SliceStable(records, func(i, j int) bool {
+		return kindOrder(records[i].Kind) < kindOrder(records[j].Kind)
+	})
+
+	err := s.repo.WithTransaction(ctx, func(tx repo.TxRepository) error {
+		for _, rec := range records {
+			if err := validateImportRecord(&rec); err != nil {
+				fail(rec, err)
+				continue
+			}
+			err := tx.WithSavepoint(ctx, func(sp repo.TxRepository) error {
+				return applyImportRecord(ctx, sp, rec)
+			})
+			if err != nil {
+				fail(rec, err)
+				continue
+			}
+			report.Applied++
+		}
+
+		if opts.DryRun || (opts.Mode == model.ImportAtomic && len(report.Errors) > 0) {
+			return errImportRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRollback) {
+		return nil, mapError(err)
+	}
+
+	report.Committed = err == nil
+	report.Failed = len(report.Errors)
+	if !report.Committed && !opts.DryRun {
+		// Ничего не сохранено: «применённые» строки откатились вместе с транзакцией.
+		report.Applied = 0
+	}
+	sort.SliceStable(report.Errors, func(i, j int) bool { return report.Errors[i].Row < report.Errors[j].Row })
+	if report.Committed {
+		audit(ctx, "import", "mode", report.Mode, "applied", report.Applied, "failed", report.Failed)
+	}
+	return report, nil
+}
+
+func kindOrder(kind model.ImportKind) int {
+	if order, ok := importOrder[kind]; ok {
+		return order
+	}
+	return len(importOrder)
+}
+
+// validateImportRecord проверяет запись без обращения к БД и дополняет значения по умолчанию.
+func validateImportRecord(rec *model.ImportRecord) error {
+	switch rec.Kind {
+	case model.ImportTeam:
+		return requireFields(map[string]string{"team_name": rec.TeamName})
+	case model.ImportUser:
+		if err := requireFields(map[string]string{
+			"user_id":   rec.UserID,
+			"username":  rec.Username,
+			"team_name": rec.TeamName,
+		}); err != nil {
+			return err
+		}
+		if rec.IsActive == nil {
+			return fmt.Errorf("%w: is_active is required", model.ErrBadRequest)
+		}
+		return nil
+	case model.ImportPR:
+		return validateImportPR(rec)
+	default:
+		return fmt.Errorf("%w: unknown kind %q", model.ErrBadRequest, rec.Kind)
+	}
+}
+
+func validateImportPR(rec *model.ImportRecord) error {
+	if err := requireFields(map[string]string{
+		"pull_request_id":   rec.PullRequestID,
+		"pull_request_name": rec.PullRequestName,
+		"author_id":         rec.AuthorID,
+	}); err != nil {
+		return err
+	}
+
+	switch rec.Status {
+	case "":
+		rec.Status = model.PROpen
+	case model.PROpen, model.PRMerged:
+	default:
+		return fmt.Errorf("%w: unknown status %q", model.ErrBadRequest, rec.Status)
+	}
+
+	if len(rec.AssignedReviewers) > 2 {
+		return fmt.Errorf("%w: at most 2 reviewers can be assigned", model.ErrBadRequest)
+	}
+	seen := make(map[string]struct{}, len(rec.AssignedReviewers))
+	for _, id := range rec.AssignedReviewers {
+		if strings.TrimSpace(id) == "" {
+			return fmt.Errorf("%w: empty reviewer id", model.ErrBadRequest)
+		}
+		if id == rec.AuthorID {
+			return fmt.Errorf("%w: author cannot be a reviewer", model.ErrBadRequest)
+		}
+		if _, dup := seen[id]; dup {
+			return fmt.Errorf("%w: duplicate reviewer %q", model.ErrBadRequest, id)
+		}
+		seen[id] = struct{}{}
+	}
+
+	switch {
+	case rec.Status == model.PROpen && rec.MergedAt != nil:
+		return fmt.Errorf("%w: merged_at is set for an OPEN pull request", model.ErrBadRequest)
+	case rec.Status == model.PRMerged && rec.MergedAt == nil:
+		merged := time.Now()
+		if rec.CreatedAt != nil {
+			merged = *rec.CreatedAt
+		}
+		rec.MergedAt = &merged
+	}
+	if rec.CreatedAt != nil && rec.MergedAt != nil && rec.MergedAt.Before(*rec.CreatedAt) {
+		return fmt.Errorf("%w: merged_at is before created_at", model.ErrBadRequest)
+	}
+	return nil
+}
+
+func requireFields(fields map[string]string) error {
+	var missing []string
+	for name, value := range fields {
+		if strings.TrimSpace(value) == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	return fmt.Errorf("%w: missing %s", model.ErrBadRequest, strings.Join(missing, ", "))
+}
+
+func applyImportRecord(ctx context.Context, tx repo.TxRepository, rec model.ImportRecord) error {
+	switch rec.Kind {
+	case model.ImportTeam:
+		return tx.EnsureTeam(ctx, rec.TeamName)
+
+	case model.ImportUser:
+		err := tx.UpsertUser(ctx, model.User{
+			UserID:   rec.UserID,
+			Username: rec.Username,
+			TeamName: rec.TeamName,
+			IsActive: *rec.IsActive,
+		})
+		if errors.Is(err, repo.ErrNotFound) {
+			return fmt.Errorf("team %q: %w", rec.TeamName, model.ErrNotFound)
+		}
+		return err
+
+	case model.ImportPR:
+		if _, err := tx.GetUserByID(ctx, rec.AuthorID); err != nil {
+			return fmt.Errorf("author %q: %w", rec.AuthorID, mapError(err))
+		}
+		for _, id := range rec.AssignedReviewers {
+			if _, err := tx.GetUserByID(ctx, id); err != nil {
+				return fmt.Errorf("reviewer %q: %w", id, mapError(err))
+			}
+		}
+		err := tx.CreatePR(ctx, &model.PullRequest{
+			ID:                rec.PullRequestID,
+			Name:              rec.PullRequestName,
+			AuthorID:          rec.AuthorID,
+			Status:            rec.Status,
+			AssignedReviewers: rec.AssignedReviewers,
+			CreatedAt:         rec.CreatedAt,
+			MergedAt:          rec.MergedAt,
+		})
+		if errors.Is(err, repo.ErrAlreadyExists) {
+			return model.ErrPRExists
+		}
+		return err
+	}
+	return nil
+}
+
+// importErrorMessage скрывает детали внутренних ошибок, оставляя доменные сообщения.
+func importErrorMessage(ctx context.Context, err error) string {
+	for _, domain := range []error{model.ErrBadRequest, model.ErrNotFound, model.ErrPRExists} {
+		if errors.Is(err, domain) {
+			return err.Error()
+		}
+	}
+	slog.ErrorContext(ctx, "Import row failed", "error", err)
+	return model.ErrInternal.Error()
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+
+	"github.com/trainee/review-service/internal/model"
+)
+
+type ifMatchKey struct{}
+
+// WithIfMatch возвращает копию ctx с версией ресурса, которую ожидает вызывающий
+// (If-Match). Мутация выполняется, только если текущая версия ресурса совпадает,
+// иначе возвращается model.ErrPreconditionFailed.
+func WithIfMatch(ctx context.Context, version int64) context.Context {
+	return context.WithValue(ctx, ifMatchKey{}, version)
+}
+
+// expectedVersion возвращает версию из If-Match или 0, если условие не задано.
+func expectedVersion(ctx context.Context) int64 {
+	v, _ := ctx.Value(ifMatchKey{}).(int64)
+	return v
+}
+
+// checkVersion сверяет текущую версию ресурса с If-Match.
+func checkVersion(ctx context.Context, current int64) error {
+	if expected := expectedVersion(ctx); expected != 0 && expected != current {
+		return model.ErrPreconditionFailed
+	}
+	return nil
+}
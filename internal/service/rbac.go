@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/model"
+)
+
+// access — права вызывающего.
+//
+//   - Без аутентификации (principal == nil) проверки не выполняются.
+//   - API токены — сервисные аккаунты: действуют в масштабе организации, ограничены
+//     только scopes на уровне транспорта; правами администратора обладают при scope admin.
+//   - Пользователи (JWT) получают права из ролей: org_admin — всё, team_lead — управление
+//     активностью и ревью своей команды, остальные — чтение и переназначение себя.
+type access struct {
+	principal *auth.Principal
+	orgAdmin  bool
+	leads     map[string]bool
+}
+
+func (s *Service) access(ctx context.Context) (*access, error) {
+	p := Actor(ctx)
+	a := &access{principal: p, leads: map[string]bool{}}
+	switch {
+	case p == nil:
+		a.orgAdmin = true
+	case p.Method == "api_token":
+		a.orgAdmin = p.HasScope(auth.ScopeAdmin)
+	default:
+		roles, err := s.repo.ListRoles(ctx, p.Subject)
+		if err != nil {
+			return nil, mapError(err)
+		}
+		for _, r := range roles {
+			switch r.Role {
+			case model.RoleOrgAdmin:
+				a.orgAdmin = true
+			case model.RoleTeamLead:
+				a.leads[r.TeamName] = true
+			}
+		}
+	}
+	return a, nil
+}
+
+// orgWide — права на операции в масштабе организации (создание команд, жизненный цикл PR).
+func (a *access) orgWide() bool {
+	return a.orgAdmin || a.principal.Method == "api_token"
+}
+
+// manageTeam — права на участников команды teamName.
+func (a *access) manageTeam(teamName string) bool {
+	return a.orgWide() || a.leads[teamName]
+}
+
+func (a *access) isSelf(userID string) bool {
+	return a.principal != nil && a.principal.Subject == userID
+}
+
+// deny логирует отказ и возвращает ErrForbidden с пояснением.
+func (a *access) deny(ctx context.Context, action string) error {
+	slog.WarnContext(ctx, "access denied", "action", action, "actor", a.principal.Subject)
+	return fmt.Errorf("%w: %s", model.ErrForbidden, action)
+}
+
+// requireOrgAdmin пропускает только администраторов организации.
+func (s *Service) requireOrgAdmin(ctx context.Context, action string) error {
+	a, err := s.access(ctx)
+	if err != nil {
+		return err
+	}
+	if !a.orgAdmin {
+		return a.deny(ctx, action)
+	}
+	return nil
+}
+
+// requireOrgWide пропускает администраторов и сервисные аккаунты.
+func (s *Service) requireOrgWide(ctx context.Context, action string) error {
+	a, err := s.access(ctx)
+	if err != nil {
+		return err
+	}
+	if !a.orgWide() {
+		return a.deny(ctx, action)
+	}
+	return nil
+}
+
+// --- Роли ---
+
+// ListRoles возвращает роли пользователя (или все, если userID пуст).
+func (s *Service) ListRoles(ctx context.Context, userID string) ([]model.RoleAssignment, error) {
+	ctx, span := startSpan(ctx, "ListRoles")
+	defer span.End()
+	roles, err := s.repo.ListRoles(ctx, userID)
+	if roles == nil {
+		roles = []model.RoleAssignment{}
+	}
+	return roles, mapError(err)
+}
+
+func (s *Service) AssignRole(ctx context.Context, role model.RoleAssignment) error {
+	ctx, span := startSpan(ctx, "AssignRole")
+	defer span.End()
+	if err := validateRole(role); err != nil {
+		return err
+	}
+	if err := s.requireOrgAdmin(ctx, "role.assign"); err != nil {
+		return err
+	}
+	if err := s.repo.AssignRole(ctx, role); err != nil {
+		return mapError(err)
+	}
+	audit(ctx, "role.assign", "user_id", role.UserID, "role", role.Role, "team_name", role.TeamName)
+	return nil
+}
+
+func (s *Service) RevokeRole(ctx context.Context, role model.RoleAssignment) error {
+	ctx, span := startSpan(ctx, "RevokeRole")
+	defer span.End()
+	if err := validateRole(role); err != nil {
+		return err
+	}
+	if err := s.requireOrgAdmin(ctx, "role.revoke"); err != nil {
+		return err
+	}
+	if err := s.repo.RevokeRole(ctx, role); err != nil {
+		return mapError(err)
+	}
+	audit(ctx, "role.revoke", "user_id", role.UserID, "role", role.Role, "team_name", role.TeamName)
+	return nil
+}
+
+func validateRole(role model.RoleAssignment) error {
+	if role.UserID == "" {
+		return model.ErrBadRequest
+	}
+	switch role.Role {
+	case model.RoleOrgAdmin:
+		if role.TeamName != "" {
+			return fmt.Errorf("%w: org_admin is not bound to a team", model.ErrBadRequest)
+		}
+	case model.RoleTeamLead:
+		if role.TeamName == "" {
+			return fmt.Errorf("%w: team_lead requires team_name", model.ErrBadRequest)
+		}
+	default:
+		return fmt.Errorf("%w: unknown role %q", model.ErrBadRequest, role.Role)
+	}
+	return nil
+}
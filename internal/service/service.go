@@ -6,16 +6,24 @@ import (
 	"math/rand"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/trainee/review-service/internal/events"
 	"github.com/trainee/review-service/internal/model"
 	repo "github.com/trainee/review-service/internal/repository"
 )
 
 type Service struct {
-	repo repo.Repository
+	repo   repo.Repository
+	events *events.Broker
 }
 
-func NewService(repository repo.Repository) *Service {
-	return &Service{repo: repository}
+func NewService(repository repo.Repository, opts ...Option) *Service {
+	s := &Service{repo: repository}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // mapError переводит ошибки репозитория в доменные ошибки.
@@ -33,6 +41,9 @@ func mapError(err error) error {
 	if errors.Is(err, repo.ErrNotFound) {
 		return model.ErrNotFound
 	}
+	if errors.Is(err, repo.ErrVersionConflict) {
+		return model.ErrPreconditionFailed
+	}
 	// ErrAlreadyExists обрабатывается в конкретных методах (CreateTeam, CreatePR)
 
 	// Все остальные ошибки считаются внутренними ошибками сервера
@@ -42,37 +53,225 @@ func mapError(err error) error {
 // --- Teams & Users ---
 
 func (s *Service) CreateTeam(ctx context.Context, team model.Team) error {
+	ctx, span := startSpan(ctx, "CreateTeam")
+	defer span.End()
+	if err := s.requireOrgWide(ctx, "team.create"); err != nil {
+		return err
+	}
 	err := s.repo.CreateTeamTx(ctx, team)
 	if errors.Is(err, repo.ErrAlreadyExists) {
 		return model.ErrTeamExists
 	}
-	return mapError(err)
+	if err != nil {
+		return mapError(err)
+	}
+	audit(ctx, "team.create", "team_name", team.TeamName, "members", len(team.Members))
+	return nil
 }
 
 func (s *Service) GetTeam(ctx context.Context, teamName string) (*model.Team, error) {
+	ctx, span := startSpan(ctx, "GetTeam", attribute.String("team_name", teamName))
+	defer span.End()
 	team, err := s.repo.GetTeam(ctx, teamName)
 	return team, mapError(err)
 }
 
 func (s *Service) SetUserActiveStatus(ctx context.Context, userID string, isActive bool) (*model.User, error) {
-	user, err := s.repo.SetUserActiveStatus(ctx, userID, isActive)
+	ctx, span := startSpan(ctx, "SetUserActiveStatus", attribute.String("user_id", userID))
+	defer span.End()
+	a, err := s.access(ctx)
+	if err != nil {
+		return nil, err
+	}
+	version := expectedVersion(ctx)
+	if !a.orgWide() {
+		// Тимлид управляет активностью только участников своей команды.
+		current, err := s.repo.GetUserByID(ctx, userID)
+		if err != nil {
+			return nil, mapError(err)
+		}
+		if !a.manageTeam(current.TeamName) {
+			return nil, a.deny(ctx, "user.set_active")
+		}
+		if err := checkVersion(ctx, current.Version); err != nil {
+			return nil, err
+		}
+		// Версия из первого чтения: если пользователя успели перевести в другую
+		// команду, изменение не выполнится.
+		version = current.Version
+	}
+
+	user, err := s.repo.SetUserActiveStatus(ctx, userID, isActive, version)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	audit(ctx, "user.set_active", "user_id", userID, "is_active", isActive)
+	s.publish(ctx, events.Event{
+		Type:     events.UserStatusChanged,
+		UserID:   user.UserID,
+		TeamName: user.TeamName,
+		IsActive: &user.IsActive,
+	})
+	return user, nil
+}
+
+// AddTeamMember добавляет пользователя в команду teamName: создаёт нового или переводит
+// существующего из другой команды. Тимлиду нужны права на обе команды.
+func (s *Service) AddTeamMember(ctx context.Context, teamName string, member model.TeamMember) (*model.User, error) {
+	ctx, span := startSpan(ctx, "AddTeamMember", attribute.String("team_name", teamName), attribute.String("user_id", member.UserID))
+	defer span.End()
+	a, err := s.access(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !a.manageTeam(teamName) {
+		return nil, a.deny(ctx, "team.add_member")
+	}
+
+	var user *model.User
+	err = s.repo.WithTransaction(ctx, func(tx repo.TxRepository) error {
+		current, err := tx.GetUserByID(ctx, member.UserID)
+		switch {
+		case errors.Is(err, repo.ErrNotFound):
+		case err != nil:
+			return err
+		default:
+			if !a.manageTeam(current.TeamName) {
+				return a.deny(ctx, "team.add_member")
+			}
+			if err := checkVersion(ctx, current.Version); err != nil {
+				return err
+			}
+		}
+		// Отсутствующая команда — нарушение внешнего ключа, т.е. ErrNotFound.
+		if err := tx.UpsertUser(ctx, model.User{UserID: member.UserID, Username: member.Username, TeamName: teamName, IsActive: member.IsActive}); err != nil {
+			return err
+		}
+		user, err = tx.GetUserByID(ctx, member.UserID)
+		return err
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	audit(ctx, "team.add_member", "team_name", teamName, "user_id", member.UserID)
+	return user, nil
+}
+
+// RemoveTeamMember удаляет участника команды. Пользователя, на которого ссылаются PR,
+// удалить нельзя (ErrUserInUse) — его следует деактивировать.
+func (s *Service) RemoveTeamMember(ctx context.Context, teamName, userID string) error {
+	ctx, span := startSpan(ctx, "RemoveTeamMember", attribute.String("team_name", teamName), attribute.String("user_id", userID))
+	defer span.End()
+	a, err := s.access(ctx)
+	if err != nil {
+		return err
+	}
+	if !a.manageTeam(teamName) {
+		return a.deny(ctx, "team.remove_member")
+	}
+
+	current, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return mapError(err)
+	}
+	if current.TeamName != teamName {
+		return model.ErrNotFound
+	}
+	if err := checkVersion(ctx, current.Version); err != nil {
+		return err
+	}
+	// Версия из первого чтения: если пользователя успели перевести, удаление не выполнится.
+	_, err = s.repo.DeleteUser(ctx, userID, current.Version)
+	if errors.Is(err, repo.ErrInUse) {
+		return model.ErrUserInUse
+	}
+	if err != nil {
+		return mapError(err)
+	}
+	audit(ctx, "team.remove_member", "team_name", teamName, "user_id", userID)
+	return nil
+}
+
+func (s *Service) GetUser(ctx context.Context, userID string) (*model.UserDetails, error) {
+	ctx, span := startSpan(ctx, "GetUser", attribute.String("user_id", userID))
+	defer span.End()
+	user, err := s.repo.GetUserDetails(ctx, userID)
 	return user, mapError(err)
 }
 
-func (s *Service) GetUserReviewPRs(ctx context.Context, userID string) ([]model.PullRequestShort, error) {
-	prs, err := s.repo.GetPRsByReviewer(ctx, userID)
-	return prs, mapError(err)
+// ListUsers возвращает страницу справочника пользователей, упорядоченную по user_id.
+func (s *Service) ListUsers(ctx context.Context, filter model.UserFilter, page model.PageRequest) (*model.UserPage, error) {
+	ctx, span := startSpan(ctx, "ListUsers")
+	defer span.End()
+	key, err := decodeCursor(page.Cursor, userCursorKind, 1)
+	if err != nil {
+		return nil, err
+	}
+	after := ""
+	if key != nil {
+		after = key[0]
+	}
+
+	limit := normalizeLimit(page.Limit)
+	// Запрашиваем на один элемент больше, чтобы понять, есть ли следующая страница.
+	users, err := s.repo.ListUsers(ctx, filter, after, limit+1)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	result := &model.UserPage{Users: users}
+	if len(users) > limit {
+		result.Users = users[:limit]
+		result.NextCursor = encodeCursor(userCursorKind, users[limit-1].UserID)
+	}
+	if result.Users == nil {
+		result.Users = []model.UserDetails{}
+	}
+	return result, nil
+}
+
+// GetUserReviewPRs возвращает страницу PR, где пользователь назначен ревьювером (от новых к старым).
+func (s *Service) GetUserReviewPRs(ctx context.Context, filter model.ReviewFilter, page model.PageRequest) (*model.ReviewPage, error) {
+	ctx, span := startSpan(ctx, "GetUserReviewPRs")
+	defer span.End()
+	after, err := decodePRCursor(page.Cursor, reviewCursorKind)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := normalizeLimit(page.Limit)
+	prs, err := s.repo.GetPRsByReviewer(ctx, filter, after, limit+1)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	result := &model.ReviewPage{PullRequests: prs}
+	if len(prs) > limit {
+		last := prs[limit-1]
+		result.PullRequests = prs[:limit]
+		result.NextCursor = encodePRCursor(reviewCursorKind, last.CreatedAt, last.ID)
+	}
+	if result.PullRequests == nil {
+		result.PullRequests = []model.PullRequestShort{}
+	}
+	return result, nil
 }
 
 // --- Pull Requests ---
 
 func (s *Service) CreatePullRequest(ctx context.Context, prID, prName, authorID string) (*model.PullRequest, error) {
+	ctx, span := startSpan(ctx, "CreatePullRequest", attribute.String("pull_request_id", prID))
+	defer span.End()
+	if err := s.requireOrgWide(ctx, "pull_request.create"); err != nil {
+		return nil, err
+	}
 	pr := &model.PullRequest{
 		ID:       prID,
 		Name:     prName,
 		AuthorID: authorID,
 		Status:   model.PROpen,
 	}
+	var teamName string
 
 	err := s.repo.WithTransaction(ctx, func(tx repo.TxRepository) error {
 		author, err := tx.GetUserByID(ctx, authorID)
@@ -80,6 +279,7 @@ func (s *Service) CreatePullRequest(ctx context.Context, prID, prName, authorID
 			return err
 		}
 
+		teamName = author.TeamName
 		teamMembers, err := tx.ListTeamMembers(ctx, author.TeamName)
 		if err != nil {
 			return err
@@ -98,21 +298,75 @@ func (s *Service) CreatePullRequest(ctx context.Context, prID, prName, authorID
 		return nil, mapError(err)
 	}
 
+	audit(ctx, "pull_request.create", "pull_request_id", pr.ID, "reviewers", pr.AssignedReviewers)
+	s.publish(ctx, events.Event{
+		Type:          events.PRAssigned,
+		PullRequestID: pr.ID,
+		AuthorID:      pr.AuthorID,
+		TeamName:      teamName,
+		Reviewers:     pr.AssignedReviewers,
+	})
 	return pr, nil
 }
 
+func (s *Service) GetPullRequest(ctx context.Context, prID string) (*model.PullRequest, error) {
+	ctx, span := startSpan(ctx, "GetPullRequest", attribute.String("pull_request_id", prID))
+	defer span.End()
+	pr, err := s.repo.GetPRByID(ctx, prID)
+	return pr, mapError(err)
+}
+
+// ListPullRequests ищет PR по фильтру; страницы упорядочены от новых к старым.
+func (s *Service) ListPullRequests(ctx context.Context, filter model.PRFilter, page model.PageRequest) (*model.PullRequestPage, error) {
+	ctx, span := startSpan(ctx, "ListPullRequests")
+	defer span.End()
+	after, err := decodePRCursor(page.Cursor, prCursorKind)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := normalizeLimit(page.Limit)
+	prs, err := s.repo.ListPRs(ctx, filter, after, limit+1)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	result := &model.PullRequestPage{PullRequests: prs}
+	if len(prs) > limit {
+		last := prs[limit-1]
+		result.PullRequests = prs[:limit]
+		result.NextCursor = encodePRCursor(prCursorKind, last.CreatedAt, last.ID)
+	}
+	if result.PullRequests == nil {
+		result.PullRequests = []model.PullRequest{}
+	}
+	return result, nil
+}
+
 func (s *Service) MergePullRequest(ctx context.Context, prID string) (*model.PullRequest, error) {
-	var result *model.PullRequest
+	ctx, span := startSpan(ctx, "MergePullRequest", attribute.String("pull_request_id", prID))
+	defer span.End()
+	if err := s.requireOrgWide(ctx, "pull_request.merge"); err != nil {
+		return nil, err
+	}
+	var (
+		result *model.PullRequest
+		merged bool
+	)
 
 	err := s.repo.WithTransaction(ctx, func(tx repo.TxRepository) error {
 		current, err := tx.GetPRByIDForUpdate(ctx, prID)
 		if err != nil {
 			return err
 		}
+		if err := checkVersion(ctx, current.Version); err != nil {
+			return err
+		}
 		if current.Status == model.PRMerged {
 			result = current
 			return nil
 		}
+		merged = true
 
 		now := time.Now()
 		current.Status = model.PRMerged
@@ -121,21 +375,44 @@ func (s *Service) MergePullRequest(ctx context.Context, prID string) (*model.Pul
 		result, err = tx.UpdatePR(ctx, current)
 		return err
 	})
+	if err != nil {
+		return nil, mapError(err)
+	}
 
-	return result, mapError(err)
+	audit(ctx, "pull_request.merge", "pull_request_id", prID)
+	if merged {
+		s.publish(ctx, events.Event{
+			Type:          events.PRMerged,
+			PullRequestID: result.ID,
+			AuthorID:      result.AuthorID,
+			TeamName:      s.teamOf(ctx, result.AuthorID),
+			Reviewers:     result.AssignedReviewers,
+		})
+	}
+	return result, nil
 }
 
 func (s *Service) ReassignReviewer(ctx context.Context, prID, oldUserID string) (*model.PullRequest, string, error) {
+	ctx, span := startSpan(ctx, "ReassignReviewer", attribute.String("pull_request_id", prID), attribute.String("old_reviewer_id", oldUserID))
+	defer span.End()
+	a, err := s.access(ctx)
+	if err != nil {
+		return nil, "", err
+	}
 	var (
 		updated    *model.PullRequest
 		replacedBy string
+		teamName   string
 	)
 
-	err := s.repo.WithTransaction(ctx, func(tx repo.TxRepository) error {
+	err = s.repo.WithTransaction(ctx, func(tx repo.TxRepository) error {
 		current, err := tx.GetPRByIDForUpdate(ctx, prID)
 		if err != nil {
 			return err
 		}
+		if err := checkVersion(ctx, current.Version); err != nil {
+			return err
+		}
 		if current.Status == model.PRMerged {
 			return model.ErrPRMerged
 		}
@@ -144,6 +421,10 @@ func (s *Service) ReassignReviewer(ctx context.Context, prID, oldUserID string)
 		if err != nil {
 			return err
 		}
+		// Пользователь может снять с ревью только себя, тимлид — участников своей команды.
+		if !a.isSelf(oldUserID) && !a.manageTeam(oldUser.TeamName) {
+			return a.deny(ctx, "pull_request.reassign")
+		}
 
 		if !isReviewerAssigned(current.AssignedReviewers, oldUserID) {
 			return model.ErrNotAssigned
@@ -158,6 +439,7 @@ func (s *Service) ReassignReviewer(ctx context.Context, prID, oldUserID string)
 			return model.ErrNoCandidate
 		}
 		replacedBy = reviewers[0]
+		teamName = oldUser.TeamName
 
 		for i, r := range current.AssignedReviewers {
 			if r == oldUserID {
@@ -169,8 +451,21 @@ func (s *Service) ReassignReviewer(ctx context.Context, prID, oldUserID string)
 		updated, err = tx.UpdatePR(ctx, current)
 		return err
 	})
+	if err != nil {
+		return nil, "", mapError(err)
+	}
 
-	return updated, replacedBy, mapError(err)
+	audit(ctx, "pull_request.reassign", "pull_request_id", prID, "old_reviewer_id", oldUserID, "new_reviewer_id", replacedBy)
+	s.publish(ctx, events.Event{
+		Type:          events.PRReassigned,
+		PullRequestID: updated.ID,
+		AuthorID:      updated.AuthorID,
+		TeamName:      teamName,
+		Reviewers:     updated.AssignedReviewers,
+		OldReviewerID: oldUserID,
+		NewReviewerID: replacedBy,
+	})
+	return updated, replacedBy, nil
 }
 
 // activeReviewers фильтрует активных участников команды, исключая автора и уже назначенных.
@@ -1,180 +1,41 @@
 package service
 
 import (
+	"bytes"
 	"context"
-	"sort"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
 	"strconv"
-	"sync"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/events"
 	"github.com/trainee/review-service/internal/model"
 	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/repository/memory"
+	"github.com/trainee/review-service/internal/tenant"
 )
 
-// in-memory fake, детерминированные результаты для тестов сервиса.
-type fakeRepo struct {
-	mu    sync.Mutex
-	teams map[string]model.Team
-	users map[string]model.User
-	prs   map[string]*model.PullRequest
-}
-
-func newFakeRepo() *fakeRepo {
-	return &fakeRepo{
-		teams: make(map[string]model.Team),
-		users: make(map[string]model.User),
-		prs:   make(map[string]*model.PullRequest),
-	}
-}
-
-func (f *fakeRepo) WithTransaction(_ context.Context, fn func(repo.TxRepository) error) error {
-	return fn(f)
-}
-
-func (f *fakeRepo) CreateTeamTx(_ context.Context, team model.Team) error {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	if _, ok := f.teams[team.TeamName]; ok {
-		return repo.ErrAlreadyExists
-	}
-	f.teams[team.TeamName] = team
-	for _, m := range team.Members {
-		f.users[m.UserID] = model.User{
-			UserID:   m.UserID,
-			Username: m.Username,
-			TeamName: team.TeamName,
-			IsActive: m.IsActive,
-		}
-	}
-	return nil
-}
-
-func (f *fakeRepo) GetTeam(_ context.Context, teamName string) (*model.Team, error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	tm, ok := f.teams[teamName]
-	if !ok {
-		return nil, repo.ErrNotFound
-	}
-	return &tm, nil
-}
-
-func (f *fakeRepo) SetUserActiveStatus(_ context.Context, userID string, isActive bool) (*model.User, error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	u, ok := f.users[userID]
-	if !ok {
-		return nil, repo.ErrNotFound
-	}
-	u.IsActive = isActive
-	f.users[userID] = u
-	return &u, nil
-}
-
-func (f *fakeRepo) GetUserByID(_ context.Context, userID string) (*model.User, error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	u, ok := f.users[userID]
-	if !ok {
-		return nil, repo.ErrNotFound
-	}
-	copy := u
-	return &copy, nil
-}
-
-func (f *fakeRepo) ListTeamMembers(_ context.Context, teamName string) ([]model.User, error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	var members []model.User
-	for _, u := range f.users {
-		if u.TeamName == teamName {
-			members = append(members, u)
-		}
-	}
-	return members, nil
-}
-
-func (f *fakeRepo) CreatePR(_ context.Context, pr *model.PullRequest) error {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	if _, ok := f.prs[pr.ID]; ok {
-		return repo.ErrAlreadyExists
-	}
-	_, ok := f.users[pr.AuthorID]
-	if !ok {
-		return repo.ErrNotFound
-	}
-	now := time.Now()
-	pr.CreatedAt = &now
-	pr.Status = model.PROpen
-	f.prs[pr.ID] = pr
-	return nil
-}
-
-func (f *fakeRepo) GetPRByID(_ context.Context, prID string) (*model.PullRequest, error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	pr, ok := f.prs[prID]
-	if !ok {
-		return nil, repo.ErrNotFound
-	}
-	cp := *pr
-	return &cp, nil
-}
-
-func (f *fakeRepo) GetPRByIDForUpdate(ctx context.Context, prID string) (*model.PullRequest, error) {
-	return f.GetPRByID(ctx, prID)
-}
-
-func (f *fakeRepo) UpdatePR(_ context.Context, pr *model.PullRequest) (*model.PullRequest, error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	current, ok := f.prs[pr.ID]
-	if !ok {
-		return nil, repo.ErrNotFound
-	}
-	current.Status = pr.Status
-	current.AssignedReviewers = append([]string(nil), pr.AssignedReviewers...)
-	current.MergedAt = pr.MergedAt
-	cp := *current
-	return &cp, nil
-}
-
-func (f *fakeRepo) GetPRsByReviewer(_ context.Context, userID string) ([]model.PullRequestShort, error) {
-	f.mu.Lock()
-	defer f.mu.Unlock()
-	var prs []model.PullRequestShort
-	for _, pr := range f.prs {
-		for _, r := range pr.AssignedReviewers {
-			if r == userID {
-				prs = append(prs, model.PullRequestShort{
-					ID:       pr.ID,
-					Name:     pr.Name,
-					AuthorID: pr.AuthorID,
-					Status:   pr.Status,
-				})
-				break
-			}
-		}
-	}
-	sort.Slice(prs, func(i, j int) bool {
-		return prs[i].ID < prs[j].ID
-	})
-	return prs, nil
-}
-
 // --- Тесты сервиса ---
 
-func prepareService() (*Service, *fakeRepo) {
-	f := newFakeRepo()
+func prepareService(opts ...memory.Option) (*Service, *memory.Repository) {
+	f := memory.New(opts...)
 	s := NewService(f)
 	return s, f
 }
 
-func seedTeam(f *fakeRepo, name string, active bool, ids ...string) {
+func seedTeam(f *memory.Repository, name string, active bool, ids ...string) {
+	seedOrgTeam(context.Background(), f, name, active, ids...)
+}
+
+// seedOrgTeam создаёт команду в организации из ctx.
+func seedOrgTeam(ctx context.Context, f *memory.Repository, name string, active bool, ids ...string) {
 	members := make([]model.TeamMember, 0, len(ids))
 	for i, id := range ids {
 		members = append(members, model.TeamMember{
@@ -183,7 +44,7 @@ func seedTeam(f *fakeRepo, name string, active bool, ids ...string) {
 			IsActive: active,
 		})
 	}
-	_ = f.CreateTeamTx(context.Background(), model.Team{
+	_ = f.CreateTeamTx(ctx, model.Team{
 		TeamName: name,
 		Members:  members,
 	})
@@ -267,9 +128,10 @@ func TestReassignValidation(t *testing.T) {
 
 func TestGetPRsByReviewerNotFound(t *testing.T) {
 	svc, _ := prepareService()
-	prs, err := svc.GetUserReviewPRs(context.Background(), "missing")
+	page, err := svc.GetUserReviewPRs(context.Background(), model.ReviewFilter{UserID: "missing"}, model.PageRequest{})
 	require.NoError(t, err)
-	require.Empty(t, prs)
+	require.Empty(t, page.PullRequests)
+	require.Empty(t, page.NextCursor)
 }
 
 func TestSetUserActiveStatusNotFound(t *testing.T) {
@@ -292,3 +154,525 @@ func TestMergePRNotFound(t *testing.T) {
 	_, err := svc.MergePullRequest(context.Background(), "absent")
 	require.ErrorIs(t, err, model.ErrNotFound)
 }
+
+func TestIfMatchPreconditions(t *testing.T) {
+	svc, f := prepareService()
+	seedTeam(f, "backend", true, "u1", "u2", "u3", "u4")
+	ctx := context.Background()
+	pr, err := svc.CreatePullRequest(ctx, "pr1", "feat", "u1")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), pr.Version)
+
+	reassigned, _, err := svc.ReassignReviewer(WithIfMatch(ctx, 1), "pr1", pr.AssignedReviewers[0])
+	require.NoError(t, err)
+	require.Equal(t, int64(2), reassigned.Version)
+
+	// Клиент, видевший версию 1, не перезаписывает чужое переназначение.
+	_, err = svc.MergePullRequest(WithIfMatch(ctx, 1), "pr1")
+	require.ErrorIs(t, err, model.ErrPreconditionFailed)
+	current, err := f.GetPRByID(ctx, "pr1")
+	require.NoError(t, err)
+	require.Equal(t, model.PROpen, current.Status)
+
+	merged, err := svc.MergePullRequest(WithIfMatch(ctx, 2), "pr1")
+	require.NoError(t, err)
+	require.Equal(t, int64(3), merged.Version)
+
+	// Версия пользователя проверяется репозиторием.
+	_, err = svc.SetUserActiveStatus(WithIfMatch(ctx, 42), "u4", false)
+	require.ErrorIs(t, err, model.ErrPreconditionFailed)
+}
+
+func TestAssignmentEvents(t *testing.T) {
+	f := memory.New()
+	broker := events.NewBroker()
+	svc := NewService(f, WithEvents(broker))
+	ctx := tenant.NewContext(context.Background(), "acme")
+	seedOrgTeam(ctx, f, "backend", true, "u1", "u2", "u3", "u4")
+	sub := broker.Subscribe(ctx, "acme", 4)
+
+	pr, err := svc.CreatePullRequest(ctx, "pr1", "feat", "u1")
+	require.NoError(t, err)
+	assigned := <-sub
+	require.Equal(t, events.PRAssigned, assigned.Type)
+	require.Equal(t, "acme", assigned.Org)
+	require.Equal(t, "backend", assigned.TeamName)
+	require.ElementsMatch(t, pr.AssignedReviewers, assigned.Reviewers)
+
+	old := pr.AssignedReviewers[0]
+	_, newReviewer, err := svc.ReassignReviewer(ctx, "pr1", old)
+	require.NoError(t, err)
+	reassigned := <-sub
+	require.Equal(t, events.PRReassigned, reassigned.Type)
+	require.Equal(t, old, reassigned.OldReviewerID)
+	require.Equal(t, newReviewer, reassigned.NewReviewerID)
+
+	// Неудачные операции событий не публикуют.
+	_, _, err = svc.ReassignReviewer(ctx, "pr1", "u1")
+	require.ErrorIs(t, err, model.ErrNotAssigned)
+	require.Empty(t, sub)
+}
+
+func TestListUsersPagination(t *testing.T) {
+	svc, f := prepareService()
+	seedTeam(f, "backend", true, "u1", "u2", "u3")
+	seedTeam(f, "frontend", false, "u4")
+	_, err := svc.CreatePullRequest(context.Background(), "pr1", "feat", "u1")
+	require.NoError(t, err)
+
+	page, err := svc.ListUsers(context.Background(), model.UserFilter{}, model.PageRequest{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Users, 2)
+	require.Equal(t, "u1", page.Users[0].UserID)
+	require.Equal(t, 1, page.Users[1].OpenReviewCount)
+	require.NotEmpty(t, page.NextCursor)
+
+	page, err = svc.ListUsers(context.Background(), model.UserFilter{}, model.PageRequest{Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, page.Users, 2)
+	require.Equal(t, "u3", page.Users[0].UserID)
+	require.Empty(t, page.NextCursor)
+
+	inactive := false
+	page, err = svc.ListUsers(context.Background(), model.UserFilter{IsActive: &inactive}, model.PageRequest{})
+	require.NoError(t, err)
+	require.Len(t, page.Users, 1)
+	require.Equal(t, "frontend", page.Users[0].TeamName)
+
+	_, err = svc.ListUsers(context.Background(), model.UserFilter{}, model.PageRequest{Cursor: "garbage"})
+	require.ErrorIs(t, err, model.ErrBadRequest)
+}
+
+func TestGetUserNotFound(t *testing.T) {
+	svc, _ := prepareService()
+	_, err := svc.GetUser(context.Background(), "ghost")
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestListPullRequestsKeyset(t *testing.T) {
+	now := time.Now()
+	svc, f := prepareService(memory.WithClock(func() time.Time { return now }))
+	seedTeam(f, "backend", true, "u1", "u2", "u3")
+	seedTeam(f, "frontend", true, "u4", "u5")
+	ctx := context.Background()
+	for _, id := range []string{"pr1", "pr2", "pr3"} {
+		_, err := svc.CreatePullRequest(ctx, id, "feat "+id, "u1")
+		require.NoError(t, err)
+		// Одинаковый created_at у pr2 и pr3 не должен ломать порядок страниц.
+		if id == "pr1" {
+			now = now.Add(time.Second)
+		}
+	}
+	_, err := svc.CreatePullRequest(ctx, "pr4", "fix", "u4")
+	require.NoError(t, err)
+
+	page, err := svc.ListPullRequests(ctx, model.PRFilter{TeamName: "backend"}, model.PageRequest{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.PullRequests, 2)
+	require.Equal(t, "pr3", page.PullRequests[0].ID)
+	require.Equal(t, "pr2", page.PullRequests[1].ID)
+	require.NotEmpty(t, page.NextCursor)
+
+	page, err = svc.ListPullRequests(ctx, model.PRFilter{TeamName: "backend"}, model.PageRequest{Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, page.PullRequests, 1)
+	require.Equal(t, "pr1", page.PullRequests[0].ID)
+	require.Empty(t, page.NextCursor)
+
+	_, err = svc.MergePullRequest(ctx, "pr4")
+	require.NoError(t, err)
+	page, err = svc.ListPullRequests(ctx, model.PRFilter{Status: model.PRMerged}, model.PageRequest{})
+	require.NoError(t, err)
+	require.Len(t, page.PullRequests, 1)
+	require.Equal(t, "pr4", page.PullRequests[0].ID)
+
+	page, err = svc.ListPullRequests(ctx, model.PRFilter{NameContains: "FEAT"}, model.PageRequest{})
+	require.NoError(t, err)
+	require.Len(t, page.PullRequests, 3)
+
+	// Курсор другого списка отклоняется.
+	users, err := svc.ListUsers(ctx, model.UserFilter{}, model.PageRequest{Limit: 1})
+	require.NoError(t, err)
+	_, err = svc.ListPullRequests(ctx, model.PRFilter{}, model.PageRequest{Cursor: users.NextCursor})
+	require.ErrorIs(t, err, model.ErrBadRequest)
+}
+
+func TestGetUserReviewPRsPagination(t *testing.T) {
+	// Все PR созданы «одновременно»: порядок задаёт pull_request_id.
+	now := time.Now()
+	svc, f := prepareService(memory.WithClock(func() time.Time { return now }))
+	seedTeam(f, "backend", true, "u1", "u2")
+	ctx := context.Background()
+	for _, id := range []string{"pr1", "pr2", "pr3"} {
+		_, err := svc.CreatePullRequest(ctx, id, "feat", "u1")
+		require.NoError(t, err)
+	}
+	_, err := svc.MergePullRequest(ctx, "pr2")
+	require.NoError(t, err)
+
+	open := model.ReviewFilter{UserID: "u2", Status: model.PROpen}
+	page, err := svc.GetUserReviewPRs(ctx, open, model.PageRequest{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, page.PullRequests, 1)
+	require.Equal(t, "pr3", page.PullRequests[0].ID)
+
+	page, err = svc.GetUserReviewPRs(ctx, open, model.PageRequest{Limit: 1, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, page.PullRequests, 1)
+	require.Equal(t, "pr1", page.PullRequests[0].ID)
+	require.Empty(t, page.NextCursor)
+
+	page, err = svc.GetUserReviewPRs(ctx, model.ReviewFilter{UserID: "u2"}, model.PageRequest{})
+	require.NoError(t, err)
+	require.Len(t, page.PullRequests, 3)
+}
+
+func importBatch() model.ImportBatch {
+	active := true
+	created := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	return model.ImportBatch{
+		Records: []model.ImportRecord{
+			// Порядок строк не важен: команды и пользователи применяются раньше PR.
+			{Row: 1, Kind: model.ImportPR, PullRequestID: "pr1", PullRequestName: "feat", AuthorID: "u1",
+				Status: model.PRMerged, AssignedReviewers: []string{"u2"}, CreatedAt: &created},
+			{Row: 2, Kind: model.ImportTeam, TeamName: "payments"},
+			{Row: 3, Kind: model.ImportUser, UserID: "u1", Username: "alice", TeamName: "payments", IsActive: &active},
+			{Row: 4, Kind: model.ImportUser, UserID: "u2", Username: "bob", TeamName: "payments", IsActive: &active},
+			{Row: 5, Kind: model.ImportUser, UserID: "u3", Username: "carol", TeamName: "ghosts", IsActive: &active},
+			{Row: 6, Kind: model.ImportPR, PullRequestID: "pr2", PullRequestName: "fix", AuthorID: "u1", AssignedReviewers: []string{"u1"}},
+		},
+		Rejected: []model.ImportRowError{{Row: 7, Message: "invalid JSON"}},
+	}
+}
+
+func TestImportBestEffort(t *testing.T) {
+	svc, f := prepareService()
+
+	report, err := svc.Import(context.Background(), importBatch(), model.ImportOptions{Mode: model.ImportBestEffort})
+	require.NoError(t, err)
+	require.True(t, report.Committed)
+	require.Equal(t, 7, report.Total)
+	require.Equal(t, 4, report.Applied)
+	require.Equal(t, 3, report.Failed)
+	require.Equal(t, []int{5, 6, 7}, []int{report.Errors[0].Row, report.Errors[1].Row, report.Errors[2].Row})
+	require.Equal(t, "u3", report.Errors[0].Key)
+	require.Contains(t, report.Errors[0].Message, "ghosts")
+
+	pr, err := f.GetPRByID(context.Background(), "pr1")
+	require.NoError(t, err)
+	require.Equal(t, model.PRMerged, pr.Status)
+	require.Equal(t, *pr.CreatedAt, *pr.MergedAt)
+	require.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+}
+
+func TestImportAtomicAndDryRun(t *testing.T) {
+	svc, _ := prepareService()
+
+	report, err := svc.Import(context.Background(), importBatch(), model.ImportOptions{})
+	require.NoError(t, err)
+	require.Equal(t, model.ImportAtomic, report.Mode)
+	require.False(t, report.Committed)
+	require.Zero(t, report.Applied)
+	require.Equal(t, 3, report.Failed)
+
+	svc, _ = prepareService()
+	batch := importBatch()
+	batch.Records = batch.Records[:4]
+	batch.Rejected = nil
+	report, err = svc.Import(context.Background(), batch, model.ImportOptions{Mode: model.ImportAtomic, DryRun: true})
+	require.NoError(t, err)
+	require.False(t, report.Committed)
+	require.Equal(t, 4, report.Applied)
+	require.Empty(t, report.Errors)
+
+	_, err = svc.Import(context.Background(), batch, model.ImportOptions{Mode: "sometimes"})
+	require.ErrorIs(t, err, model.ErrBadRequest)
+}
+
+func TestExportRestoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	src, f := prepareService()
+	seedTeam(f, "backend", true, "u1", "u2", "u3")
+	seedTeam(f, "empty", true)
+	_, err := src.CreatePullRequest(ctx, "pr1", "feature", "u1")
+	require.NoError(t, err)
+	_, err = src.MergePullRequest(ctx, "pr1")
+	require.NoError(t, err)
+	_, err = src.CreatePullRequest(ctx, "pr2", "bugfix", "u2")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, src.Export(ctx, &buf))
+
+	dst, g := prepareService()
+	report, err := dst.Restore(ctx, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, &model.RestoreReport{Version: 1, Teams: 2, Users: 3, PullRequests: 2}, report)
+	_, err = g.GetTeam(ctx, "empty")
+	require.NoError(t, err)
+	for _, id := range []string{"pr1", "pr2"} {
+		pr, err := f.GetPRByID(ctx, id)
+		require.NoError(t, err)
+		restored, err := dst.GetPullRequest(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, pr.Status, restored.Status)
+		require.ElementsMatch(t, pr.AssignedReviewers, restored.AssignedReviewers)
+		require.True(t, pr.CreatedAt.Equal(*restored.CreatedAt))
+	}
+
+	// Повторное восстановление в непустую БД запрещено.
+	_, err = dst.Restore(ctx, bytes.NewReader(buf.Bytes()))
+	require.ErrorIs(t, err, model.ErrNotEmpty)
+}
+
+func TestRestoreIntegrityChecks(t *testing.T) {
+	ctx := context.Background()
+	src, f := prepareService()
+	seedTeam(f, "backend", true, "u1", "u2")
+	_, err := src.CreatePullRequest(ctx, "pr1", "feature", "u1")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, src.Export(ctx, &buf))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+	cases := map[string]struct {
+		data    string
+		message string
+	}{
+		"truncated": {strings.Join(lines[:len(lines)-1], "\n"), "footer is missing"},
+		"tampered":  {strings.Replace(buf.String(), `"feature"`, `"changed"`, 1), "checksum mismatch"},
+		// Пользователь ссылается на команду, которой нет в архиве.
+		"dangling": {strings.Join(append([]string{lines[0]}, lines[2:]...), "\n"), `unknown team "backend"`},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			dst, _ := prepareService()
+			_, err := dst.Restore(ctx, strings.NewReader(tc.data))
+			require.ErrorIs(t, err, model.ErrBadRequest)
+			require.ErrorContains(t, err, tc.message)
+		})
+	}
+}
+
+// failingUpsertRepo возвращает err из UpsertUser внутри транзакции.
+type failingUpsertRepo struct {
+	*memory.Repository
+	err error
+}
+
+type failingUpsertTx struct {
+	repo.TxRepository
+	err error
+}
+
+func (f failingUpsertTx) UpsertUser(context.Context, model.User) error { return f.err }
+
+func (f failingUpsertRepo) WithTransaction(ctx context.Context, fn func(repo.TxRepository) error) error {
+	return f.Repository.WithTransaction(ctx, func(tx repo.TxRepository) error {
+		return fn(failingUpsertTx{tx, f.err})
+	})
+}
+
+func TestRestoreRepositoryErrors(t *testing.T) {
+	ctx := context.Background()
+	src, f := prepareService()
+	seedTeam(f, "backend", true, "u1")
+	var buf bytes.Buffer
+	require.NoError(t, src.Export(ctx, &buf))
+
+	// Ошибки хранилища не выдаются за ошибки архива.
+	dbErr := errors.New("connection reset")
+	dst := NewService(failingUpsertRepo{memory.New(), dbErr})
+	_, err := dst.Restore(ctx, bytes.NewReader(buf.Bytes()))
+	require.ErrorIs(t, err, dbErr)
+	require.NotErrorIs(t, err, model.ErrBadRequest)
+	require.ErrorContains(t, err, "line 3")
+
+	dst = NewService(failingUpsertRepo{memory.New(), repo.ErrNotFound})
+	_, err = dst.Restore(ctx, bytes.NewReader(buf.Bytes()))
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestMutationsAreAuditedWithActor(t *testing.T) {
+	var logs bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	defer slog.SetDefault(prev)
+
+	svc, f := prepareService()
+	seedTeam(f, "backend", true, "u1", "u2")
+	require.NoError(t, f.AssignRole(context.Background(), model.RoleAssignment{UserID: "u1", Role: model.RoleOrgAdmin}))
+	ctx := auth.NewContext(context.Background(), &auth.Principal{Subject: "u1", Method: "jwt"})
+	require.Equal(t, "u1", Actor(ctx).Subject)
+	require.Nil(t, Actor(context.Background()))
+
+	_, err := svc.CreatePullRequest(ctx, "pr1", "feat", "u1")
+	require.NoError(t, err)
+	_, err = svc.MergePullRequest(context.Background(), "pr1")
+	require.NoError(t, err)
+	// Неуспешные операции не попадают в аудит.
+	_, err = svc.MergePullRequest(ctx, "missing")
+	require.Error(t, err)
+
+	var entries []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	require.Len(t, entries, 2)
+	require.Equal(t, "pull_request.create", entries[0]["action"])
+	require.Equal(t, "u1", entries[0]["actor"])
+	require.Equal(t, "jwt", entries[0]["auth_method"])
+	require.Equal(t, tenant.Default, entries[0]["org"])
+	require.Equal(t, "anonymous", entries[1]["actor"])
+}
+
+func asUser(userID string) context.Context {
+	return auth.NewContext(context.Background(), &auth.Principal{Subject: userID, Method: "jwt"})
+}
+
+func TestRBAC(t *testing.T) {
+	svc, f := prepareService()
+	seedTeam(f, "backend", true, "lead", "u1", "u2", "u3")
+	seedTeam(f, "frontend", true, "f1", "f2")
+	require.NoError(t, svc.AssignRole(context.Background(), model.RoleAssignment{UserID: "boss", Role: model.RoleOrgAdmin}))
+	require.NoError(t, svc.AssignRole(context.Background(), model.RoleAssignment{UserID: "lead", Role: model.RoleTeamLead, TeamName: "backend"}))
+
+	boss, lead, regular := asUser("boss"), asUser("lead"), asUser("u1")
+
+	// Создание команд и PR — только администратор (или сервисный аккаунт).
+	require.ErrorIs(t, svc.CreateTeam(lead, model.Team{TeamName: "new"}), model.ErrForbidden)
+	require.NoError(t, svc.CreateTeam(boss, model.Team{TeamName: "new"}))
+	_, err := svc.CreatePullRequest(regular, "pr1", "feat", "u1")
+	require.ErrorIs(t, err, model.ErrForbidden)
+	service := auth.NewContext(context.Background(), &auth.Principal{Subject: "tok_ci", Method: "api_token", Scopes: []string{auth.ScopePRsWrite}})
+	pr, err := svc.CreatePullRequest(service, "pr1", "feat", "u1")
+	require.NoError(t, err)
+	_, err = svc.MergePullRequest(regular, "pr1")
+	require.ErrorIs(t, err, model.ErrForbidden)
+
+	// Тимлид управляет активностью только своей команды.
+	_, err = svc.SetUserActiveStatus(lead, "u3", false)
+	require.NoError(t, err)
+	_, err = svc.SetUserActiveStatus(lead, "f1", false)
+	require.ErrorIs(t, err, model.ErrForbidden)
+	_, err = svc.SetUserActiveStatus(regular, "u1", false)
+	require.ErrorIs(t, err, model.ErrForbidden)
+	_, err = svc.SetUserActiveStatus(boss, "f1", true)
+	require.NoError(t, err)
+
+	// Обычный пользователь переназначает только себя.
+	reviewer := pr.AssignedReviewers[0]
+	_, _, err = svc.ReassignReviewer(asUser("f2"), "pr1", reviewer)
+	require.ErrorIs(t, err, model.ErrForbidden)
+	_, _, err = svc.ReassignReviewer(asUser(reviewer), "pr1", reviewer)
+	require.NotErrorIs(t, err, model.ErrForbidden)
+
+	// Чтение доступно всем; администрирование — только администратору.
+	_, err = svc.GetTeam(regular, "backend")
+	require.NoError(t, err)
+	require.ErrorIs(t, svc.AssignRole(lead, model.RoleAssignment{UserID: "u1", Role: model.RoleTeamLead, TeamName: "backend"}), model.ErrForbidden)
+	require.ErrorIs(t, svc.Export(regular, io.Discard), model.ErrForbidden)
+	_, err = svc.Import(lead, model.ImportBatch{}, model.ImportOptions{})
+	require.ErrorIs(t, err, model.ErrForbidden)
+	admin := auth.NewContext(context.Background(), &auth.Principal{Subject: "tok_ops", Method: "api_token", Scopes: []string{auth.ScopeAdmin}})
+	require.NoError(t, svc.Export(admin, io.Discard))
+}
+
+// movingRepo переводит пользователя в другую команду сразу после того, как сервис
+// прочитал его, — как конкурирующий запрос администратора.
+type movingRepo struct {
+	*memory.Repository
+	to string
+}
+
+func (m movingRepo) GetUserByID(ctx context.Context, userID string) (*model.User, error) {
+	user, err := m.Repository.GetUserByID(ctx, userID)
+	if err == nil {
+		moved := *user
+		moved.TeamName = m.to
+		err = m.Repository.WithTransaction(ctx, func(tx repo.TxRepository) error {
+			return tx.UpsertUser(ctx, moved)
+		})
+	}
+	return user, err
+}
+
+func TestSetUserActiveStatusLeadRace(t *testing.T) {
+	f := memory.New()
+	seedTeam(f, "backend", true, "lead", "u1")
+	seedTeam(f, "frontend", true, "f1")
+	ctx := context.Background()
+	require.NoError(t, NewService(f).AssignRole(ctx, model.RoleAssignment{UserID: "lead", Role: model.RoleTeamLead, TeamName: "backend"}))
+
+	// Пользователя перевели в чужую команду между проверкой прав и изменением.
+	svc := NewService(movingRepo{f, "frontend"})
+	_, err := svc.SetUserActiveStatus(asUser("lead"), "u1", false)
+	require.ErrorIs(t, err, model.ErrPreconditionFailed)
+	user, err := f.GetUserByID(ctx, "u1")
+	require.NoError(t, err)
+	require.True(t, user.IsActive)
+}
+
+func TestTeamMembership(t *testing.T) {
+	svc, f := prepareService()
+	seedTeam(f, "backend", true, "lead", "u1", "u2")
+	seedTeam(f, "frontend", true, "f1", "f2")
+	ctx := context.Background()
+	require.NoError(t, svc.AssignRole(ctx, model.RoleAssignment{UserID: "lead", Role: model.RoleTeamLead, TeamName: "backend"}))
+	lead, regular := asUser("lead"), asUser("u1")
+
+	// Тимлид добавляет и удаляет участников только своей команды.
+	user, err := svc.AddTeamMember(lead, "backend", model.TeamMember{UserID: "u9", Username: "New", IsActive: true})
+	require.NoError(t, err)
+	require.Equal(t, model.User{UserID: "u9", Username: "New", TeamName: "backend", IsActive: true, Version: 1}, *user)
+	_, err = svc.AddTeamMember(lead, "frontend", model.TeamMember{UserID: "u8", Username: "x"})
+	require.ErrorIs(t, err, model.ErrForbidden)
+	_, err = svc.AddTeamMember(regular, "backend", model.TeamMember{UserID: "u8", Username: "x"})
+	require.ErrorIs(t, err, model.ErrForbidden)
+	// Забрать участника чужой команды тимлид не может.
+	_, err = svc.AddTeamMember(lead, "backend", model.TeamMember{UserID: "f1", Username: "f1"})
+	require.ErrorIs(t, err, model.ErrForbidden)
+	_, err = svc.AddTeamMember(ctx, "ghosts", model.TeamMember{UserID: "u8", Username: "x"})
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	require.ErrorIs(t, svc.RemoveTeamMember(lead, "frontend", "f2"), model.ErrForbidden)
+	require.ErrorIs(t, svc.RemoveTeamMember(lead, "backend", "f2"), model.ErrNotFound)
+	require.ErrorIs(t, svc.RemoveTeamMember(WithIfMatch(lead, 7), "backend", "u9"), model.ErrPreconditionFailed)
+	require.NoError(t, svc.RemoveTeamMember(lead, "backend", "u9"))
+	_, err = svc.GetUser(ctx, "u9")
+	require.ErrorIs(t, err, model.ErrNotFound)
+
+	// Администратор переводит участника между командами.
+	user, err = svc.AddTeamMember(ctx, "frontend", model.TeamMember{UserID: "u2", Username: "Bob", IsActive: true})
+	require.NoError(t, err)
+	require.Equal(t, "frontend", user.TeamName)
+
+	// Участника, на которого ссылаются PR, нельзя удалить — только деактивировать.
+	_, err = svc.CreatePullRequest(ctx, "pr1", "feat", "u1")
+	require.NoError(t, err)
+	require.ErrorIs(t, svc.RemoveTeamMember(lead, "backend", "u1"), model.ErrUserInUse)
+}
+
+func TestRoleValidation(t *testing.T) {
+	svc, f := prepareService()
+	seedTeam(f, "backend", true, "u1")
+	ctx := context.Background()
+
+	require.ErrorIs(t, svc.AssignRole(ctx, model.RoleAssignment{UserID: "u1", Role: "owner"}), model.ErrBadRequest)
+	require.ErrorIs(t, svc.AssignRole(ctx, model.RoleAssignment{UserID: "u1", Role: model.RoleTeamLead}), model.ErrBadRequest)
+	require.ErrorIs(t, svc.AssignRole(ctx, model.RoleAssignment{UserID: "u1", Role: model.RoleOrgAdmin, TeamName: "backend"}), model.ErrBadRequest)
+	require.ErrorIs(t, svc.AssignRole(ctx, model.RoleAssignment{UserID: "u1", Role: model.RoleTeamLead, TeamName: "ghosts"}), model.ErrNotFound)
+
+	lead := model.RoleAssignment{UserID: "u1", Role: model.RoleTeamLead, TeamName: "backend"}
+	require.NoError(t, svc.AssignRole(ctx, lead))
+	require.NoError(t, svc.AssignRole(ctx, lead))
+	roles, err := svc.ListRoles(ctx, "u1")
+	require.NoError(t, err)
+	require.Equal(t, []model.RoleAssignment{lead}, roles)
+
+	require.NoError(t, svc.RevokeRole(ctx, lead))
+	require.ErrorIs(t, svc.RevokeRole(ctx, lead), model.ErrNotFound)
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/trainee/review-service/internal/tracing"
+)
+
+// startSpan начинает спан метода сервиса. Запросы pgx внутри метода становятся
+// его дочерними спанами, так что видно, на что ушло время: блокировку PR,
+// поиск кандидатов или обновление.
+func startSpan(ctx context.Context, method string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracing.Start(ctx, "Service."+method, attrs...)
+}
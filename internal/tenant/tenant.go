@@ -0,0 +1,44 @@
+// Package tenant описывает организацию (tenant), в рамках которой выполняется запрос.
+// Команды, пользователи и PR разных организаций полностью изолированы: имена
+// команд и user_id могут совпадать.
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/trainee/review-service/internal/model"
+)
+
+// Default — организация для запросов, где она не указана явно, и для данных,
+// созданных до появления организаций.
+const Default = "default"
+
+// Header — заголовок, которым организация выбирается без аутентификации.
+const Header = "X-Org-ID"
+
+var validID = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,62}$`)
+
+// Validate проверяет формат идентификатора организации.
+func Validate(orgID string) error {
+	if !validID.MatchString(orgID) {
+		return fmt.Errorf("%w: invalid organization id %q", model.ErrBadRequest, orgID)
+	}
+	return nil
+}
+
+type orgKey struct{}
+
+// NewContext возвращает копию ctx с организацией.
+func NewContext(ctx context.Context, orgID string) context.Context {
+	return context.WithValue(ctx, orgKey{}, orgID)
+}
+
+// FromContext возвращает организацию запроса или Default, если она не задана.
+func FromContext(ctx context.Context) string {
+	if orgID, ok := ctx.Value(orgKey{}).(string); ok && orgID != "" {
+		return orgID
+	}
+	return Default
+}
@@ -0,0 +1,25 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trainee/review-service/internal/model"
+)
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, Default, FromContext(ctx))
+	require.Equal(t, "acme", FromContext(NewContext(ctx, "acme")))
+	require.Equal(t, Default, FromContext(NewContext(ctx, "")))
+}
+
+func TestValidate(t *testing.T) {
+	for _, id := range []string{"default", "acme", "bu-42", "a_b"} {
+		require.NoError(t, Validate(id), id)
+	}
+	for _, id := range []string{"", "Acme", "-acme", "a b", "acme/1", string(make([]byte, 64))} {
+		require.ErrorIs(t, Validate(id), model.ErrBadRequest, id)
+	}
+}
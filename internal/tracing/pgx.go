@@ -0,0 +1,93 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxQueryText — ограничение db.query.text: длинные скрипты (миграции) не
+// должны раздувать спаны.
+const maxQueryText = 2048
+
+// QueryTracer создаёт спан на каждый запрос pgx (Query, QueryRow, Exec,
+// SendBatch). Параметры запросов в спаны не попадают — только текст SQL.
+// Подключается через pgxpool.Config.ConnConfig.Tracer.
+type QueryTracer struct{}
+
+var (
+	_ pgx.QueryTracer = QueryTracer{}
+	_ pgx.BatchTracer = QueryTracer{}
+)
+
+func (QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, _ = startQuery(ctx, data.SQL)
+	return ctx
+}
+
+func (QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	if data.Err == nil {
+		span.SetAttributes(attribute.Int64("db.response.rows_affected", data.CommandTag.RowsAffected()))
+	}
+	endSpan(span, data.Err)
+}
+
+func (QueryTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	ctx, _ = Tracer().Start(ctx, "BATCH", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("db.system.name", "postgresql"),
+		attribute.Int("db.operation.batch.size", data.Batch.Len()),
+	))
+	return ctx
+}
+
+// TraceBatchQuery вызывается после выполнения запроса пакета: спан запроса
+// создаётся и закрывается сразу, его длительность не показательна.
+func (QueryTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	_, span := startQuery(ctx, data.SQL)
+	endSpan(span, data.Err)
+}
+
+func (QueryTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	endSpan(trace.SpanFromContext(ctx), data.Err)
+}
+
+func startQuery(ctx context.Context, sql string) (context.Context, trace.Span) {
+	op := operation(sql)
+	text := strings.TrimSpace(sql)
+	if len(text) > maxQueryText {
+		// Режем по границе руны: комментарии и литералы бывают не ASCII.
+		n := maxQueryText
+		for n > 0 && !utf8.RuneStart(text[n]) {
+			n--
+		}
+		text = text[:n]
+	}
+	return Tracer().Start(ctx, op, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("db.system.name", "postgresql"),
+		attribute.String("db.operation.name", op),
+		attribute.String("db.query.text", text),
+	))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// operation — первое слово запроса (SELECT, UPDATE, BEGIN...), имя спана.
+func operation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+	return strings.ToUpper(strings.TrimRight(fields[0], ";"))
+}
@@ -0,0 +1,124 @@
+// Package tracing настраивает OpenTelemetry: провайдер спанов с выбранным
+// экспортёром, распространение контекста W3C (traceparent, baggage) и
+// трассировку запросов pgx.
+//
+// Без Setup используется глобальный no-op провайдер, поэтому спаны в handler,
+// сервисе и репозитории ничего не стоят, пока трассировка не включена.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Name — имя инструментирования и сервиса по умолчанию (OTEL_SERVICE_NAME его переопределяет).
+const Name = "review-service"
+
+// Экспортёры спанов.
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// Config — настройки трассировки.
+type Config struct {
+	// Exporter — none, otlp (OTLP/HTTP) или stdout (JSON в stdout или File).
+	Exporter string
+	// Endpoint — URL коллектора OTLP, например http://otel-collector:4318;
+	// пустое значение — OTEL_EXPORTER_OTLP_* или http://localhost:4318.
+	Endpoint string
+	// File — файл для экспортёра stdout; пустое значение — stdout.
+	File string
+	// SampleRatio — доля трассируемых корневых запросов (0..1); решение
+	// вызывающего из traceparent соблюдается.
+	SampleRatio float64
+}
+
+// Setup включает трассировку по cfg и возвращает функцию, которая дописывает
+// накопленные спаны при остановке. Распространение W3C включается всегда:
+// даже без экспортёра входящий traceparent попадает в журнал и исходящие вызовы.
+func Setup(ctx context.Context, cfg Config) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var (
+		exporter sdktrace.SpanExporter
+		closer   io.Closer
+	)
+	switch cfg.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		var opts []otlptracehttp.Option
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+		}
+		exporter, err = otlptracehttp.New(ctx, opts...)
+	case ExporterStdout:
+		var w io.Writer = os.Stdout
+		if cfg.File != "" {
+			f, ferr := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if ferr != nil {
+				return nil, fmt.Errorf("open trace file: %w", ferr)
+			}
+			w, closer = f, f
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(w))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create trace exporter: %w", err)
+	}
+
+	// OTEL_SERVICE_NAME и OTEL_RESOURCE_ATTRIBUTES важнее имени по умолчанию.
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(Name)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		if closer != nil {
+			_ = closer.Close()
+		}
+		return nil, fmt.Errorf("trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			err = errors.Join(err, closer.Close())
+		}
+		return err
+	}, nil
+}
+
+// Tracer возвращает трассировщик сервиса из глобального провайдера.
+func Tracer() trace.Tracer {
+	return otel.Tracer(Name)
+}
+
+// Start начинает дочерний спан внутренней операции.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
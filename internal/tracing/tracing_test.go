@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans подменяет глобальный провайдер на время теста.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return recorder
+}
+
+func attr(span sdktrace.ReadOnlySpan, key string) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestQueryTracer(t *testing.T) {
+	recorder := recordSpans(t)
+	ctx, parent := Start(context.Background(), "Service.ReassignReviewer")
+	var tracer QueryTracer
+
+	qctx := tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "\n\t\tSELECT * FROM pull_requests WHERE pull_request_id = $1 FOR UPDATE", Args: []any{"pr-1"}})
+	tracer.TraceQueryEnd(qctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1")})
+
+	qctx = tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "update pull_requests SET status = $1"})
+	tracer.TraceQueryEnd(qctx, nil, pgx.TraceQueryEndData{Err: errors.New("deadlock detected")})
+
+	batch := &pgx.Batch{}
+	batch.Queue("INSERT INTO users VALUES ($1)", "u1")
+	bctx := tracer.TraceBatchStart(ctx, nil, pgx.TraceBatchStartData{Batch: batch})
+	tracer.TraceBatchQuery(bctx, nil, pgx.TraceBatchQueryData{SQL: "INSERT INTO users VALUES ($1)"})
+	tracer.TraceBatchEnd(bctx, nil, pgx.TraceBatchEndData{})
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 5)
+	sel, upd, ins, batchSpan := spans[0], spans[1], spans[2], spans[3]
+
+	require.Equal(t, "SELECT", sel.Name())
+	require.Equal(t, parent.SpanContext().SpanID(), sel.Parent().SpanID())
+	require.Equal(t, "SELECT * FROM pull_requests WHERE pull_request_id = $1 FOR UPDATE", attr(sel, "db.query.text").AsString())
+	require.Equal(t, int64(1), attr(sel, "db.response.rows_affected").AsInt64())
+	for _, kv := range sel.Attributes() {
+		require.NotEqual(t, "pr-1", kv.Value.Emit(), "query arguments must not be recorded")
+	}
+
+	require.Equal(t, "UPDATE", upd.Name())
+	require.Equal(t, codes.Error, upd.Status().Code)
+
+	require.Equal(t, "INSERT", ins.Name())
+	require.Equal(t, batchSpan.SpanContext().SpanID(), ins.Parent().SpanID())
+	require.Equal(t, "BATCH", batchSpan.Name())
+	require.Equal(t, int64(1), attr(batchSpan, "db.operation.batch.size").AsInt64())
+}
+
+func TestQueryTextTruncation(t *testing.T) {
+	recorder := recordSpans(t)
+	var tracer QueryTracer
+
+	// Двухбайтовая «я» попадает на границу ограничения.
+	sql := "SELECT 1 --" + strings.Repeat("я", maxQueryText)
+	qctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: sql})
+	tracer.TraceQueryEnd(qctx, nil, pgx.TraceQueryEndData{})
+
+	text := attr(recorder.Ended()[0], "db.query.text").AsString()
+	require.True(t, utf8.ValidString(text))
+	require.LessOrEqual(t, len(text), maxQueryText)
+	require.True(t, strings.HasPrefix(sql, text))
+}
+
+func TestSetup(t *testing.T) {
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	ctx := context.Background()
+
+	_, err := Setup(ctx, Config{Exporter: "zipkin"})
+	require.ErrorContains(t, err, "unknown trace exporter")
+
+	shutdown, err := Setup(ctx, Config{Exporter: ExporterNone})
+	require.NoError(t, err)
+	require.NoError(t, shutdown(ctx))
+
+	path := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err = Setup(ctx, Config{Exporter: ExporterStdout, File: path, SampleRatio: 1})
+	require.NoError(t, err)
+	_, span := Start(ctx, "Service.MergePullRequest")
+	span.End()
+	require.NoError(t, shutdown(ctx))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"Name":"Service.MergePullRequest"`)
+	require.Contains(t, string(data), Name)
+}
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE IF EXISTS pull_requests;
+DROP TYPE IF EXISTS pr_status;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS teams;
+
+COMMIT;
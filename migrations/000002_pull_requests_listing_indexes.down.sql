@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_pr_author;
+DROP INDEX IF EXISTS idx_pr_created_at_id;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- Keyset-пагинация списка PR идёт по (created_at DESC, pull_request_id DESC)
+CREATE INDEX IF NOT EXISTS idx_pr_created_at_id ON pull_requests (created_at DESC, pull_request_id DESC);
+
+-- Фильтрация PR по автору (в том числе по команде автора)
+CREATE INDEX IF NOT EXISTS idx_pr_author ON pull_requests (author_id);
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+-- Роли пользователей. user_id — идентификатор из SSO, поэтому без FK на users:
+-- администратор организации не обязан состоять в команде.
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id TEXT NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('org_admin', 'team_lead')),
+    team_name TEXT REFERENCES teams(team_name) ON DELETE CASCADE,
+    -- team_lead всегда привязан к команде, org_admin — никогда.
+    CHECK ((role = 'team_lead') = (team_name IS NOT NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_roles_unique ON user_roles (user_id, role, COALESCE(team_name, ''));
+
+COMMIT;
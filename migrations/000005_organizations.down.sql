@@ -0,0 +1,47 @@
+BEGIN;
+
+-- Откат возможен, пока данные есть только в одной организации: иначе
+-- ключи без org_id пересекутся и восстановление первичных ключей упадёт.
+DROP POLICY IF EXISTS org_isolation ON teams;
+DROP POLICY IF EXISTS org_isolation ON users;
+DROP POLICY IF EXISTS org_isolation ON pull_requests;
+DROP POLICY IF EXISTS org_isolation ON user_roles;
+ALTER TABLE teams DISABLE ROW LEVEL SECURITY;
+ALTER TABLE users DISABLE ROW LEVEL SECURITY;
+ALTER TABLE pull_requests DISABLE ROW LEVEL SECURITY;
+ALTER TABLE user_roles DISABLE ROW LEVEL SECURITY;
+
+DROP INDEX IF EXISTS idx_api_tokens_org;
+DROP INDEX IF EXISTS idx_user_roles_unique;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_roles_unique ON user_roles (user_id, role, COALESCE(team_name, ''));
+DROP INDEX IF EXISTS idx_pr_author;
+CREATE INDEX IF NOT EXISTS idx_pr_author ON pull_requests (author_id);
+DROP INDEX IF EXISTS idx_pr_created_at_id;
+CREATE INDEX IF NOT EXISTS idx_pr_created_at_id ON pull_requests (created_at DESC, pull_request_id DESC);
+DROP INDEX IF EXISTS idx_users_team_active;
+CREATE INDEX IF NOT EXISTS idx_users_team_active ON users (team_name, is_active);
+
+ALTER TABLE user_roles DROP CONSTRAINT IF EXISTS user_roles_team_name_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_author_id_fkey;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_team_name_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_pkey;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_pkey;
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_pkey;
+
+ALTER TABLE teams ADD CONSTRAINT teams_pkey PRIMARY KEY (team_name);
+ALTER TABLE users ADD CONSTRAINT users_pkey PRIMARY KEY (user_id);
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_pkey PRIMARY KEY (pull_request_id);
+ALTER TABLE users ADD CONSTRAINT users_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE RESTRICT;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_author_id_fkey
+    FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE RESTRICT;
+ALTER TABLE user_roles ADD CONSTRAINT user_roles_team_name_fkey
+    FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE;
+
+ALTER TABLE user_roles DROP COLUMN IF EXISTS org_id;
+ALTER TABLE api_tokens DROP COLUMN IF EXISTS org_id;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS org_id;
+ALTER TABLE users DROP COLUMN IF EXISTS org_id;
+ALTER TABLE teams DROP COLUMN IF EXISTS org_id;
+
+COMMIT;
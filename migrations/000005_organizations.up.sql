@@ -0,0 +1,74 @@
+BEGIN;
+
+-- Организации (tenants). Существующие данные попадают в организацию 'default';
+-- дальше org_id всегда задаёт сервис, поэтому DEFAULT снимается.
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS org_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS org_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS org_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE api_tokens ADD COLUMN IF NOT EXISTS org_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE user_roles ADD COLUMN IF NOT EXISTS org_id TEXT NOT NULL DEFAULT 'default';
+
+ALTER TABLE teams ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE pull_requests ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE api_tokens ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE user_roles ALTER COLUMN org_id DROP DEFAULT;
+
+-- Имена команд, user_id и pull_request_id уникальны только внутри организации,
+-- а ссылки не пересекают её границу.
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_author_id_fkey;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_team_name_fkey;
+ALTER TABLE user_roles DROP CONSTRAINT IF EXISTS user_roles_team_name_fkey;
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_pkey;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_pkey;
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_pkey;
+
+ALTER TABLE teams ADD CONSTRAINT teams_pkey PRIMARY KEY (org_id, team_name);
+ALTER TABLE users ADD CONSTRAINT users_pkey PRIMARY KEY (org_id, user_id);
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_pkey PRIMARY KEY (org_id, pull_request_id);
+ALTER TABLE users ADD CONSTRAINT users_team_name_fkey
+    FOREIGN KEY (org_id, team_name) REFERENCES teams(org_id, team_name) ON DELETE RESTRICT;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_author_id_fkey
+    FOREIGN KEY (org_id, author_id) REFERENCES users(org_id, user_id) ON DELETE RESTRICT;
+-- Для org_admin team_name = NULL, и FK (MATCH SIMPLE) не проверяется.
+ALTER TABLE user_roles ADD CONSTRAINT user_roles_team_name_fkey
+    FOREIGN KEY (org_id, team_name) REFERENCES teams(org_id, team_name) ON DELETE CASCADE;
+
+-- Индексы с организацией в начале ключа.
+DROP INDEX IF EXISTS idx_users_team_active;
+CREATE INDEX IF NOT EXISTS idx_users_team_active ON users (org_id, team_name, is_active);
+DROP INDEX IF EXISTS idx_pr_created_at_id;
+CREATE INDEX IF NOT EXISTS idx_pr_created_at_id ON pull_requests (org_id, created_at DESC, pull_request_id DESC);
+DROP INDEX IF EXISTS idx_pr_author;
+CREATE INDEX IF NOT EXISTS idx_pr_author ON pull_requests (org_id, author_id);
+DROP INDEX IF EXISTS idx_user_roles_unique;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_roles_unique ON user_roles (org_id, user_id, role, COALESCE(team_name, ''));
+CREATE INDEX IF NOT EXISTS idx_api_tokens_org ON api_tokens (org_id);
+
+-- Row-level security: строки видны, только если app.org_id совпадает с org_id.
+-- Владелец таблиц политики обходит, поэтому изоляция на уровне БД действует,
+-- когда сервис подключается отдельной ролью с DB_ROW_LEVEL_SECURITY=true.
+-- api_tokens не ограничивается: токен ищется по хэшу до того, как известна организация.
+ALTER TABLE teams ENABLE ROW LEVEL SECURITY;
+ALTER TABLE users ENABLE ROW LEVEL SECURITY;
+ALTER TABLE pull_requests ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_roles ENABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS org_isolation ON teams;
+CREATE POLICY org_isolation ON teams
+    USING (org_id = current_setting('app.org_id', true))
+    WITH CHECK (org_id = current_setting('app.org_id', true));
+DROP POLICY IF EXISTS org_isolation ON users;
+CREATE POLICY org_isolation ON users
+    USING (org_id = current_setting('app.org_id', true))
+    WITH CHECK (org_id = current_setting('app.org_id', true));
+DROP POLICY IF EXISTS org_isolation ON pull_requests;
+CREATE POLICY org_isolation ON pull_requests
+    USING (org_id = current_setting('app.org_id', true))
+    WITH CHECK (org_id = current_setting('app.org_id', true));
+DROP POLICY IF EXISTS org_isolation ON user_roles;
+CREATE POLICY org_isolation ON user_roles
+    USING (org_id = current_setting('app.org_id', true))
+    WITH CHECK (org_id = current_setting('app.org_id', true));
+
+COMMIT;
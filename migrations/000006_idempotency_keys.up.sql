@@ -0,0 +1,20 @@
+BEGIN;
+
+-- Ответы на запросы с заголовком Idempotency-Key. Ключ уникален в пределах
+-- организации и вызывающего (scope); status_code = 0 — запрос ещё выполняется.
+-- Как и api_tokens, таблица не под RLS: истёкшие ключи чистятся по всем организациям.
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    org_id TEXT NOT NULL,
+    scope TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    fingerprint TEXT NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    response BYTEA,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, scope, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS version;
+ALTER TABLE users DROP COLUMN IF EXISTS version;
+ALTER TABLE teams DROP COLUMN IF EXISTS version;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+-- Версии для оптимистичной блокировки: отдаются клиентам как ETag и
+-- сравниваются с If-Match перед изменением.
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+COMMIT;
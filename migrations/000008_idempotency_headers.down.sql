@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS headers;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Заголовки исходного ответа (Content-Type, Location, ETag), которые повтор
+-- запроса с тем же Idempotency-Key должен вернуть вместе с телом.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS headers JSONB;
+
+COMMIT;
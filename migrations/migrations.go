@@ -0,0 +1,296 @@
+// Package migrations встраивает SQL-миграции схемы в бинарник и применяет их.
+//
+// Версия схемы хранится в таблице schema_migrations в формате golang-migrate
+// (одна строка version, dirty), поэтому базы, размеченные контейнером migrate,
+// продолжают работать, а файлы можно применять и утилитой migrate.
+package migrations
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// lockID — ключ pg_advisory_lock: одновременно мигрирует только один процесс.
+const lockID = 7_318_204_451
+
+var (
+	// ErrDirty — предыдущая миграция упала посередине; схему нужно починить вручную
+	// и выставить версию в schema_migrations.
+	ErrDirty = errors.New("database schema is dirty")
+	// ErrVersionMismatch — версия схемы не совпадает с ожидаемой бинарником.
+	ErrVersionMismatch = errors.New("database schema version mismatch")
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration — пара скриптов одной версии схемы.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// All возвращает встроенные миграции по возрастанию версии.
+func All() ([]Migration, error) {
+	return parse(files)
+}
+
+func parse(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[uint]*Migration{}
+	for _, e := range entries {
+		m := fileName.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("migrations: unexpected file %s", e.Name())
+		}
+		v, err := strconv.ParseUint(m[1], 10, 32)
+		if err != nil || v == 0 {
+			return nil, fmt.Errorf("migrations: invalid version in %s", e.Name())
+		}
+		data, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+		mig := byVersion[uint(v)]
+		if mig == nil {
+			mig = &Migration{Version: uint(v), Name: m[2]}
+			byVersion[uint(v)] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("migrations: version %d has different names %q and %q", v, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(data)
+		} else {
+			mig.Down = string(data)
+		}
+	}
+
+	list := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migrations: version %d needs both up and down scripts", m.Version)
+		}
+		list = append(list, *m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	for i, m := range list {
+		if m.Version != uint(i+1) {
+			return nil, fmt.Errorf("migrations: version %d is missing", i+1)
+		}
+	}
+	return list, nil
+}
+
+// Latest — версия схемы, которую ожидает бинарник.
+func Latest() uint {
+	list, err := All()
+	if err != nil || len(list) == 0 {
+		return 0
+	}
+	return list[len(list)-1].Version
+}
+
+// Status — состояние схемы БД.
+type Status struct {
+	// Version — применённая версия; 0 — миграций не было.
+	Version uint `json:"version"`
+	Dirty   bool `json:"dirty"`
+	// Latest — версия, которую ожидает бинарник.
+	Latest  uint     `json:"latest"`
+	Pending []string `json:"pending"`
+}
+
+// Migrator применяет встроенные миграции к БД.
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+}
+
+func New(pool *pgxpool.Pool) (*Migrator, error) {
+	list, err := All()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{pool: pool, migrations: list}, nil
+}
+
+// Status читает версию схемы.
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	st := Status{Latest: m.latest(), Pending: []string{}}
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return st, err
+	}
+	defer conn.Release()
+	st.Version, st.Dirty, err = readVersion(ctx, conn.Conn())
+	if err != nil {
+		return st, err
+	}
+	for _, mig := range m.migrations {
+		if mig.Version > st.Version {
+			st.Pending = append(st.Pending, label(mig))
+		}
+	}
+	return st, nil
+}
+
+// Check проверяет, что схема чистая и её версия совпадает с Latest.
+func (m *Migrator) Check(ctx context.Context) error {
+	st, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if st.Dirty {
+		return fmt.Errorf("%w at version %d", ErrDirty, st.Version)
+	}
+	if st.Version != st.Latest {
+		return fmt.Errorf("%w: database is at %d, binary expects %d", ErrVersionMismatch, st.Version, st.Latest)
+	}
+	return nil
+}
+
+// Up применяет все недостающие миграции и возвращает их.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+	err := m.locked(ctx, func(conn *pgx.Conn, current uint) error {
+		for _, mig := range m.migrations {
+			if mig.Version <= current {
+				continue
+			}
+			if err := apply(ctx, conn, mig.Up, mig.Version, mig.Version); err != nil {
+				return fmt.Errorf("migrate up %s: %w", label(mig), err)
+			}
+			applied = append(applied, mig)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down откатывает steps последних миграций и возвращает откаченные.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var reverted []Migration
+	err := m.locked(ctx, func(conn *pgx.Conn, current uint) error {
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			mig := m.migrations[i]
+			if mig.Version > current {
+				continue
+			}
+			if err := apply(ctx, conn, mig.Down, mig.Version, mig.Version-1); err != nil {
+				return fmt.Errorf("migrate down %s: %w", label(mig), err)
+			}
+			reverted = append(reverted, mig)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// locked выполняет fn под advisory lock на выделенном соединении.
+// Грязную схему не трогает: сначала её нужно починить вручную.
+func (m *Migrator) locked(ctx context.Context, fn func(conn *pgx.Conn, current uint) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", lockID); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		// Контекст мог быть отменён: снимаем блокировку в любом случае.
+		_, _ = conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", lockID)
+	}()
+
+	if _, err := conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	current, dirty, err := readVersion(ctx, conn.Conn())
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("%w at version %d", ErrDirty, current)
+	}
+	return fn(conn.Conn(), current)
+}
+
+// apply выполняет скрипт так же, как golang-migrate: версия помечается dirty до
+// запуска и очищается после успеха, так что упавшая миграция видна в статусе.
+func apply(ctx context.Context, conn *pgx.Conn, script string, dirtyVersion, cleanVersion uint) error {
+	if err := setVersion(ctx, conn, dirtyVersion, true); err != nil {
+		return err
+	}
+	// Без аргументов pgx использует простой протокол, и скрипт может состоять из
+	// нескольких команд, включая собственные BEGIN/COMMIT.
+	if _, err := conn.Exec(ctx, script); err != nil {
+		return err
+	}
+	return setVersion(ctx, conn, cleanVersion, false)
+}
+
+func setVersion(ctx context.Context, conn *pgx.Conn, version uint, dirty bool) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "TRUNCATE schema_migrations"); err != nil {
+			return err
+		}
+		// Версия 0 — пустая таблица, как после полного отката в golang-migrate.
+		if version == 0 && !dirty {
+			return nil
+		}
+		_, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)", int64(version), dirty)
+		return err
+	})
+}
+
+// readVersion читает версию; без таблицы schema_migrations схема считается пустой.
+func readVersion(ctx context.Context, conn *pgx.Conn) (uint, bool, error) {
+	var exists bool
+	if err := conn.QueryRow(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists); err != nil {
+		return 0, false, err
+	}
+	if !exists {
+		return 0, false, nil
+	}
+	var (
+		version int64
+		dirty   bool
+	)
+	err := conn.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("read schema version: %w", err)
+	}
+	return uint(version), dirty, nil
+}
+
+func (m *Migrator) latest() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// label — имя миграции в выводе команд, например "000007_versions".
+func label(m Migration) string {
+	return fmt.Sprintf("%06d_%s", m.Version, m.Name)
+}
@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	list, err := All()
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+	require.Equal(t, list[len(list)-1].Version, Latest())
+	for _, m := range list {
+		require.Contains(t, m.Up, "COMMIT;", label(m))
+		require.Contains(t, m.Down, "COMMIT;", label(m))
+	}
+	require.Equal(t, "000001_init_schema", label(list[0]))
+}
+
+func TestParse(t *testing.T) {
+	file := func(s string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(s)} }
+
+	list, err := parse(fstest.MapFS{
+		"000002_b.up.sql":   file("up 2"),
+		"000002_b.down.sql": file("down 2"),
+		"000001_a.up.sql":   file("up 1"),
+		"000001_a.down.sql": file("down 1"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Migration{
+		{Version: 1, Name: "a", Up: "up 1", Down: "down 1"},
+		{Version: 2, Name: "b", Up: "up 2", Down: "down 2"},
+	}, list)
+
+	tests := []struct {
+		name  string
+		fsys  fstest.MapFS
+		error string
+	}{
+		{"missing down", fstest.MapFS{"000001_a.up.sql": file("up")}, "needs both up and down"},
+		{"gap", fstest.MapFS{
+			"000001_a.up.sql": file("up"), "000001_a.down.sql": file("down"),
+			"000003_c.up.sql": file("up"), "000003_c.down.sql": file("down"),
+		}, "version 2 is missing"},
+		{"name mismatch", fstest.MapFS{"000001_a.up.sql": file("up"), "000001_b.down.sql": file("down")}, "different names"},
+		{"unexpected file", fstest.MapFS{"README.md": file("docs")}, "unexpected file"},
+		{"zero version", fstest.MapFS{"000000_a.up.sql": file("up")}, "invalid version"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse(tt.fsys)
+			require.ErrorContains(t, err, tt.error)
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Health проверяет доступность сервиса (GET /health).
+func (c *Client) Health(ctx context.Context) error {
+	_, err := c.callJSON(ctx, http.MethodGet, "/health", nil, nil, nil, nil)
+	return err
+}
+
+// --- Teams ---
+
+// CreateTeam создаёт команду; участники создаются или обновляются (POST /v2/teams).
+func (c *Client) CreateTeam(ctx context.Context, team Team, opts ...CallOption) (*Team, error) {
+	var out Team
+	if _, err := c.callJSON(ctx, http.MethodPost, "/v2/teams", nil, team, &out, opts); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTeam возвращает команду с участниками (GET /v2/teams/{name}).
+func (c *Client) GetTeam(ctx context.Context, teamName string) (*Team, error) {
+	var out Team
+	h, err := c.callJSON(ctx, http.MethodGet, "/v2/teams/"+escape(teamName), nil, nil, &out, nil)
+	if err != nil {
+		return nil, err
+	}
+	out.Version = version(h)
+	return &out, nil
+}
+
+// GetTeamMember возвращает участника команды; участник другой команды — ErrNotFound.
+func (c *Client) GetTeamMember(ctx context.Context, teamName, userID string) (*UserDetails, error) {
+	var out UserDetails
+	h, err := c.callJSON(ctx, http.MethodGet, "/v2/teams/"+escape(teamName)+"/members/"+escape(userID), nil, nil, &out, nil)
+	if err != nil {
+		return nil, err
+	}
+	out.Version = version(h)
+	return &out, nil
+}
+
+// SetUserActive меняет активность участника команды (PATCH /v2/teams/{name}/members/{user_id}).
+func (c *Client) SetUserActive(ctx context.Context, teamName, userID string, active bool, opts ...CallOption) (*User, error) {
+	var out User
+	h, err := c.callJSON(ctx, http.MethodPatch, "/v2/teams/"+escape(teamName)+"/members/"+escape(userID), nil,
+		map[string]bool{"is_active": active}, &out, opts)
+	if err != nil {
+		return nil, err
+	}
+	out.Version = version(h)
+	return &out, nil
+}
+
+// AddTeamMember добавляет пользователя в команду или переводит из другой
+// (PUT /v2/teams/{name}/members/{user_id}).
+func (c *Client) AddTeamMember(ctx context.Context, teamName string, member TeamMember, opts ...CallOption) (*User, error) {
+	var out User
+	h, err := c.callJSON(ctx, http.MethodPut, "/v2/teams/"+escape(teamName)+"/members/"+escape(member.UserID), nil,
+		map[string]any{"username": member.Username, "is_active": member.IsActive}, &out, opts)
+	if err != nil {
+		return nil, err
+	}
+	out.Version = version(h)
+	return &out, nil
+}
+
+// RemoveTeamMember удаляет участника команды (DELETE /v2/teams/{name}/members/{user_id});
+// на которого ссылаются PR — ErrUserInUse.
+func (c *Client) RemoveTeamMember(ctx context.Context, teamName, userID string, opts ...CallOption) error {
+	_, err := c.callJSON(ctx, http.MethodDelete, "/v2/teams/"+escape(teamName)+"/members/"+escape(userID), nil, nil, nil, opts)
+	return err
+}
+
+// --- Users ---
+
+// GetUser возвращает пользователя с числом открытых ревью (GET /v2/users/{user_id}).
+func (c *Client) GetUser(ctx context.Context, userID string) (*UserDetails, error) {
+	var out UserDetails
+	h, err := c.callJSON(ctx, http.MethodGet, "/v2/users/"+escape(userID), nil, nil, &out, nil)
+	if err != nil {
+		return nil, err
+	}
+	out.Version = version(h)
+	return &out, nil
+}
+
+// ListUsers возвращает страницу справочника пользователей (GET /v2/users).
+func (c *Client) ListUsers(ctx context.Context, filter UserFilter, page PageRequest) (*UserPage, error) {
+	q := url.Values{}
+	setIf(q, "team_name", filter.TeamName)
+	setIf(q, "username_prefix", filter.UsernamePrefix)
+	if filter.IsActive != nil {
+		q.Set("is_active", strconv.FormatBool(*filter.IsActive))
+	}
+	var out UserPage
+	if _, err := c.callJSON(ctx, http.MethodGet, "/v2/users", pageQuery(q, page), nil, &out, nil); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUserReviews возвращает PR, где пользователь ревьюер. Пустой status — только
+// открытые, StatusAll — любые (GET /v2/users/{user_id}/reviews).
+func (c *Client) GetUserReviews(ctx context.Context, userID string, status PRStatus, page PageRequest) (*ReviewPage, error) {
+	q := url.Values{}
+	setIf(q, "status", string(status))
+	var out ReviewPage
+	if _, err := c.callJSON(ctx, http.MethodGet, "/v2/users/"+escape(userID)+"/reviews", pageQuery(q, page), nil, &out, nil); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// --- Pull Requests ---
+
+// CreatePullRequest создаёт PR и назначает ревьюеров (POST /v2/pull-requests).
+func (c *Client) CreatePullRequest(ctx context.Context, id, name, authorID string, opts ...CallOption) (*PullRequest, error) {
+	body := map[string]string{"pull_request_id": id, "pull_request_name": name, "author_id": authorID}
+	var out PullRequest
+	h, err := c.callJSON(ctx, http.MethodPost, "/v2/pull-requests", nil, body, &out, opts)
+	if err != nil {
+		return nil, err
+	}
+	out.Version = version(h)
+	return &out, nil
+}
+
+// GetPullRequest возвращает PR (GET /v2/pull-requests/{id}).
+func (c *Client) GetPullRequest(ctx context.Context, id string) (*PullRequest, error) {
+	var out PullRequest
+	h, err := c.callJSON(ctx, http.MethodGet, "/v2/pull-requests/"+escape(id), nil, nil, &out, nil)
+	if err != nil {
+		return nil, err
+	}
+	out.Version = version(h)
+	return &out, nil
+}
+
+// ListPullRequests ищет PR, от новых к старым (GET /v2/pull-requests).
+func (c *Client) ListPullRequests(ctx context.Context, filter PRFilter, page PageRequest) (*PullRequestPage, error) {
+	q := url.Values{}
+	setIf(q, "status", string(filter.Status))
+	setIf(q, "author_id", filter.AuthorID)
+	setIf(q, "reviewer_id", filter.ReviewerID)
+	setIf(q, "team_name", filter.TeamName)
+	setIf(q, "name", filter.NameContains)
+	setTime(q, "created_from", filter.CreatedFrom)
+	setTime(q, "created_to", filter.CreatedTo)
+	setTime(q, "merged_from", filter.MergedFrom)
+	setTime(q, "merged_to", filter.MergedTo)
+	var out PullRequestPage
+	if _, err := c.callJSON(ctx, http.MethodGet, "/v2/pull-requests", pageQuery(q, page), nil, &out, nil); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// MergePullRequest переводит PR в MERGED; повтор для смерженного PR не ошибка
+// (PATCH /v2/pull-requests/{id}).
+func (c *Client) MergePullRequest(ctx context.Context, id string, opts ...CallOption) (*PullRequest, error) {
+	var out PullRequest
+	h, err := c.callJSON(ctx, http.MethodPatch, "/v2/pull-requests/"+escape(id), nil,
+		map[string]PRStatus{"status": StatusMerged}, &out, opts)
+	if err != nil {
+		return nil, err
+	}
+	out.Version = version(h)
+	return &out, nil
+}
+
+// ListReviewers возвращает ревьюеров PR (GET /v2/pull-requests/{id}/reviewers).
+func (c *Client) ListReviewers(ctx context.Context, id string) ([]string, error) {
+	var out struct {
+		Reviewers []string `json:"reviewers"`
+	}
+	if _, err := c.callJSON(ctx, http.MethodGet, "/v2/pull-requests/"+escape(id)+"/reviewers", nil, nil, &out, nil); err != nil {
+		return nil, err
+	}
+	return out.Reviewers, nil
+}
+
+// ReassignReviewer снимает ревьюера с PR и возвращает PR и назначенную замену
+// (DELETE /v2/pull-requests/{id}/reviewers/{user_id}).
+func (c *Client) ReassignReviewer(ctx context.Context, id, userID string, opts ...CallOption) (*PullRequest, string, error) {
+	var out struct {
+		PullRequest PullRequest `json:"pull_request"`
+		ReplacedBy  string      `json:"replaced_by"`
+	}
+	h, err := c.callJSON(ctx, http.MethodDelete, "/v2/pull-requests/"+escape(id)+"/reviewers/"+escape(userID), nil, nil, &out, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	out.PullRequest.Version = version(h)
+	return &out.PullRequest, out.ReplacedBy, nil
+}
+
+// --- Roles ---
+
+// ListRoles возвращает роли пользователя или, если userID пуст, все роли (GET /roles/list).
+func (c *Client) ListRoles(ctx context.Context, userID string) ([]RoleAssignment, error) {
+	q := url.Values{}
+	setIf(q, "user_id", userID)
+	var out struct {
+		Roles []RoleAssignment `json:"roles"`
+	}
+	if _, err := c.callJSON(ctx, http.MethodGet, "/roles/list", q, nil, &out, nil); err != nil {
+		return nil, err
+	}
+	return out.Roles, nil
+}
+
+// AssignRole выдаёт роль (POST /roles/assign).
+func (c *Client) AssignRole(ctx context.Context, role RoleAssignment, opts ...CallOption) error {
+	_, err := c.callJSON(ctx, http.MethodPost, "/roles/assign", nil, role, nil, opts)
+	return err
+}
+
+// RevokeRole отзывает роль (POST /roles/revoke).
+func (c *Client) RevokeRole(ctx context.Context, role RoleAssignment, opts ...CallOption) error {
+	_, err := c.callJSON(ctx, http.MethodPost, "/roles/revoke", nil, role, nil, opts)
+	return err
+}
+
+// --- API tokens ---
+
+// CreateToken выпускает API токен; значение токена возвращается только здесь.
+// ttl == 0 — бессрочный токен (POST /auth/token/create).
+func (c *Client) CreateToken(ctx context.Context, name string, scopes []string, ttl time.Duration) (string, *APIToken, error) {
+	body := map[string]any{"name": name, "scopes": scopes}
+	if ttl > 0 {
+		body["expires_in"] = ttl.String()
+	}
+	var out struct {
+		Token    string   `json:"token"`
+		APIToken APIToken `json:"api_token"`
+	}
+	if _, err := c.callJSON(ctx, http.MethodPost, "/auth/token/create", nil, body, &out, nil); err != nil {
+		return "", nil, err
+	}
+	return out.Token, &out.APIToken, nil
+}
+
+// ListTokens возвращает токены организации без их значений (GET /auth/token/list).
+func (c *Client) ListTokens(ctx context.Context) ([]APIToken, error) {
+	var out struct {
+		Tokens []APIToken `json:"tokens"`
+	}
+	if _, err := c.callJSON(ctx, http.MethodGet, "/auth/token/list", nil, nil, &out, nil); err != nil {
+		return nil, err
+	}
+	return out.Tokens, nil
+}
+
+// RevokeToken отзывает токен (POST /auth/token/revoke).
+func (c *Client) RevokeToken(ctx context.Context, tokenID string) error {
+	_, err := c.callJSON(ctx, http.MethodPost, "/auth/token/revoke", nil, map[string]string{"token_id": tokenID}, nil, nil)
+	return err
+}
+
+// --- Import, export & restore ---
+
+// Import загружает команды, пользователей и PR в формате JSONL или CSV (POST /import).
+// Сервер не принимает Idempotency-Key для импорта, поэтому вызов не повторяется.
+func (c *Client) Import(ctx context.Context, r io.Reader, format ImportFormat, opts ImportOptions) (*ImportReport, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("client: read import data: %w", err)
+	}
+	q := url.Values{"format": {string(format)}}
+	setIf(q, "mode", string(opts.Mode))
+	if opts.DryRun {
+		q.Set("dry_run", "true")
+	}
+	contentType := "application/x-ndjson"
+	if format == FormatCSV {
+		contentType = "text/csv"
+	}
+	var out ImportReport
+	req := request{method: http.MethodPost, path: "/import", query: q, body: body, contentType: contentType}
+	if _, err := c.call(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Export пишет в w полную выгрузку данных организации (GET /export).
+func (c *Client) Export(ctx context.Context, w io.Writer) error {
+	resp, err := c.do(ctx, request{method: http.MethodGet, path: "/export"})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// Restore восстанавливает выгрузку в пустую организацию (POST /restore).
+// Как и Import, вызов не повторяется.
+func (c *Client) Restore(ctx context.Context, r io.Reader) (*RestoreReport, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("client: read archive: %w", err)
+	}
+	var out RestoreReport
+	req := request{method: http.MethodPost, path: "/restore", body: body, contentType: "application/x-ndjson"}
+	if _, err := c.call(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,329 @@
+// Package client — Go-клиент HTTP API сервиса назначения ревьюеров.
+//
+//	c, err := client.New("http://localhost:8080", client.WithToken(token))
+//	pr, err := c.CreatePullRequest(ctx, "pr-1", "Add search", "u1")
+//	if errors.Is(err, client.ErrPRExists) { ... }
+//
+// Клиент работает с ресурсным API /v2 и служебными маршрутами (импорт, выгрузка,
+// токены, роли, поток событий). Ошибки ответов возвращаются как *APIError и
+// сравниваются с ошибками пакета через errors.Is. GET-запросы и мутации с
+// Idempotency-Key повторяются при сетевых ошибках, 429, 502–504 и
+// IDEMPOTENCY_IN_PROGRESS с экспоненциальной задержкой.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/trainee/review-service/internal/tenant"
+)
+
+// RetryPolicy задаёт повторы идемпотентных запросов.
+type RetryPolicy struct {
+	// MaxAttempts — число попыток, включая первую; 1 отключает повторы.
+	MaxAttempts int
+	// MinBackoff — задержка перед первым повтором; дальше она удваивается до MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy используется, если не задан WithRetry.
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, MinBackoff: 100 * time.Millisecond, MaxBackoff: 2 * time.Second}
+
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	token      string
+	org        string
+	retry      RetryPolicy
+}
+
+// Option настраивает Client.
+type Option func(*Client)
+
+// WithHTTPClient задаёт http.Client (таймауты, транспорт); по умолчанию http.DefaultClient.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithToken передаёт API токен или JWT в заголовке Authorization.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithOrg задаёт организацию (X-Org-ID); для аутентифицированных вызовов она
+// должна совпадать с организацией токена.
+func WithOrg(org string) Option {
+	return func(c *Client) {
+		c.org = org
+	}
+}
+
+// WithRetry задаёт политику повторов.
+func WithRetry(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// New создаёт клиент для сервиса по адресу baseURL, например "http://localhost:8080".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("client: invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("client: base URL must be http or https, got %q", baseURL)
+	}
+	c := &Client{baseURL: u, httpClient: http.DefaultClient, retry: DefaultRetryPolicy}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.retry.MaxAttempts < 1 {
+		c.retry.MaxAttempts = 1
+	}
+	return c, nil
+}
+
+// CallOption настраивает отдельный вызов.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	idempotencyKey string
+	ifMatch        int64
+}
+
+// WithIdempotencyKey передаёт Idempotency-Key: сервис выполнит мутацию один раз,
+// а клиент сможет безопасно её повторить.
+func WithIdempotencyKey(key string) CallOption {
+	return func(o *callOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// WithIfMatch выполняет мутацию, только если версия ресурса (Version) не изменилась;
+// иначе вызов вернёт ErrPreconditionFailed.
+func WithIfMatch(version int64) CallOption {
+	return func(o *callOptions) {
+		o.ifMatch = version
+	}
+}
+
+// request — описание HTTP-запроса к API.
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        []byte
+	contentType string
+	opts        []CallOption
+}
+
+func jsonRequest(method, path string, body any, opts []CallOption) (request, error) {
+	req := request{method: method, path: path, opts: opts}
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return req, fmt.Errorf("client: encode request: %w", err)
+		}
+		req.body, req.contentType = data, "application/json"
+	}
+	return req, nil
+}
+
+// do выполняет запрос с повторами и возвращает успешный ответ; тело закрывает вызывающий.
+func (c *Client) do(ctx context.Context, req request) (*http.Response, error) {
+	var o callOptions
+	for _, opt := range req.opts {
+		opt(&o)
+	}
+	retryable := req.method == http.MethodGet || o.idempotencyKey != ""
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, req, o)
+		if err == nil && resp.StatusCode < 400 {
+			return resp, nil
+		}
+		var retryAfter time.Duration
+		if err == nil {
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+			err = decodeError(resp)
+		}
+		if !retryable || attempt >= c.retry.MaxAttempts || !shouldRetry(ctx, err) {
+			return nil, err
+		}
+		if err := sleep(ctx, max(retryAfter, c.backoff(attempt))); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, req request, o callOptions) (*http.Response, error) {
+	u := c.baseURL.JoinPath(req.path)
+	u.RawQuery = req.query.Encode()
+
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	}
+	if c.token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.org != "" {
+		httpReq.Header.Set(tenant.Header, c.org)
+	}
+	if o.idempotencyKey != "" {
+		httpReq.Header.Set("Idempotency-Key", o.idempotencyKey)
+	}
+	if o.ifMatch > 0 {
+		httpReq.Header.Set("If-Match", etag(o.ifMatch))
+	}
+	return c.httpClient.Do(httpReq)
+}
+
+// shouldRetry — временные ошибки: сеть, перегрузка и незавершённый запрос с тем же ключом.
+func shouldRetry(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return errors.Is(err, ErrIdempotencyInProgress)
+}
+
+// backoff — экспоненциальная задержка с джиттером в [d/2, d].
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.retry.MinBackoff << (attempt - 1)
+	if d <= 0 || d > c.retry.MaxBackoff {
+		d = c.retry.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func parseRetryAfter(v string) time.Duration {
+	if seconds, err := strconv.Atoi(v); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return 0
+}
+
+// decodeError разбирает APIErrorResponse и закрывает тело ответа.
+func decodeError(resp *http.Response) error {
+	defer resp.Body.Close()
+	var payload struct {
+		Error struct {
+			Code    string       `json:"code"`
+			Message string       `json:"message"`
+			Details []FieldError `json:"details"`
+		} `json:"error"`
+	}
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err == nil {
+		apiErr.Code, apiErr.Message, apiErr.Details = payload.Error.Code, payload.Error.Message, payload.Error.Details
+	}
+	if apiErr.Code == "" {
+		apiErr.Code, apiErr.Message = "HTTP_"+strconv.Itoa(resp.StatusCode), http.StatusText(resp.StatusCode)
+	}
+	return apiErr
+}
+
+// call выполняет JSON-запрос и декодирует ответ в out (если out != nil).
+func (c *Client) call(ctx context.Context, req request, out any) (http.Header, error) {
+	resp, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return nil, fmt.Errorf("client: decode response: %w", err)
+		}
+	}
+	return resp.Header, nil
+}
+
+func (c *Client) callJSON(ctx context.Context, method, path string, query url.Values, body, out any, opts []CallOption) (http.Header, error) {
+	req, err := jsonRequest(method, path, body, opts)
+	if err != nil {
+		return nil, err
+	}
+	req.query = query
+	return c.call(ctx, req, out)
+}
+
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// version разбирает ETag ответа; 0 — заголовка нет.
+func version(h http.Header) int64 {
+	v, _ := strconv.ParseInt(strings.Trim(h.Get("ETag"), `"`), 10, 64)
+	return v
+}
+
+func pageQuery(q url.Values, page PageRequest) url.Values {
+	if page.Limit > 0 {
+		q.Set("limit", strconv.Itoa(page.Limit))
+	}
+	if page.Cursor != "" {
+		q.Set("cursor", page.Cursor)
+	}
+	return q
+}
+
+func setIf(q url.Values, key, value string) {
+	if value != "" {
+		q.Set(key, value)
+	}
+}
+
+func setTime(q url.Values, key string, t *time.Time) {
+	if t != nil {
+		q.Set(key, t.Format(time.RFC3339))
+	}
+}
+
+func escape(segment string) string {
+	return url.PathEscape(segment)
+}
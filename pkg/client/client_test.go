@@ -0,0 +1,553 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/trainee/review-service/internal/auth"
+	"github.com/trainee/review-service/internal/events"
+	"github.com/trainee/review-service/internal/handler"
+	"github.com/trainee/review-service/internal/model"
+	repo "github.com/trainee/review-service/internal/repository"
+	"github.com/trainee/review-service/internal/service"
+	"github.com/trainee/review-service/pkg/client"
+)
+
+// fakeRepo — in-memory репозиторий для сквозных тестов клиента с настоящим роутером.
+// Методы, которые тесты не вызывают, достаются от nil repo.Repository.
+type fakeRepo struct {
+	repo.Repository
+
+	mu    sync.Mutex
+	teams map[string]model.Team
+	users map[string]model.User
+	prs   map[string]*model.PullRequest
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{
+		teams: map[string]model.Team{},
+		users: map[string]model.User{},
+		prs:   map[string]*model.PullRequest{},
+	}
+}
+
+func (f *fakeRepo) WithTransaction(_ context.Context, fn func(repo.TxRepository) error) error {
+	return fn(f)
+}
+
+func (f *fakeRepo) WithSavepoint(_ context.Context, fn func(repo.TxRepository) error) error {
+	return fn(f)
+}
+
+func (f *fakeRepo) EnsureTeam(context.Context, string) error { return nil }
+func (f *fakeRepo) IsEmpty(context.Context) (bool, error)    { return len(f.teams) == 0, nil }
+
+func (f *fakeRepo) CreateTeamTx(_ context.Context, team model.Team) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.teams[team.TeamName]; ok {
+		return repo.ErrAlreadyExists
+	}
+	team.Version = 1
+	f.teams[team.TeamName] = team
+	for _, m := range team.Members {
+		f.users[m.UserID] = model.User{UserID: m.UserID, Username: m.Username, TeamName: team.TeamName, IsActive: m.IsActive, Version: 1}
+	}
+	return nil
+}
+
+func (f *fakeRepo) GetTeam(_ context.Context, teamName string) (*model.Team, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	team, ok := f.teams[teamName]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	team.Members = nil
+	for _, u := range f.users {
+		if u.TeamName == teamName {
+			team.Members = append(team.Members, model.TeamMember{UserID: u.UserID, Username: u.Username, IsActive: u.IsActive})
+		}
+	}
+	sort.Slice(team.Members, func(i, j int) bool { return team.Members[i].UserID < team.Members[j].UserID })
+	return &team, nil
+}
+
+func (f *fakeRepo) SetUserActiveStatus(_ context.Context, userID string, isActive bool, expectedVersion int64) (*model.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	if expectedVersion != 0 && u.Version != expectedVersion {
+		return nil, repo.ErrVersionConflict
+	}
+	u.IsActive = isActive
+	u.Version++
+	f.users[userID] = u
+	return &u, nil
+}
+
+func (f *fakeRepo) UpsertUser(_ context.Context, user model.User) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.teams[user.TeamName]; !ok {
+		return repo.ErrNotFound
+	}
+	user.Version = f.users[user.UserID].Version + 1
+	f.users[user.UserID] = user
+	return nil
+}
+
+func (f *fakeRepo) DeleteUser(_ context.Context, userID string, expectedVersion int64) (*model.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	if expectedVersion != 0 && u.Version != expectedVersion {
+		return nil, repo.ErrVersionConflict
+	}
+	for _, pr := range f.prs {
+		if pr.AuthorID == userID {
+			return nil, repo.ErrInUse
+		}
+	}
+	delete(f.users, userID)
+	return &u, nil
+}
+
+func (f *fakeRepo) GetUserByID(_ context.Context, userID string) (*model.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	u, ok := f.users[userID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	return &u, nil
+}
+
+func (f *fakeRepo) ListTeamMembers(_ context.Context, teamName string) ([]model.User, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var members []model.User
+	for _, u := range f.users {
+		if u.TeamName == teamName {
+			members = append(members, u)
+		}
+	}
+	return members, nil
+}
+
+func (f *fakeRepo) GetUserDetails(ctx context.Context, userID string) (*model.UserDetails, error) {
+	u, err := f.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &model.UserDetails{User: *u}, nil
+}
+
+func (f *fakeRepo) ListUsers(_ context.Context, filter model.UserFilter, afterUserID string, limit int) ([]model.UserDetails, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var result []model.UserDetails
+	for _, u := range f.users {
+		if (filter.TeamName == "" || u.TeamName == filter.TeamName) &&
+			(filter.IsActive == nil || u.IsActive == *filter.IsActive) &&
+			strings.HasPrefix(u.Username, filter.UsernamePrefix) && u.UserID > afterUserID {
+			result = append(result, model.UserDetails{User: u})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].UserID < result[j].UserID })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (f *fakeRepo) CreatePR(_ context.Context, pr *model.PullRequest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.prs[pr.ID]; ok {
+		return repo.ErrAlreadyExists
+	}
+	now := time.Now().UTC()
+	pr.CreatedAt = &now
+	pr.Version = 1
+	cp := *pr
+	cp.AssignedReviewers = slices.Clone(pr.AssignedReviewers)
+	f.prs[pr.ID] = &cp
+	return nil
+}
+
+func (f *fakeRepo) GetPRByID(_ context.Context, prID string) (*model.PullRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pr, ok := f.prs[prID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	cp := *pr
+	cp.AssignedReviewers = slices.Clone(pr.AssignedReviewers)
+	return &cp, nil
+}
+
+func (f *fakeRepo) GetPRByIDForUpdate(ctx context.Context, prID string) (*model.PullRequest, error) {
+	return f.GetPRByID(ctx, prID)
+}
+
+func (f *fakeRepo) UpdatePR(_ context.Context, pr *model.PullRequest) (*model.PullRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	current, ok := f.prs[pr.ID]
+	if !ok {
+		return nil, repo.ErrNotFound
+	}
+	if current.Version != pr.Version {
+		return nil, repo.ErrVersionConflict
+	}
+	cp := *pr
+	cp.Version++
+	f.prs[pr.ID] = &cp
+	out := cp
+	return &out, nil
+}
+
+func (f *fakeRepo) ListPRs(_ context.Context, filter model.PRFilter, after *model.PRCursor, limit int) ([]model.PullRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var result []model.PullRequest
+	for _, pr := range f.prs {
+		if (filter.Status == "" || pr.Status == filter.Status) &&
+			(filter.AuthorID == "" || pr.AuthorID == filter.AuthorID) &&
+			(filter.ReviewerID == "" || slices.Contains(pr.AssignedReviewers, filter.ReviewerID)) &&
+			(after == nil || pr.ID < after.ID) {
+			result = append(result, *pr)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID > result[j].ID })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (f *fakeRepo) GetPRsByReviewer(ctx context.Context, filter model.ReviewFilter, after *model.PRCursor, limit int) ([]model.PullRequestShort, error) {
+	prs, err := f.ListPRs(ctx, model.PRFilter{ReviewerID: filter.UserID, Status: filter.Status}, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	var result []model.PullRequestShort
+	for _, pr := range prs {
+		result = append(result, model.PullRequestShort{ID: pr.ID, Name: pr.Name, AuthorID: pr.AuthorID, Status: pr.Status, CreatedAt: pr.CreatedAt})
+	}
+	return result, nil
+}
+
+// newTestClient поднимает настоящий роутер поверх fakeRepo. wrap позволяет
+// подменить ответы сервера (например, для проверки повторов).
+func newTestClient(t *testing.T, wrap func(http.Handler) http.Handler, opts ...client.Option) (*client.Client, *events.Broker) {
+	t.Helper()
+	broker := events.NewBroker()
+	svc := service.NewService(newFakeRepo(), service.WithEvents(broker))
+	var h http.Handler = handler.NewHandler(svc, handler.WithEvents(broker)).SetupRouter()
+	if wrap != nil {
+		h = wrap(h)
+	}
+	srv := httptest.NewServer(h)
+	t.Cleanup(func() {
+		broker.Close()
+		srv.Close()
+	})
+
+	opts = append([]client.Option{client.WithRetry(client.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond})}, opts...)
+	c, err := client.New(srv.URL, opts...)
+	require.NoError(t, err)
+	return c, broker
+}
+
+func seedTeam(t *testing.T, c *client.Client) {
+	t.Helper()
+	_, err := c.CreateTeam(context.Background(), client.Team{
+		TeamName: "backend",
+		Members: []client.TeamMember{
+			{UserID: "u1", Username: "alice", IsActive: true},
+			{UserID: "u2", Username: "bob", IsActive: true},
+			{UserID: "u3", Username: "carol", IsActive: true},
+			{UserID: "u4", Username: "dave", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestNew(t *testing.T) {
+	_, err := client.New("localhost:8080")
+	require.Error(t, err)
+	_, err = client.New("http://localhost:8080/")
+	require.NoError(t, err)
+}
+
+func TestTeamsAndUsers(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx := context.Background()
+	require.NoError(t, c.Health(ctx))
+	seedTeam(t, c)
+
+	_, err := c.CreateTeam(ctx, client.Team{TeamName: "backend"})
+	require.ErrorIs(t, err, client.ErrTeamExists)
+	var apiErr *client.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusConflict, apiErr.StatusCode)
+	require.Equal(t, "TEAM_EXISTS", apiErr.Code)
+
+	// Ошибки валидации сообщают поле и правило.
+	_, err = c.CreateTeam(ctx, client.Team{TeamName: "dup", Members: []client.TeamMember{
+		{UserID: "u9", Username: "x", IsActive: true},
+		{UserID: "u9", Username: "y", IsActive: true},
+	}})
+	require.ErrorIs(t, err, client.ErrBadRequest)
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, []client.FieldError{{Field: "members.1.user_id", Rule: "unique", Message: "duplicate user_id u9"}}, apiErr.Details)
+
+	team, err := c.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	require.Len(t, team.Members, 4)
+	require.Equal(t, int64(1), team.Version)
+	_, err = c.GetTeam(ctx, "missing")
+	require.ErrorIs(t, err, client.ErrNotFound)
+
+	member, err := c.GetTeamMember(ctx, "backend", "u2")
+	require.NoError(t, err)
+	require.Equal(t, "bob", member.Username)
+
+	// If-Match с устаревшей версией отклоняется.
+	_, err = c.SetUserActive(ctx, "backend", "u2", false, client.WithIfMatch(member.Version+1))
+	require.ErrorIs(t, err, client.ErrPreconditionFailed)
+	user, err := c.SetUserActive(ctx, "backend", "u2", false, client.WithIfMatch(member.Version))
+	require.NoError(t, err)
+	require.False(t, user.IsActive)
+	require.Equal(t, member.Version+1, user.Version)
+
+	inactive := false
+	page, err := c.ListUsers(ctx, client.UserFilter{TeamName: "backend", IsActive: &inactive}, client.PageRequest{})
+	require.NoError(t, err)
+	require.Len(t, page.Users, 1)
+	require.Equal(t, "u2", page.Users[0].UserID)
+
+	page, err = c.ListUsers(ctx, client.UserFilter{}, client.PageRequest{Limit: 3})
+	require.NoError(t, err)
+	require.Len(t, page.Users, 3)
+	require.NotEmpty(t, page.NextCursor)
+	page, err = c.ListUsers(ctx, client.UserFilter{}, client.PageRequest{Limit: 3, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, page.Users, 1)
+
+	_, err = c.GetUser(ctx, "nobody")
+	require.ErrorIs(t, err, client.ErrNotFound)
+
+	// Состав команды меняется через PUT и DELETE участника.
+	added, err := c.AddTeamMember(ctx, "backend", client.TeamMember{UserID: "u9", Username: "ivan", IsActive: true})
+	require.NoError(t, err)
+	require.Equal(t, "backend", added.TeamName)
+	require.Equal(t, int64(1), added.Version)
+	require.NoError(t, c.RemoveTeamMember(ctx, "backend", "u9", client.WithIfMatch(added.Version)))
+	require.ErrorIs(t, c.RemoveTeamMember(ctx, "backend", "u9"), client.ErrNotFound)
+}
+
+func TestPullRequests(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx := context.Background()
+	seedTeam(t, c)
+
+	pr, err := c.CreatePullRequest(ctx, "pr-1", "Add search", "u1")
+	require.NoError(t, err)
+	require.Equal(t, client.StatusOpen, pr.Status)
+	require.Len(t, pr.AssignedReviewers, 2)
+	require.Equal(t, int64(1), pr.Version)
+	_, err = c.CreatePullRequest(ctx, "pr-1", "Add search", "u1")
+	require.ErrorIs(t, err, client.ErrPRExists)
+
+	reviewers, err := c.ListReviewers(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, pr.AssignedReviewers, reviewers)
+
+	reviews, err := c.GetUserReviews(ctx, reviewers[0], "", client.PageRequest{})
+	require.NoError(t, err)
+	require.Len(t, reviews.PullRequests, 1)
+
+	updated, replacedBy, err := c.ReassignReviewer(ctx, "pr-1", reviewers[0])
+	require.NoError(t, err)
+	require.Contains(t, updated.AssignedReviewers, replacedBy)
+	require.NotContains(t, updated.AssignedReviewers, reviewers[0])
+	_, _, err = c.ReassignReviewer(ctx, "pr-1", "u1")
+	require.ErrorIs(t, err, client.ErrNotAssigned)
+
+	_, err = c.MergePullRequest(ctx, "pr-1", client.WithIfMatch(pr.Version))
+	require.ErrorIs(t, err, client.ErrPreconditionFailed)
+	merged, err := c.MergePullRequest(ctx, "pr-1", client.WithIfMatch(updated.Version))
+	require.NoError(t, err)
+	require.Equal(t, client.StatusMerged, merged.Status)
+	_, _, err = c.ReassignReviewer(ctx, "pr-1", replacedBy)
+	require.ErrorIs(t, err, client.ErrPRMerged)
+
+	// По умолчанию ревью только открытых PR, StatusAll — любых.
+	reviews, err = c.GetUserReviews(ctx, replacedBy, "", client.PageRequest{})
+	require.NoError(t, err)
+	require.Empty(t, reviews.PullRequests)
+	reviews, err = c.GetUserReviews(ctx, replacedBy, client.StatusAll, client.PageRequest{})
+	require.NoError(t, err)
+	require.Len(t, reviews.PullRequests, 1)
+
+	list, err := c.ListPullRequests(ctx, client.PRFilter{Status: client.StatusMerged, AuthorID: "u1"}, client.PageRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.PullRequests, 1)
+
+	got, err := c.GetPullRequest(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, merged.Version, got.Version)
+	_, err = c.GetPullRequest(ctx, "pr/missing")
+	require.ErrorIs(t, err, client.ErrNotFound)
+}
+
+func TestRetries(t *testing.T) {
+	var (
+		failures atomic.Int32
+		attempts atomic.Int32
+	)
+	// Первые два ответа на каждый запрос — 503.
+	flaky := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts.Add(1)
+			if failures.Add(1) <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+	c, _ := newTestClient(t, flaky)
+	ctx := context.Background()
+
+	// GET повторяется.
+	require.NoError(t, c.Health(ctx))
+	require.Equal(t, int32(3), attempts.Load())
+
+	// Мутация без Idempotency-Key — нет: сервер мог её выполнить.
+	failures.Store(0)
+	attempts.Store(0)
+	_, err := c.CreateTeam(ctx, client.Team{TeamName: "backend"})
+	var apiErr *client.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	require.Equal(t, int32(1), attempts.Load())
+
+	// С ключом — повторяется.
+	failures.Store(0)
+	attempts.Store(0)
+	_, err = c.CreateTeam(ctx, client.Team{TeamName: "backend"}, client.WithIdempotencyKey("create-backend"))
+	require.NoError(t, err)
+	require.Equal(t, int32(3), attempts.Load())
+
+	// Импорт не повторяется: сервер не принимает для него Idempotency-Key.
+	failures.Store(0)
+	attempts.Store(0)
+	_, err = c.Import(ctx, strings.NewReader(`{"kind":"team","team_name":"frontend"}`), client.FormatJSONL, client.ImportOptions{})
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, int32(1), attempts.Load())
+
+	// Ошибки клиента не повторяются.
+	failures.Store(2)
+	attempts.Store(0)
+	_, err = c.GetTeam(ctx, "missing")
+	require.ErrorIs(t, err, client.ErrNotFound)
+	require.Equal(t, int32(1), attempts.Load())
+
+	// Повторы прекращаются при отмене контекста.
+	failures.Store(-100)
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.Error(t, c.Health(cancelled))
+}
+
+func TestAuthentication(t *testing.T) {
+	h := handler.NewHandler(service.NewService(newFakeRepo()), handler.WithAuth(handler.AuthConfig{Authenticator: staticAuth{}}))
+	srv := httptest.NewServer(h.SetupRouter())
+	defer srv.Close()
+
+	c, err := client.New(srv.URL, client.WithToken("wrong"))
+	require.NoError(t, err)
+	_, err = c.GetTeam(context.Background(), "backend")
+	require.ErrorIs(t, err, client.ErrUnauthorized)
+}
+
+// staticAuth не принимает ни один токен.
+type staticAuth struct{}
+
+func (staticAuth) Authenticate(context.Context, string) (*auth.Principal, error) {
+	return nil, auth.ErrInvalidCredentials
+}
+
+func TestEvents(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	seedTeam(t, c)
+
+	stream, err := c.Events(ctx, client.EventFilter{TeamName: "backend"})
+	require.NoError(t, err)
+	defer stream.Close()
+
+	pr, err := c.CreatePullRequest(ctx, "pr-1", "Add search", "u1")
+	require.NoError(t, err)
+	e, err := stream.Next()
+	require.NoError(t, err)
+	require.Equal(t, client.EventPRAssigned, e.Type)
+	require.Equal(t, pr.AssignedReviewers, e.Reviewers)
+	require.Equal(t, e.ID, stream.LastEventID)
+
+	_, err = c.MergePullRequest(ctx, "pr-1")
+	require.NoError(t, err)
+
+	// Продолжение с последнего полученного события.
+	resumed, err := c.Events(ctx, client.EventFilter{LastEventID: stream.LastEventID})
+	require.NoError(t, err)
+	defer resumed.Close()
+	e, err = resumed.Next()
+	require.NoError(t, err)
+	require.Equal(t, client.EventPRMerged, e.Type)
+
+	gap, err := c.Events(ctx, client.EventFilter{LastEventID: 1000})
+	require.NoError(t, err)
+	defer gap.Close()
+	_, err = gap.Next()
+	require.ErrorIs(t, err, client.ErrStreamReset)
+}
+
+func TestImport(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx := context.Background()
+
+	data := `{"kind":"team","team_name":"backend"}
+{"kind":"user","user_id":"u1","username":"alice","team_name":"backend","is_active":true}
+`
+	report, err := c.Import(ctx, strings.NewReader(data), client.FormatJSONL, client.ImportOptions{DryRun: true})
+	require.NoError(t, err)
+	require.False(t, report.Committed)
+	require.Equal(t, 2, report.Total)
+
+	_, err = c.Import(ctx, strings.NewReader(data), client.ImportFormat("xml"), client.ImportOptions{})
+	require.ErrorIs(t, err, client.ErrBadRequest)
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/trainee/review-service/internal/model"
+)
+
+// Ошибки API. Это те же значения, что возвращает сервис, поэтому errors.Is работает
+// одинаково по обе стороны HTTP.
+var (
+	ErrNotFound              = model.ErrNotFound
+	ErrTeamExists            = model.ErrTeamExists
+	ErrPRExists              = model.ErrPRExists
+	ErrPRMerged              = model.ErrPRMerged
+	ErrNotAssigned           = model.ErrNotAssigned
+	ErrNoCandidate           = model.ErrNoCandidate
+	ErrBadRequest            = model.ErrBadRequest
+	ErrNotEmpty              = model.ErrNotEmpty
+	ErrUserInUse             = model.ErrUserInUse
+	ErrInternal              = model.ErrInternal
+	ErrUnauthorized          = model.ErrUnauthorized
+	ErrInsufficientScope     = model.ErrInsufficientScope
+	ErrForbidden             = model.ErrForbidden
+	ErrIdempotencyKeyReused  = model.ErrIdempotencyKeyReused
+	ErrIdempotencyInProgress = model.ErrIdempotencyInProgress
+	ErrPreconditionFailed    = model.ErrPreconditionFailed
+)
+
+// codeErrors сопоставляет коды APIErrorResponse ошибкам (обратно respondError).
+var codeErrors = map[string]error{
+	"NOT_FOUND":               ErrNotFound,
+	"TEAM_EXISTS":             ErrTeamExists,
+	"PR_EXISTS":               ErrPRExists,
+	"PR_MERGED":               ErrPRMerged,
+	"NOT_ASSIGNED":            ErrNotAssigned,
+	"NO_CANDIDATE":            ErrNoCandidate,
+	"BAD_REQUEST":             ErrBadRequest,
+	"NOT_EMPTY":               ErrNotEmpty,
+	"USER_IN_USE":             ErrUserInUse,
+	"INTERNAL_ERROR":          ErrInternal,
+	"UNAUTHORIZED":            ErrUnauthorized,
+	"INSUFFICIENT_SCOPE":      ErrInsufficientScope,
+	"FORBIDDEN":               ErrForbidden,
+	"IDEMPOTENCY_KEY_REUSED":  ErrIdempotencyKeyReused,
+	"IDEMPOTENCY_IN_PROGRESS": ErrIdempotencyInProgress,
+	"PRECONDITION_FAILED":     ErrPreconditionFailed,
+}
+
+// FieldError — нарушение правила для поля запроса (details в ответе BAD_REQUEST).
+type FieldError = model.FieldError
+
+// APIError — ошибочный ответ сервиса. errors.Is(err, ErrNotFound) и т.п. проверяют
+// его код; неизвестные коды не соответствуют ни одной ошибке пакета.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	// Details перечисляет некорректные поля запроса, если сервис их сообщил.
+	Details []FieldError
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("review-service: %s: %s (HTTP %d)", e.Code, e.Message, e.StatusCode)
+}
+
+func (e *APIError) Unwrap() error {
+	return codeErrors[e.Code]
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrStreamReset — часть событий после LastEventID потеряна; состояние нужно
+// перечитать через API. Поток после этой ошибки продолжает работать.
+var ErrStreamReset = errors.New("client: events after the last event ID are no longer available")
+
+// EventFilter — параметры подписки на события.
+type EventFilter struct {
+	// UserID — события, где пользователь ревьюер, автор PR или сменил активность.
+	UserID   string
+	TeamName string
+	// LastEventID продолжает поток после разрыва; 0 — только новые события.
+	LastEventID uint64
+}
+
+// EventStream — открытый поток событий (GET /events).
+type EventStream struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+	// LastEventID — ID последнего прочитанного события; передайте его в EventFilter
+	// при переподключении.
+	LastEventID uint64
+}
+
+// Events подписывается на события организации. Поток закрывается вызовом Close,
+// отменой ctx или остановкой сервера (тогда Next вернёт io.EOF).
+func (c *Client) Events(ctx context.Context, filter EventFilter) (*EventStream, error) {
+	q := url.Values{}
+	setIf(q, "user_id", filter.UserID)
+	setIf(q, "team_name", filter.TeamName)
+	if filter.LastEventID > 0 {
+		q.Set("last_event_id", strconv.FormatUint(filter.LastEventID, 10))
+	}
+	resp, err := c.do(ctx, request{method: http.MethodGet, path: "/events", query: q})
+	if err != nil {
+		return nil, err
+	}
+	return &EventStream{body: resp.Body, reader: bufio.NewReader(resp.Body), LastEventID: filter.LastEventID}, nil
+}
+
+// Next блокируется до следующего события.
+func (s *EventStream) Next() (Event, error) {
+	var (
+		id, name string
+		data     strings.Builder
+	)
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return Event{}, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch {
+		case line == "":
+			if name == "" && data.Len() == 0 {
+				continue
+			}
+			if name == "stream.reset" {
+				return Event{}, ErrStreamReset
+			}
+			var e Event
+			if err := json.Unmarshal([]byte(data.String()), &e); err != nil {
+				return Event{}, fmt.Errorf("client: decode event %s: %w", id, err)
+			}
+			s.LastEventID = e.ID
+			return e, nil
+		case field == "":
+			// Комментарий (keepalive).
+		case field == "id":
+			id = value
+		case field == "event":
+			name = value
+		case field == "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		}
+	}
+}
+
+func (s *EventStream) Close() error {
+	return s.body.Close()
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"github.com/trainee/review-service/internal/events"
+	"github.com/trainee/review-service/internal/model"
+)
+
+// Типы API. Это псевдонимы типов сервиса, поэтому JSON клиента и сервера совпадает
+// по построению. Version заполняется из заголовка ETag ответа.
+type (
+	Team             = model.Team
+	TeamMember       = model.TeamMember
+	User             = model.User
+	UserDetails      = model.UserDetails
+	UserFilter       = model.UserFilter
+	UserPage         = model.UserPage
+	PullRequest      = model.PullRequest
+	PullRequestShort = model.PullRequestShort
+	PRStatus         = model.PRStatus
+	PRFilter         = model.PRFilter
+	PullRequestPage  = model.PullRequestPage
+	ReviewPage       = model.ReviewPage
+	PageRequest      = model.PageRequest
+	Role             = model.Role
+	RoleAssignment   = model.RoleAssignment
+	APIToken         = model.APIToken
+	ImportMode       = model.ImportMode
+	ImportOptions    = model.ImportOptions
+	ImportReport     = model.ImportReport
+	ImportRowError   = model.ImportRowError
+	RestoreReport    = model.RestoreReport
+	Event            = events.Event
+	EventType        = events.Type
+)
+
+const (
+	StatusOpen   = model.PROpen
+	StatusMerged = model.PRMerged
+	// StatusAll в GetUserReviews возвращает PR в любом статусе (по умолчанию — только OPEN).
+	StatusAll PRStatus = "ALL"
+
+	RoleOrgAdmin = model.RoleOrgAdmin
+	RoleTeamLead = model.RoleTeamLead
+
+	ImportAtomic     = model.ImportAtomic
+	ImportBestEffort = model.ImportBestEffort
+
+	EventPRAssigned        = events.PRAssigned
+	EventPRReassigned      = events.PRReassigned
+	EventPRMerged          = events.PRMerged
+	EventUserStatusChanged = events.UserStatusChanged
+)
+
+// ImportFormat — формат тела запроса импорта.
+type ImportFormat string
+
+const (
+	FormatJSONL ImportFormat = "jsonl"
+	FormatCSV   ImportFormat = "csv"
+)